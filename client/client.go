@@ -104,3 +104,34 @@ func (c *Client) GetServerMetadata(ctx context.Context) (*types.ServerMetadata,
 
 	return &metadata, nil
 }
+
+// GetHealth fetches the health of the MCPJungle server.
+// If verbose is true, the health of every registered MCP server is included.
+func (c *Client) GetHealth(ctx context.Context, verbose bool) (*types.HealthResponse, error) {
+	u := c.baseURL + "/health"
+	if verbose {
+		u += "?verbose=true"
+	}
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var health types.HealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, err
+	}
+
+	return &health, nil
+}
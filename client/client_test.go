@@ -1,11 +1,14 @@
 package client
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestNewClient(t *testing.T) {
@@ -354,3 +357,35 @@ func TestParseErrorResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHealthVerbose(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			t.Errorf("Expected path /health, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("verbose") != "true" {
+			t.Errorf("Expected verbose=true query parameter, got %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"degraded","servers":{"github":{"status":"unhealthy","last_error":"connection refused","latency_ms":12}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", &http.Client{})
+	health, err := client.GetHealth(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if health.Status != "degraded" {
+		t.Errorf("Expected status degraded, got %s", health.Status)
+	}
+	s, ok := health.Servers["github"]
+	if !ok {
+		t.Fatalf("Expected health of server github in response")
+	}
+	if s.Status != types.HealthStatusUnhealthy || s.LastError != "connection refused" || s.LatencyMs != 12 {
+		t.Errorf("Unexpected server health: %+v", s)
+	}
+}
@@ -1,10 +1,15 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -65,6 +70,31 @@ func (c *Client) ListServers() ([]*types.McpServer, error) {
 	return servers, nil
 }
 
+// GetServer fetches a registered server by name.
+func (c *Client) GetServer(name string) (*types.McpServer, error) {
+	u, _ := c.constructAPIEndpoint("/servers/" + name)
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var server types.McpServer
+	if err := json.NewDecoder(resp.Body).Decode(&server); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &server, nil
+}
+
 // DeregisterServer deletes a server by name.
 func (c *Client) DeregisterServer(name string) error {
 	u, _ := c.constructAPIEndpoint("/servers/" + name)
@@ -126,3 +156,91 @@ func (c *Client) setServerEnabled(name string, enabled bool) (*types.EnableDisab
 
 	return &result, nil
 }
+
+// GetServerLogs retrieves up to n of the most recent lines a stdio MCP server wrote to stderr, oldest first.
+// If n is 0, all lines kept by mcpjungle are returned.
+func (c *Client) GetServerLogs(name string, n int) ([]types.ServerLogLine, error) {
+	u, err := c.serverLogsEndpoint(name, n, false)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var lines []types.ServerLogLine
+	if err := json.NewDecoder(resp.Body).Decode(&lines); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return lines, nil
+}
+
+// FollowServerLogs streams the stderr output of a stdio MCP server, starting with up to n of the most recent lines.
+// fn is called for every line. It blocks until ctx is cancelled or the server stops the stream,
+// eg- because the MCP server was deregistered.
+func (c *Client) FollowServerLogs(ctx context.Context, name string, n int, fn func(types.ServerLogLine)) error {
+	u, err := c.serverLogsEndpoint(name, n, true)
+	if err != nil {
+		return err
+	}
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.parseErrorResponse(resp)
+	}
+
+	// every log line is sent as a server-sent event with a single JSON data line
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		var l types.ServerLogLine
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &l); err != nil {
+			return fmt.Errorf("failed to decode log line: %w", err)
+		}
+		fn(l)
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs stream: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) serverLogsEndpoint(name string, n int, follow bool) (string, error) {
+	u, err := c.constructAPIEndpoint("/servers/" + name + "/logs")
+	if err != nil {
+		return "", fmt.Errorf("failed to construct API endpoint: %w", err)
+	}
+	parsed, _ := url.Parse(u)
+	q := parsed.Query()
+	q.Set("lines", strconv.Itoa(n))
+	if follow {
+		q.Set("follow", "true")
+	}
+	parsed.RawQuery = q.Encode()
+	return parsed.String(), nil
+}
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -222,6 +223,56 @@ func TestListServers(t *testing.T) {
 	})
 }
 
+func TestGetServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful get", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				t.Errorf("Expected GET method, got %s", r.Method)
+			}
+			expectedPath := "/api/v0/servers/filesystem"
+			if !strings.HasSuffix(r.URL.Path, expectedPath) {
+				t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(&types.McpServer{
+				Name:      "filesystem",
+				Transport: "stdio",
+				Command:   "npx",
+				StdioSupervision: types.StdioSupervision{
+					Sandbox: &types.StdioSandbox{Enabled: true, WritablePaths: []string{"/data"}},
+				},
+			})
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		s, err := client.GetServer("filesystem")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s.Name != "filesystem" || !s.Sandbox.IsEnabled() || len(s.Sandbox.WritablePaths) != 1 {
+			t.Errorf("Unexpected server: %+v", s)
+		}
+	})
+
+	t.Run("server not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("MCP server non-existent-server not found"))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		if _, err := client.GetServer("non-existent-server"); err == nil {
+			t.Error("Expected error, got nil")
+		}
+	})
+}
+
 func TestDeregisterServer(t *testing.T) {
 	t.Parallel()
 
@@ -289,3 +340,74 @@ func TestDeregisterServer(t *testing.T) {
 		}
 	})
 }
+
+func TestGetServerLogs(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/api/v0/servers/filesystem/logs"
+		if !strings.HasSuffix(r.URL.Path, expectedPath) {
+			t.Errorf("Expected path to end with %s, got %s", expectedPath, r.URL.Path)
+		}
+		if r.URL.Query().Get("lines") != "2" {
+			t.Errorf("Expected lines=2 query parameter, got %q", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("follow") != "" {
+			t.Errorf("Expected no follow query parameter, got %q", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]types.ServerLogLine{{Line: "starting"}, {Line: "ready"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	lines, err := client.GetServerLogs("filesystem", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(lines) != 2 || lines[0].Line != "starting" || lines[1].Line != "ready" {
+		t.Errorf("Unexpected log lines: %+v", lines)
+	}
+}
+
+func TestFollowServerLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams lines", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("follow") != "true" {
+				t.Errorf("Expected follow=true query parameter, got %q", r.URL.RawQuery)
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("event:log\ndata:{\"line\":\"starting\"}\n\nevent:log\ndata:{\"line\":\"ready\"}\n\n"))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		var got []string
+		err := client.FollowServerLogs(context.Background(), "filesystem", 10, func(l types.ServerLogLine) {
+			got = append(got, l.Line)
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if strings.Join(got, ",") != "starting,ready" {
+			t.Errorf("Unexpected log lines: %v", got)
+		}
+	})
+
+	t.Run("non-stdio server", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"logs are only available for stdio MCP servers"}`))
+		}))
+		defer server.Close()
+
+		client := NewClient(server.URL, "test-token", &http.Client{})
+		err := client.FollowServerLogs(context.Background(), "remote", 10, func(types.ServerLogLine) {})
+		if err == nil || !strings.Contains(err.Error(), "only available for stdio") {
+			t.Errorf("Expected unsupported error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// ListRateLimits returns all rate limits and quotas configured in mcpjungle.
+func (c *Client) ListRateLimits() ([]types.RateLimit, error) {
+	u, _ := c.constructAPIEndpoint("/rate-limits")
+
+	req, err := c.newRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var limits []types.RateLimit
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return limits, nil
+}
+
+// SetRateLimit creates a rate limit, or replaces the existing one for the same scope and target.
+func (c *Client) SetRateLimit(limit *types.RateLimit) error {
+	u, _ := c.constructAPIEndpoint("/rate-limits")
+
+	body, err := json.Marshal(limit)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rate limit: %w", err)
+	}
+
+	req, err := c.newRequest(http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return c.parseErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// DeleteRateLimit removes the rate limit for the given scope and target.
+func (c *Client) DeleteRateLimit(scope, target string) error {
+	u, _ := c.constructAPIEndpoint("/rate-limits/" + url.PathEscape(scope) + "/" + url.PathEscape(target))
+
+	req, err := c.newRequest(http.MethodDelete, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		return c.parseErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	t.Parallel()
+
+	limit := &types.RateLimit{Scope: "client", Target: "agent", RequestsPerMinute: 30, DailyQuota: 1000}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v0/rate-limits" {
+			t.Errorf("Expected path /api/v0/rate-limits, got %s", r.URL.Path)
+		}
+
+		var got types.RateLimit
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if got != *limit {
+			t.Errorf("Expected rate limit %+v, got %+v", *limit, got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(got)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	if err := client.SetRateLimit(limit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestListRateLimits(t *testing.T) {
+	t.Parallel()
+
+	expected := []types.RateLimit{
+		{Scope: "server", Target: "github", RequestsPerMinute: 100, Burst: 10},
+		{Scope: "tool", Target: "github__search_code", RequestsPerMinute: 10},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	limits, err := client.ListRateLimits()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(limits) != len(expected) {
+		t.Fatalf("Expected %d rate limits, got %d", len(expected), len(limits))
+	}
+	for i := range limits {
+		if limits[i] != expected[i] {
+			t.Errorf("Expected limits[%d] = %+v, got %+v", i, expected[i], limits[i])
+		}
+	}
+}
+
+func TestDeleteRateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected DELETE method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v0/rate-limits/tool/github__search_code" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	if err := client.DeleteRateLimit("tool", "github__search_code"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
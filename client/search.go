@@ -0,0 +1,53 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// Search searches tools, prompts, MCP servers and tool groups for the given query.
+// The search is limited to the given kinds of entities, or covers all kinds if none are given.
+// A maxResults of 0 lets the server apply its default.
+func (c *Client) Search(query string, kinds []types.SearchKind, maxResults int) (*types.SearchResponse, error) {
+	u, err := c.constructAPIEndpoint("/search")
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
+	}
+	parsed, _ := url.Parse(u)
+	q := parsed.Query()
+	q.Set("q", query)
+	for _, k := range kinds {
+		q.Add("kind", string(k))
+	}
+	if maxResults > 0 {
+		q.Set("max_results", strconv.Itoa(maxResults))
+	}
+	parsed.RawQuery = q.Encode()
+
+	req, err := c.newRequest(http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", parsed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var res types.SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &res, nil
+}
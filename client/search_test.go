@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	expected := types.SearchResponse{
+		Query: "review",
+		Kinds: []types.SearchKind{types.SearchKindPrompt, types.SearchKindServer},
+		Results: []types.SearchResult{
+			{Kind: types.SearchKindPrompt, Name: "github__review_pr", ServerName: "github", Score: 1.5, Enabled: true},
+		},
+		Count: 1,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v0/search" {
+			t.Errorf("Expected path /api/v0/search, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "review" || !slices.Equal(q["kind"], []string{"prompt", "server"}) || q.Get("max_results") != "5" {
+			t.Errorf("Unexpected query parameters: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	res, err := client.Search("review", expected.Kinds, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if res.Count != 1 || len(res.Results) != 1 || res.Results[0] != expected.Results[0] {
+		t.Errorf("Unexpected results: %+v", res.Results)
+	}
+}
+
+func TestSearchError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "unsupported search kind: resource"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	_, err := client.Search("review", []types.SearchKind{"resource"}, 0)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+}
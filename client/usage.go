@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// GetUsageReport returns the tool usage since the given time, aggregated by tool, client or server.
+// since can be a number of days (eg- "7d"), a duration (eg- "12h") or an RFC3339 timestamp.
+// Empty values let the server apply its defaults. A limit of 0 returns all rows.
+func (c *Client) GetUsageReport(since string, by types.UsageGroupBy, limit int) (*types.UsageReport, error) {
+	u, err := c.constructAPIEndpoint("/usage")
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct API endpoint: %w", err)
+	}
+	parsed, _ := url.Parse(u)
+	q := parsed.Query()
+	if since != "" {
+		q.Set("since", since)
+	}
+	if by != "" {
+		q.Set("by", string(by))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	parsed.RawQuery = q.Encode()
+
+	req, err := c.newRequest(http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request to %s: %w", parsed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, c.parseErrorResponse(resp)
+	}
+
+	var report types.UsageReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &report, nil
+}
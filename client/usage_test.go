@@ -0,0 +1,68 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestGetUsageReport(t *testing.T) {
+	t.Parallel()
+
+	expected := types.UsageReport{
+		GroupBy: types.UsageGroupByClient,
+		Rows: []types.UsageRow{
+			{Name: "agent", Calls: 12, Outcomes: map[string]int64{"success": 11, "error": 1}, AvgDurationMs: 40},
+		},
+		UnusedTools: []string{"github__get_issue"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v0/usage" {
+			t.Errorf("Expected path /api/v0/usage, got %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("since") != "30d" || q.Get("by") != "client" || q.Get("limit") != "5" {
+			t.Errorf("Unexpected query parameters: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(expected)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	report, err := client.GetUsageReport("30d", types.UsageGroupByClient, 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Rows) != 1 || report.Rows[0].Name != "agent" || report.Rows[0].Outcomes["error"] != 1 {
+		t.Errorf("Unexpected rows: %+v", report.Rows)
+	}
+	if len(report.UnusedTools) != 1 || report.UnusedTools[0] != "github__get_issue" {
+		t.Errorf("Unexpected unused tools: %v", report.UnusedTools)
+	}
+}
+
+func TestGetUsageReportError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid number of days: xd"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", &http.Client{})
+	if _, err := client.GetUsageReport("xd", "", 0); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+}
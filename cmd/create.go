@@ -57,12 +57,32 @@ var createToolGroupCmd = &cobra.Command{
 	RunE: runCreateToolGroup,
 }
 
+var createRateLimitCmd = &cobra.Command{
+	Use:   "rate-limit [scope] [target]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Create or replace a rate limit",
+	Long: "Limit the rate at which tools can be called through the MCP proxy.\n" +
+		"A rate limit applies to one of the following scopes:\n" +
+		"  - client: all tool calls made by an MCP client (target is the client name)\n" +
+		"  - tool: all calls of a tool (target is the canonical tool name, eg- github__search_code)\n" +
+		"  - server: all tool calls proxied to an upstream MCP server (target is the server name)\n\n" +
+		"MCP clients can additionally be given daily and monthly call quotas (UTC).\n" +
+		"If a rate limit already exists for the scope and target, it is replaced.\n" +
+		"Calls that exceed a limit receive an error result telling the client when to retry.",
+	RunE: runCreateRateLimit,
+}
+
 var (
 	createMcpClientCmdAllowedServers string
 	createMcpClientCmdAllowedGroups  string
 	createMcpClientCmdDescription    string
 
 	createToolGroupConfigFilePath string
+
+	createRateLimitCmdRequestsPerMinute int
+	createRateLimitCmdBurst             int
+	createRateLimitCmdDailyQuota        int64
+	createRateLimitCmdMonthlyQuota      int64
 )
 
 func init() {
@@ -98,7 +118,33 @@ func init() {
 
 	createCmd.AddCommand(createMcpClientCmd)
 	createCmd.AddCommand(createUserCmd)
+	createRateLimitCmd.Flags().IntVar(
+		&createRateLimitCmdRequestsPerMinute,
+		"rpm",
+		0,
+		"Number of calls allowed per minute. 0 means the rate is not limited.",
+	)
+	createRateLimitCmd.Flags().IntVar(
+		&createRateLimitCmdBurst,
+		"burst",
+		0,
+		"Maximum number of calls allowed in quick succession. Defaults to the value of --rpm.",
+	)
+	createRateLimitCmd.Flags().Int64Var(
+		&createRateLimitCmdDailyQuota,
+		"daily-quota",
+		0,
+		"Maximum number of calls allowed per day (client scope only).",
+	)
+	createRateLimitCmd.Flags().Int64Var(
+		&createRateLimitCmdMonthlyQuota,
+		"monthly-quota",
+		0,
+		"Maximum number of calls allowed per month (client scope only).",
+	)
+
 	createCmd.AddCommand(createToolGroupCmd)
+	createCmd.AddCommand(createRateLimitCmd)
 
 	rootCmd.AddCommand(createCmd)
 }
@@ -209,3 +255,25 @@ func runCreateToolGroup(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runCreateRateLimit(cmd *cobra.Command, args []string) error {
+	scope, err := types.ValidateRateLimitScope(args[0])
+	if err != nil {
+		return err
+	}
+
+	limit := &types.RateLimit{
+		Scope:             string(scope),
+		Target:            args[1],
+		RequestsPerMinute: createRateLimitCmdRequestsPerMinute,
+		Burst:             createRateLimitCmdBurst,
+		DailyQuota:        createRateLimitCmdDailyQuota,
+		MonthlyQuota:      createRateLimitCmdMonthlyQuota,
+	}
+	if err := apiClient.SetRateLimit(limit); err != nil {
+		return fmt.Errorf("failed to create rate limit: %w", err)
+	}
+
+	cmd.Printf("Rate limit for %s '%s' saved successfully!\n", limit.Scope, limit.Target)
+	return nil
+}
@@ -23,7 +23,7 @@ func TestCreateCommandStructure(t *testing.T) {
 
 	// Test subcommands count
 	subcommands := createCmd.Commands()
-	testhelpers.AssertEqual(t, 3, len(subcommands))
+	testhelpers.AssertEqual(t, 4, len(subcommands))
 }
 
 func TestCreateMcpClientSubcommand(t *testing.T) {
@@ -131,7 +131,7 @@ func TestCreateCommandIntegration(t *testing.T) {
 
 	// Test all create subcommands are properly configured
 	subcommands := createCmd.Commands()
-	expectedSubcommands := []string{"mcp-client", "user", "group"}
+	expectedSubcommands := []string{"mcp-client", "user", "group", "rate-limit"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
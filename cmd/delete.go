@@ -44,10 +44,20 @@ var deleteToolGroupCmd = &cobra.Command{
 	RunE: runDeleteToolGroup,
 }
 
+var deleteRateLimitCmd = &cobra.Command{
+	Use:   "rate-limit [scope] [target]",
+	Args:  cobra.ExactArgs(2),
+	Short: "Delete a rate limit",
+	Long: "Delete the rate limit and quotas configured for a client, tool or server.\n" +
+		"Scope must be one of 'client', 'tool' or 'server'.",
+	RunE: runDeleteRateLimit,
+}
+
 func init() {
 	deleteCmd.AddCommand(deleteMcpClientCmd)
 	deleteCmd.AddCommand(deleteUserCmd)
 	deleteCmd.AddCommand(deleteToolGroupCmd)
+	deleteCmd.AddCommand(deleteRateLimitCmd)
 
 	rootCmd.AddCommand(deleteCmd)
 }
@@ -78,3 +88,12 @@ func runDeleteToolGroup(cmd *cobra.Command, args []string) error {
 	cmd.Printf("Tool group '%s' deleted successfully!\n", name)
 	return nil
 }
+
+func runDeleteRateLimit(cmd *cobra.Command, args []string) error {
+	scope, target := args[0], args[1]
+	if err := apiClient.DeleteRateLimit(scope, target); err != nil {
+		return fmt.Errorf("failed to delete the rate limit: %w", err)
+	}
+	cmd.Printf("Rate limit for %s '%s' deleted successfully!\n", scope, target)
+	return nil
+}
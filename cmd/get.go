@@ -3,14 +3,16 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
 var getCmd = &cobra.Command{
 	Use:   "get",
-	Short: "Get entities like Prompts and Tool Groups",
+	Short: "Get entities like Servers, Prompts and Tool Groups",
 	Annotations: map[string]string{
 		"group": string(subCommandGroupAdvanced),
 		"order": "1",
@@ -28,6 +30,15 @@ var getGroupCmd = &cobra.Command{
 	RunE: runGetGroup,
 }
 
+var getServerCmd = &cobra.Command{
+	Use:   "server [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Get information about a specific MCP server",
+	Long: "Get information about a registered MCP server by name.\n" +
+		"This returns the configuration of the server, including how the process of a stdio server is run and sandboxed.\n",
+	RunE: runGetServer,
+}
+
 var getPromptCmd = &cobra.Command{
 	Use:   "prompt [name]",
 	Args:  cobra.ExactArgs(1),
@@ -50,6 +61,7 @@ func init() {
 		"Arguments to pass to the prompt (this flag can be specified multiple times)",
 	)
 
+	getCmd.AddCommand(getServerCmd)
 	getCmd.AddCommand(getGroupCmd)
 	getCmd.AddCommand(getPromptCmd)
 	rootCmd.AddCommand(getCmd)
@@ -77,6 +89,11 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 	cmd.Println(group.SSEMessageEndpoint)
 	cmd.Println()
 
+	if group.LazyToolLoading {
+		cmd.Println("Lazy tool loading: enabled (clients initially only see the meta-tools)")
+		cmd.Println()
+	}
+
 	if len(group.IncludedTools) == 0 {
 		cmd.Println("Included Tools: None")
 	} else {
@@ -96,9 +113,45 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 		for i, s := range group.IncludedServers {
 			cmd.Printf("%d. %s\n", i+1, s)
 		}
+		if group.IncludedServersFilter != nil {
+			cmd.Println("Only tools that are: " + group.IncludedServersFilter.String())
+		}
 	}
 	cmd.Println()
 
+	if len(group.ToolRules) > 0 {
+		cmd.Println("Tool Rules:")
+		for i, r := range group.ToolRules {
+			cmd.Printf("%d. %s\n", i+1, r.String())
+		}
+		cmd.Println()
+	}
+
+	if len(group.ToolAliases) > 0 {
+		cmd.Println("Tool Aliases:")
+		for i, a := range group.ToolAliases {
+			cmd.Printf("%d. %s\n", i+1, a.String())
+			if a.Description != "" {
+				cmd.Printf("   %s\n", a.Description)
+			}
+		}
+		cmd.Println()
+	}
+
+	if len(group.ToolArguments) > 0 {
+		cmd.Println("Tool Arguments:")
+		names := make([]string, 0, len(group.ToolArguments))
+		for name := range group.ToolArguments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			args := group.ToolArguments[name]
+			cmd.Printf("%d. %s: %s\n", i+1, name, args.String())
+		}
+		cmd.Println()
+	}
+
 	if len(group.ExcludedTools) == 0 {
 		cmd.Println("Excluded Tools: None")
 	} else {
@@ -117,6 +170,82 @@ func runGetGroup(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runGetServer(cmd *cobra.Command, args []string) error {
+	s, err := apiClient.GetServer(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get server: %w", err)
+	}
+
+	cmd.Println(s.Name)
+	if s.Description != "" {
+		cmd.Println()
+		cmd.Println("Description: " + s.Description)
+	}
+	cmd.Println()
+	cmd.Println("Transport: " + s.Transport)
+
+	t, _ := types.ValidateTransport(s.Transport)
+	if t != types.TransportStdio {
+		cmd.Println("URL: " + s.URL)
+	} else {
+		cmd.Println("Command: " + strings.TrimSpace(s.Command+" "+strings.Join(s.Args, " ")))
+		if len(s.Env) > 0 {
+			names := make([]string, 0, len(s.Env))
+			for k := range s.Env {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			cmd.Println("Environment variables: " + strings.Join(names, ", "))
+		}
+		if len(s.EnvAllowList) > 0 {
+			cmd.Println("Inherited environment variables: " + strings.Join(s.EnvAllowList, ", "))
+		}
+		if s.WorkDir != "" {
+			cmd.Println("Working directory: " + s.WorkDir)
+		}
+		if s.RunAsUser != "" || s.RunAsGroup != "" {
+			cmd.Println("Runs as: " + strings.Trim(s.RunAsUser+":"+s.RunAsGroup, ":"))
+		}
+		if l := s.ResourceLimits; !l.IsZero() {
+			cmd.Printf("Resource limits: cpu %ds, memory %dMB, open files %d\n", l.CPUSeconds, l.MemoryMB, l.OpenFiles)
+		}
+		if s.KillTimeoutSeconds > 0 {
+			cmd.Printf("Kill timeout: %ds\n", s.KillTimeoutSeconds)
+		}
+		cmd.Println("Sandbox: " + formatStdioSandbox(s.Sandbox))
+	}
+
+	if s.MaxConcurrency > 0 {
+		cmd.Printf("Max concurrency: %d\n", s.MaxConcurrency)
+	}
+	if s.CallTimeoutSeconds > 0 {
+		cmd.Printf("Call timeout: %ds\n", s.CallTimeoutSeconds)
+	}
+	if s.CircuitBreakerState != "" {
+		cmd.Println("Circuit breaker: " + s.CircuitBreakerState)
+	}
+	if s.Health != nil {
+		cmd.Println("Health: " + formatServerHealth(s.Health))
+	}
+	return nil
+}
+
+// formatStdioSandbox describes the sandbox of a stdio server in a single line.
+func formatStdioSandbox(sb *types.StdioSandbox) string {
+	if !sb.IsEnabled() {
+		return "disabled"
+	}
+	network := "loopback only"
+	if sb.AllowNetwork {
+		network = "allowed"
+	}
+	writable := "none"
+	if len(sb.WritablePaths) > 0 {
+		writable = strings.Join(sb.WritablePaths, ", ")
+	}
+	return fmt.Sprintf("enabled (network: %s, writable paths: %s)", network, writable)
+}
+
 func runGetPrompt(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
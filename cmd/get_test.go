@@ -4,12 +4,13 @@ import (
 	"testing"
 
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 func TestGetCommandStructure(t *testing.T) {
 	t.Run("command_properties", func(t *testing.T) {
 		testhelpers.AssertEqual(t, "get", getCmd.Use)
-		testhelpers.AssertEqual(t, "Get entities like Prompts and Tool Groups", getCmd.Short)
+		testhelpers.AssertEqual(t, "Get entities like Servers, Prompts and Tool Groups", getCmd.Short)
 	})
 
 	t.Run("command_annotations", func(t *testing.T) {
@@ -21,6 +22,26 @@ func TestGetCommandStructure(t *testing.T) {
 	})
 }
 
+func TestGetServerSubcommand(t *testing.T) {
+	testhelpers.AssertEqual(t, "server [name]", getServerCmd.Use)
+	testhelpers.AssertTrue(t, len(getServerCmd.Long) > 0, "Long description should not be empty")
+	testhelpers.AssertNotNil(t, getServerCmd.RunE)
+	testhelpers.AssertNotNil(t, getServerCmd.Args)
+}
+
+func TestFormatStdioSandbox(t *testing.T) {
+	testhelpers.AssertEqual(t, "disabled", formatStdioSandbox(nil))
+	testhelpers.AssertEqual(t, "disabled", formatStdioSandbox(&types.StdioSandbox{AllowNetwork: true}))
+	testhelpers.AssertEqual(t,
+		"enabled (network: loopback only, writable paths: none)",
+		formatStdioSandbox(&types.StdioSandbox{Enabled: true}),
+	)
+	testhelpers.AssertEqual(t,
+		"enabled (network: allowed, writable paths: /data, /cache)",
+		formatStdioSandbox(&types.StdioSandbox{Enabled: true, AllowNetwork: true, WritablePaths: []string{"/data", "/cache"}}),
+	)
+}
+
 func TestGetGroupSubcommand(t *testing.T) {
 	t.Run("command_properties", func(t *testing.T) {
 		testhelpers.AssertEqual(t, "group [name]", getGroupCmd.Use)
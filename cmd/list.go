@@ -70,6 +70,12 @@ var listGroupsCmd = &cobra.Command{
 	RunE:  runListGroups,
 }
 
+var listRateLimitsCmd = &cobra.Command{
+	Use:   "rate-limits",
+	Short: "List rate limits and quotas",
+	RunE:  runListRateLimits,
+}
+
 func init() {
 	listToolsCmd.Flags().StringVar(
 		&listToolsCmdServerName,
@@ -97,6 +103,7 @@ func init() {
 	listCmd.AddCommand(listMcpClientsCmd)
 	listCmd.AddCommand(listUsersCmd)
 	listCmd.AddCommand(listGroupsCmd)
+	listCmd.AddCommand(listRateLimitsCmd)
 
 	rootCmd.AddCommand(listCmd)
 }
@@ -219,6 +226,28 @@ func runListServers(cmd *cobra.Command, args []string) error {
 			if len(s.Env) > 0 {
 				fmt.Printf("Environment variables: %s\n", s.Env)
 			}
+			if s.WorkDir != "" {
+				fmt.Println("Working directory: " + s.WorkDir)
+			}
+			if s.RunAsUser != "" {
+				fmt.Println("Runs as user: " + s.RunAsUser)
+			}
+			if s.Sandbox.IsEnabled() {
+				fmt.Println("Sandbox: " + formatStdioSandbox(s.Sandbox))
+			}
+		}
+
+		if s.MaxConcurrency > 0 {
+			fmt.Printf("Max concurrency: %d\n", s.MaxConcurrency)
+		}
+		if s.RetryPolicy != nil && s.RetryPolicy.MaxAttempts > 1 {
+			fmt.Printf("Retries: up to %d attempts\n", s.RetryPolicy.MaxAttempts)
+		}
+		if s.CircuitBreakerState != "" {
+			fmt.Println("Circuit breaker: " + s.CircuitBreakerState)
+		}
+		if s.Health != nil {
+			fmt.Println("Health: " + formatServerHealth(s.Health))
 		}
 
 		if i < len(servers)-1 {
@@ -335,3 +364,37 @@ func runListPrompts(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runListRateLimits(cmd *cobra.Command, args []string) error {
+	limits, err := apiClient.ListRateLimits()
+	if err != nil {
+		return fmt.Errorf("failed to list rate limits: %w", err)
+	}
+
+	if len(limits) == 0 {
+		cmd.Println("There are no rate limits configured")
+		return nil
+	}
+	for i, l := range limits {
+		cmd.Printf("%d. %s %s\n", i+1, l.Scope, l.Target)
+		if l.RequestsPerMinute > 0 {
+			burst := l.Burst
+			if burst == 0 {
+				burst = l.RequestsPerMinute
+			}
+			cmd.Printf("Rate: %d calls/minute (burst %d)\n", l.RequestsPerMinute, burst)
+		}
+		if l.DailyQuota > 0 {
+			cmd.Printf("Daily quota: %d calls\n", l.DailyQuota)
+		}
+		if l.MonthlyQuota > 0 {
+			cmd.Printf("Monthly quota: %d calls\n", l.MonthlyQuota)
+		}
+
+		if i < len(limits)-1 {
+			cmd.Println()
+		}
+	}
+
+	return nil
+}
@@ -100,7 +100,7 @@ func TestListCommandIntegration(t *testing.T) {
 
 	// Test all list subcommands are properly configured
 	subcommands := listCmd.Commands()
-	expectedSubcommands := []string{"tools", "prompts", "servers", "mcp-clients", "users", "groups"}
+	expectedSubcommands := []string{"tools", "prompts", "servers", "mcp-clients", "users", "groups", "rate-limits"}
 
 	testhelpers.AssertEqual(t, len(expectedSubcommands), len(subcommands))
 
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsCmdFollow bool
+	logsCmdLines  int
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs [server]",
+	Short: "Show the logs of a STDIO MCP server",
+	Long: "Show the recent output that a STDIO MCP server wrote to its stderr.\n" +
+		"MCPJungle keeps the most recent lines of every STDIO server in memory.\n" +
+		"Use --follow to keep streaming new lines as the server writes them.",
+	Args: cobra.ExactArgs(1),
+	RunE: runLogs,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupBasic),
+		"order": "9",
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsCmdFollow, "follow", "f", false, "Keep streaming new log lines")
+	logsCmd.Flags().IntVarP(&logsCmdLines, "lines", "n", 100, "Number of recent lines to show (0 shows all)")
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if logsCmdLines < 0 {
+		return fmt.Errorf("--lines must not be negative")
+	}
+
+	if !logsCmdFollow {
+		lines, err := apiClient.GetServerLogs(name, logsCmdLines)
+		if err != nil {
+			return fmt.Errorf("failed to get logs of MCP server %s: %w", name, err)
+		}
+		if len(lines) == 0 {
+			cmd.Printf("MCP server %s has not written any logs yet\n", name)
+			return nil
+		}
+		for _, l := range lines {
+			cmd.Println(formatServerLogLine(l))
+		}
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err := apiClient.FollowServerLogs(ctx, name, logsCmdLines, func(l types.ServerLogLine) {
+		cmd.Println(formatServerLogLine(l))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to follow logs of MCP server %s: %w", name, err)
+	}
+	return nil
+}
+
+func formatServerLogLine(l types.ServerLogLine) string {
+	return l.Time.Local().Format(time.RFC3339) + " " + l.Line
+}
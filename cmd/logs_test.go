@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestLogsCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "logs [server]", logsCmd.Use)
+	testhelpers.AssertTrue(t, len(logsCmd.Long) > 0, "Long description should not be empty")
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupBasic)},
+		{Key: "order", Expected: "9"},
+	}
+	testhelpers.TestCommandAnnotations(t, logsCmd.Annotations, annotationTests)
+
+	testhelpers.AssertNotNil(t, logsCmd.RunE)
+	testhelpers.AssertNotNil(t, logsCmd.Args)
+
+	followFlag := logsCmd.Flags().Lookup("follow")
+	testhelpers.AssertNotNil(t, followFlag)
+	testhelpers.AssertEqual(t, "f", followFlag.Shorthand)
+
+	linesFlag := logsCmd.Flags().Lookup("lines")
+	testhelpers.AssertNotNil(t, linesFlag)
+	testhelpers.AssertEqual(t, "n", linesFlag.Shorthand)
+	testhelpers.AssertEqual(t, "100", linesFlag.DefValue)
+}
+
+func TestFormatServerLogLine(t *testing.T) {
+	t.Parallel()
+
+	l := types.ServerLogLine{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), Line: "server ready"}
+	s := formatServerLogLine(l)
+	testhelpers.AssertTrue(t, strings.HasSuffix(s, " server ready"), "log line should end with the text")
+	testhelpers.AssertTrue(t, strings.HasPrefix(s, l.Time.Local().Format(time.RFC3339)), "log line should start with the time")
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/spf13/cobra"
+)
+
+// rlimitExecCmd is an internal command that mcpjungle runs to start a stdio server with resource limits.
+// It sets the limits and then executes the server's command.
+var rlimitExecCmd = &cobra.Command{
+	Use:    rlimit.HelperCommand + " [flags] -- command [args...]",
+	Short:  "Run a command with resource limits (internal use only)",
+	Hidden: true,
+
+	// the flags are parsed by the rlimit package, the command's own flags must be passed through untouched
+	DisableFlagParsing: true,
+	// no API client is needed
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rlimit.Exec(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rlimitExecCmd)
+}
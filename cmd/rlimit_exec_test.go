@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestRlimitExecCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, rlimit.HelperCommand, rlimitExecCmd.Name())
+	testhelpers.AssertTrue(t, rlimitExecCmd.Hidden, "the rlimit helper command should be hidden")
+	testhelpers.AssertTrue(t, rlimitExecCmd.DisableFlagParsing, "the rlimit helper command should not parse flags")
+	testhelpers.AssertNotNil(t, rlimitExecCmd.RunE)
+}
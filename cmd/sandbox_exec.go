@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/mcpjungle/mcpjungle/internal/sandbox"
+	"github.com/spf13/cobra"
+)
+
+// sandboxExecCmd is an internal command that mcpjungle runs inside the namespaces of a sandboxed stdio server.
+// It sets up the sandbox and then executes the server's command.
+var sandboxExecCmd = &cobra.Command{
+	Use:    sandbox.HelperCommand + " [flags] -- command [args...]",
+	Short:  "Run a command in a sandbox (internal use only)",
+	Hidden: true,
+
+	// the flags are parsed by the sandbox package, the command's own flags must be passed through untouched
+	DisableFlagParsing: true,
+	// no API client is needed
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return sandbox.Exec(args)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sandboxExecCmd)
+}
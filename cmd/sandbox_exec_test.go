@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/sandbox"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func TestSandboxExecCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, sandbox.HelperCommand, sandboxExecCmd.Name())
+	testhelpers.AssertTrue(t, sandboxExecCmd.Hidden, "the sandbox helper command should be hidden")
+	testhelpers.AssertTrue(t, sandboxExecCmd.DisableFlagParsing, "the sandbox helper command should not parse flags")
+	testhelpers.AssertNotNil(t, sandboxExecCmd.RunE)
+
+	// hidden commands must not break the grouping of commands in the help message
+	_, err := groupCommands(rootCmd.Commands())
+	testhelpers.AssertNoError(t, err)
+}
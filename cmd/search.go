@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchCmdKinds      []string
+	searchCmdMaxResults int
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search tools, prompts, MCP servers and tool groups",
+	Long: "Search the tools, prompts, MCP servers and tool groups registered in MCPJungle by keywords.\n" +
+		"Results are ranked by how well their names and descriptions match the query.\n" +
+		"In enterprise mode, only admins can search tool groups.",
+	Example: `  mcpjungle search "pull request"
+  mcpjungle search review --kind prompt
+  mcpjungle search github --kind server,tool_group --max-results 5`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupBasic),
+		"order": "10",
+	},
+}
+
+func init() {
+	searchCmd.Flags().StringSliceVar(
+		&searchCmdKinds,
+		"kind",
+		nil,
+		"Only search entities of these kinds: 'tool', 'prompt', 'server' or 'tool_group' (default: all kinds)",
+	)
+	searchCmd.Flags().IntVar(
+		&searchCmdMaxResults,
+		"max-results",
+		20,
+		"Maximum number of results to show (1-100)",
+	)
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	kinds, err := types.ValidateSearchKinds(searchCmdKinds)
+	if err != nil {
+		return err
+	}
+	if len(searchCmdKinds) == 0 {
+		// let the server decide which kinds the user is allowed to search
+		kinds = nil
+	}
+
+	query := strings.Join(args, " ")
+	res, err := apiClient.Search(query, kinds, searchCmdMaxResults)
+	if err != nil {
+		return fmt.Errorf("failed to search: %w", err)
+	}
+	printSearchResults(cmd, res)
+	return nil
+}
+
+// printSearchResults prints search results as a table, best match first.
+func printSearchResults(cmd *cobra.Command, res *types.SearchResponse) {
+	if len(res.Results) == 0 {
+		cmd.Printf("Nothing found matching '%s'\n", res.Query)
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "#\tKIND\tNAME\tDESCRIPTION")
+	for i, r := range res.Results {
+		name := r.Name
+		if !r.Enabled {
+			name += " (disabled)"
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, r.Kind, name, firstLine(r.Description))
+	}
+	_ = w.Flush()
+}
+
+// firstLine returns the first line of a possibly multi-line text.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+func TestSearchCommandStructure(t *testing.T) {
+	if searchCmd.Use != "search <query>" {
+		t.Errorf("Expected search command Use to be 'search <query>', got %s", searchCmd.Use)
+	}
+	if searchCmd.Annotations["group"] != string(subCommandGroupBasic) {
+		t.Errorf("Expected search command group to be 'basic', got %s", searchCmd.Annotations["group"])
+	}
+	if searchCmd.RunE == nil {
+		t.Fatal("Search command missing RunE function")
+	}
+
+	tests := []struct {
+		flag     string
+		defValue string
+	}{
+		{"kind", "[]"},
+		{"max-results", "20"},
+	}
+	for _, tt := range tests {
+		f := searchCmd.Flags().Lookup(tt.flag)
+		if f == nil {
+			t.Fatalf("search command is missing the --%s flag", tt.flag)
+		}
+		if f.DefValue != tt.defValue {
+			t.Errorf("Expected default value of --%s to be %s, got %s", tt.flag, tt.defValue, f.DefValue)
+		}
+	}
+}
+
+func TestPrintSearchResults(t *testing.T) {
+	res := &types.SearchResponse{
+		Query: "review",
+		Results: []types.SearchResult{
+			{
+				Kind:        types.SearchKindPrompt,
+				Name:        "github__review_pr",
+				ServerName:  "github",
+				Description: "Review a pull request\nUse this prompt to review code",
+				Enabled:     true,
+			},
+			{Kind: types.SearchKindTool, Name: "github__request_review", ServerName: "github"},
+		},
+	}
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	printSearchResults(cmd, res)
+
+	out := output.String()
+	for _, want := range []string{"KIND", "prompt", "github__review_pr", "Review a pull request", "github__request_review (disabled)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Use this prompt") {
+		t.Errorf("Expected only the first line of descriptions, got:\n%s", out)
+	}
+}
+
+func TestPrintSearchResultsWithoutResults(t *testing.T) {
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	printSearchResults(cmd, &types.SearchResponse{Query: "nothing"})
+
+	if !strings.Contains(output.String(), "Nothing found matching 'nothing'") {
+		t.Errorf("Unexpected output:\n%s", output.String())
+	}
+}
@@ -1,23 +1,31 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/mark3labs/mcp-go/server"
+	clientconfig "github.com/mcpjungle/mcpjungle/cmd/config"
 	"github.com/mcpjungle/mcpjungle/internal/api"
 	"github.com/mcpjungle/mcpjungle/internal/db"
 	"github.com/mcpjungle/mcpjungle/internal/migrations"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/sandbox"
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
+	"github.com/mcpjungle/mcpjungle/internal/service/search"
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -28,8 +36,36 @@ const (
 	DBUrlEnvVar            = "DATABASE_URL"
 	ServerModeEnvVar       = "SERVER_MODE"
 	TelemetryEnabledEnvVar = "OTEL_ENABLED"
+	ToolCallTimeoutEnvVar  = "TOOL_CALL_TIMEOUT"
+
+	HealthCheckIntervalEnvVar = "HEALTH_CHECK_INTERVAL"
+	HideUnhealthyToolsEnvVar  = "HIDE_UNHEALTHY_TOOLS"
+	CriticalServersEnvVar     = "CRITICAL_SERVERS"
+
+	RequireStdioSandboxEnvVar = "REQUIRE_STDIO_SANDBOX"
+
+	LazyToolLoadingEnvVar = "LAZY_TOOL_LOADING"
+
+	LogLevelEnvVar = "LOG_LEVEL"
+
+	TracesExporterEnvVar = "OTEL_TRACES_EXPORTER"
+	TracesFileEnvVar     = "OTEL_TRACES_FILE"
+
+	EmbeddingProviderEnvVar = "EMBEDDING_PROVIDER"
+	EmbeddingURLEnvVar      = "EMBEDDING_URL"
+	EmbeddingModelEnvVar    = "EMBEDDING_MODEL"
+	EmbeddingAPIKeyEnvVar   = "EMBEDDING_API_KEY"
 )
 
+// Embedding providers supported for semantic tool search
+const (
+	embeddingProviderLocal  = "local"
+	embeddingProviderOpenAI = "openai"
+)
+
+// defaultTracesFile is the file traces are written to by the file exporter if no file is specified
+const defaultTracesFile = "mcpjungle-traces.jsonl"
+
 const (
 	PostgresHostEnvVar     = "POSTGRES_HOST"
 	PostgresPortEnvVar     = "POSTGRES_PORT"
@@ -42,6 +78,24 @@ var (
 	startServerCmdBindPort          string
 	startServerCmdEnterpriseEnabled bool
 	startServerCmdProdEnabled       bool
+	startServerCmdToolCallTimeout   string
+
+	startServerCmdHealthCheckInterval string
+	startServerCmdHideUnhealthyTools  bool
+	startServerCmdCriticalServers     []string
+
+	startServerCmdRequireStdioSandbox bool
+
+	startServerCmdLazyToolLoading bool
+
+	startServerCmdLogLevel string
+
+	startServerCmdTracesExporter string
+	startServerCmdTracesFile     string
+
+	startServerCmdEmbeddingProvider string
+	startServerCmdEmbeddingURL      string
+	startServerCmdEmbeddingModel    string
 )
 
 var startServerCmd = &cobra.Command{
@@ -85,6 +139,139 @@ func init() {
 		false,
 		"[DEPRECATED] Alias for --enterprise flag.",
 	)
+	startServerCmd.Flags().StringVar(
+		&startServerCmdToolCallTimeout,
+		"tool-call-timeout",
+		"",
+		fmt.Sprintf(
+			"Default maximum duration of a tool call, eg- 90s, 5m (default %s)."+
+				" MCP servers and tools can override it. Set to 0 to disable. Alternatively, set the %s environment variable",
+			mcp.DefaultToolCallTimeout, ToolCallTimeoutEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().StringVar(
+		&startServerCmdHealthCheckInterval,
+		"health-check-interval",
+		"",
+		fmt.Sprintf(
+			"Enable periodic health checks of the registered MCP servers with the given interval, eg- 30s, 5m."+
+				" Health checks are disabled by default. STDIO servers are checked at most every %s or the interval,"+
+				" whichever is longer. Alternatively, set the %s environment variable",
+			mcp.DefaultStdioHealthCheckInterval, HealthCheckIntervalEnvVar,
+		),
+	)
+	startServerCmd.Flags().BoolVar(
+		&startServerCmdHideUnhealthyTools,
+		"hide-unhealthy-tools",
+		false,
+		fmt.Sprintf(
+			"Hide the tools of MCP servers that keep failing health checks until they recover."+
+				" Alternatively, set the %s environment variable to true",
+			HideUnhealthyToolsEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().StringSliceVar(
+		&startServerCmdCriticalServers,
+		"critical-servers",
+		nil,
+		fmt.Sprintf(
+			"Comma-separated names of MCP servers that must be healthy for the /readyz endpoint to report ready."+
+				" Alternatively, set the %s environment variable",
+			CriticalServersEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().BoolVar(
+		&startServerCmdRequireStdioSandbox,
+		"require-stdio-sandbox",
+		false,
+		fmt.Sprintf(
+			"Only allow registering stdio MCP servers that run in a sandbox (Linux only)."+
+				" Alternatively, set the %s environment variable to true",
+			RequireStdioSandboxEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().BoolVar(
+		&startServerCmdLazyToolLoading,
+		"lazy-tool-loading",
+		false,
+		fmt.Sprintf(
+			"Only list mcpjungle's meta-tools at the global MCP endpoints, clients load other tools on demand."+
+				" Alternatively, set the %s environment variable to true",
+			LazyToolLoadingEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().StringVar(
+		&startServerCmdLogLevel,
+		"log-level",
+		"",
+		fmt.Sprintf(
+			"Minimum level of logged messages: debug, info, warn or error (default info)."+
+				" It can also be changed at runtime via the API. Alternatively, set the %s environment variable",
+			LogLevelEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().StringVar(
+		&startServerCmdTracesExporter,
+		"traces-exporter",
+		"",
+		fmt.Sprintf(
+			"Export OpenTelemetry traces of the requests proxied by mcpjungle: none, otlp, stdout or file (default none)."+
+				" The OTLP exporter is configured with the standard OTEL_EXPORTER_OTLP_* environment variables."+
+				" Alternatively, set the %s environment variable",
+			TracesExporterEnvVar,
+		),
+	)
+	startServerCmd.Flags().StringVar(
+		&startServerCmdTracesFile,
+		"traces-file",
+		"",
+		fmt.Sprintf(
+			"File to write traces to when using the file traces exporter (default %s)."+
+				" Alternatively, set the %s environment variable",
+			defaultTracesFile, TracesFileEnvVar,
+		),
+	)
+
+	startServerCmd.Flags().StringVar(
+		&startServerCmdEmbeddingProvider,
+		"embedding-provider",
+		"",
+		fmt.Sprintf(
+			"Provider of the embeddings used by semantic tool search: %s or %s (default %s)."+
+				" The %s provider works offline, the %s provider calls an OpenAI-compatible embeddings API."+
+				" Alternatively, set the %s environment variable",
+			embeddingProviderLocal, embeddingProviderOpenAI, embeddingProviderLocal,
+			embeddingProviderLocal, embeddingProviderOpenAI,
+			EmbeddingProviderEnvVar,
+		),
+	)
+	startServerCmd.Flags().StringVar(
+		&startServerCmdEmbeddingURL,
+		"embedding-url",
+		"",
+		fmt.Sprintf(
+			"Base URL of the OpenAI-compatible embeddings API (default %s)."+
+				" The API key is read from the %s environment variable."+
+				" Alternatively, set the %s environment variable",
+			search.DefaultOpenAIEmbeddingURL, EmbeddingAPIKeyEnvVar, EmbeddingURLEnvVar,
+		),
+	)
+	startServerCmd.Flags().StringVar(
+		&startServerCmdEmbeddingModel,
+		"embedding-model",
+		"",
+		fmt.Sprintf(
+			"Model used by the OpenAI-compatible embeddings API (default %s)."+
+				" Alternatively, set the %s environment variable",
+			search.DefaultOpenAIEmbeddingModel, EmbeddingModelEnvVar,
+		),
+	)
 
 	rootCmd.AddCommand(startServerCmd)
 }
@@ -168,6 +355,180 @@ func getBindPort() string {
 	return port
 }
 
+// getToolCallTimeout returns the default timeout for tool calls
+// precedence: command line flag > environment variable > default
+func getToolCallTimeout() (time.Duration, error) {
+	v := startServerCmdToolCallTimeout
+	if v == "" {
+		v = os.Getenv(ToolCallTimeoutEnvVar)
+	}
+	if v == "" {
+		return mcp.DefaultToolCallTimeout, nil
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid tool call timeout '%s', must be a duration like 90s or 5m", v)
+	}
+	return timeout, nil
+}
+
+// getHealthCheckInterval returns the time between health checks of the registered MCP servers.
+// A zero interval means that health checks are disabled, which is the default.
+// precedence: command line flag > environment variable > default
+func getHealthCheckInterval() (time.Duration, error) {
+	v := startServerCmdHealthCheckInterval
+	if v == "" {
+		v = os.Getenv(HealthCheckIntervalEnvVar)
+	}
+	if v == "" {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid health check interval '%s', must be a duration like 30s or 5m", v)
+	}
+	return interval, nil
+}
+
+// isHideUnhealthyToolsEnabled returns true if the tools of unhealthy MCP servers should be hidden.
+// The command line flag takes precedence over the environment variable.
+func isHideUnhealthyToolsEnabled() (bool, error) {
+	if startServerCmdHideUnhealthyTools {
+		return true, nil
+	}
+	return parseBoolEnv(HideUnhealthyToolsEnvVar)
+}
+
+// parseBoolEnv returns the value of a boolean environment variable.
+// An unset variable is false. Valid values are "true", "1", "false" and "0", case-insensitive.
+func parseBoolEnv(name string) (bool, error) {
+	switch v := strings.ToLower(os.Getenv(name)); v {
+	case "", "false", "0":
+		return false, nil
+	case "true", "1":
+		return true, nil
+	default:
+		return false, fmt.Errorf(
+			"invalid value for %s environment variable: '%s', valid values are 'true' or 'false'", name, v,
+		)
+	}
+}
+
+// isStdioSandboxRequired returns true if stdio MCP servers may only be registered with a sandbox.
+// The command line flag takes precedence over the environment variable.
+func isStdioSandboxRequired() (bool, error) {
+	if startServerCmdRequireStdioSandbox {
+		return true, nil
+	}
+	return parseBoolEnv(RequireStdioSandboxEnvVar)
+}
+
+// isLazyToolLoadingEnabled returns true if the global MCP proxy endpoints should run in lazy tool loading mode.
+// The command line flag takes precedence over the environment variable.
+func isLazyToolLoadingEnabled() (bool, error) {
+	if startServerCmdLazyToolLoading {
+		return true, nil
+	}
+	return parseBoolEnv(LazyToolLoadingEnvVar)
+}
+
+// newServerLogger creates the logger of the server.
+// In enterprise mode, logs are emitted as JSON so that log aggregators can ingest them.
+// precedence for the log level: command line flag > environment variable > info
+func newServerLogger(mode model.ServerMode) (logger.Logger, error) {
+	conf := logger.DefaultConfig()
+	if model.IsEnterpriseMode(mode) {
+		conf = logger.ProductionConfig()
+	}
+
+	level := startServerCmdLogLevel
+	if level == "" {
+		level = os.Getenv(LogLevelEnvVar)
+	}
+	if level != "" {
+		conf.Level = strings.ToLower(level)
+	}
+
+	l, err := logger.New(conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return l, nil
+}
+
+// getTracesExporter returns the exporter that traces are sent to.
+// precedence: command line flag > environment variable > default (none)
+func getTracesExporter() (telemetry.TraceExporter, error) {
+	v := startServerCmdTracesExporter
+	if v == "" {
+		v = os.Getenv(TracesExporterEnvVar)
+	}
+	e, err := telemetry.ValidateTraceExporter(v)
+	if err != nil {
+		return "", fmt.Errorf("invalid traces exporter: %w", err)
+	}
+	return e, nil
+}
+
+// getTracesFile returns the file that traces are written to when using the file exporter.
+// precedence: command line flag > environment variable > default
+func getTracesFile() string {
+	if startServerCmdTracesFile != "" {
+		return startServerCmdTracesFile
+	}
+	if v := os.Getenv(TracesFileEnvVar); v != "" {
+		return v
+	}
+	return defaultTracesFile
+}
+
+// getEmbedder returns the embedder used by semantic tool search.
+// precedence: command line flag > environment variable > default (local)
+func getEmbedder() (search.Embedder, error) {
+	provider := startServerCmdEmbeddingProvider
+	if provider == "" {
+		provider = os.Getenv(EmbeddingProviderEnvVar)
+	}
+	switch strings.ToLower(provider) {
+	case "", embeddingProviderLocal:
+		return search.NewLocalEmbedder(search.DefaultLocalEmbedderDimensions), nil
+	case embeddingProviderOpenAI:
+		url := startServerCmdEmbeddingURL
+		if url == "" {
+			url = os.Getenv(EmbeddingURLEnvVar)
+		}
+		model := startServerCmdEmbeddingModel
+		if model == "" {
+			model = os.Getenv(EmbeddingModelEnvVar)
+		}
+		apiKey, err := getEnvOrFile(EmbeddingAPIKeyEnvVar)
+		if err != nil {
+			return nil, err
+		}
+		return search.NewOpenAIEmbedder(url, model, apiKey), nil
+	default:
+		return nil, fmt.Errorf(
+			"invalid embedding provider '%s', valid values are '%s' and '%s'",
+			provider, embeddingProviderLocal, embeddingProviderOpenAI,
+		)
+	}
+}
+
+// getCriticalServers returns the names of the MCP servers that must be healthy for mcpjungle to be ready.
+// precedence: command line flag > environment variable
+func getCriticalServers() []string {
+	if len(startServerCmdCriticalServers) > 0 {
+		return startServerCmdCriticalServers
+	}
+	var servers []string
+	for _, name := range strings.Split(os.Getenv(CriticalServersEnvVar), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			servers = append(servers, name)
+		}
+	}
+	return servers
+}
+
 // getEnvOrFile returns the value of the given environment variable.
 // If the environment variable is not set, it checks for a corresponding
 // _FILE environment variable and reads the value from the file if it exists.
@@ -192,6 +553,23 @@ func getEnvOrFile(envVar string) (string, error) {
 	return "", nil
 }
 
+// hideFilesFromSandbox hides mcpjungle's own files from sandboxed stdio servers:
+// the embedded SQLite database, which contains the access tokens of users and MCP clients,
+// and the config file of the CLI, which contains its access token.
+func hideFilesFromSandbox(dsn string) error {
+	var paths []string
+	if dsn == "" {
+		paths = append(paths, db.SQLiteDBFiles()...)
+	}
+	if p, err := clientconfig.AbsPath(); err == nil {
+		paths = append(paths, p)
+	}
+	if err := sandbox.SetHiddenPaths(paths...); err != nil {
+		return fmt.Errorf("failed to hide files from sandboxed stdio servers: %w", err)
+	}
+	return nil
+}
+
 // getPostgresDSN constructs a Postgres DSN from individual Postgres-specific environment variables & files.
 // It is used to provide an alternative way to specify Postgres connection details
 // in case the user doesn't want to use a full DATABASE_URL.
@@ -248,14 +626,27 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	serverLogger, err := newServerLogger(desiredServerMode)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = serverLogger.Sync() }()
+
 	// Initialize metrics if enabled
 	telemetryEnabled, err := isTelemetryEnabled(desiredServerMode)
 	if err != nil {
 		return err
 	}
+	// Tracing is configured independently of metrics
+	tracesExporter, err := getTracesExporter()
+	if err != nil {
+		return err
+	}
 	otelConfig := &telemetry.Config{
-		ServiceName: "mcpjungle",
-		Enabled:     telemetryEnabled,
+		ServiceName:   "mcpjungle",
+		Enabled:       telemetryEnabled,
+		TraceExporter: tracesExporter,
+		TraceFile:     getTracesFile(),
 	}
 	otelProviders, err := telemetry.Init(cmd.Context(), otelConfig)
 	if err != nil {
@@ -301,6 +692,9 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := hideFilesFromSandbox(dsn); err != nil {
+		return err
+	}
 	// Migrations should ideally be decoupled from both the server and the startup phase
 	// (should be run as a separate command).
 	// However, for the user's convenience, we run them as part of startup command for now.
@@ -310,6 +704,35 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 
 	bindPort := getBindPort()
 
+	toolCallTimeout, err := getToolCallTimeout()
+	if err != nil {
+		return err
+	}
+	healthCheckInterval, err := getHealthCheckInterval()
+	if err != nil {
+		return err
+	}
+	hideUnhealthyTools, err := isHideUnhealthyToolsEnabled()
+	if err != nil {
+		return err
+	}
+	requireStdioSandbox, err := isStdioSandboxRequired()
+	if err != nil {
+		return err
+	}
+	lazyToolLoading, err := isLazyToolLoadingEnabled()
+	if err != nil {
+		return err
+	}
+	embedder, err := getEmbedder()
+	if err != nil {
+		return err
+	}
+	criticalServers := getCriticalServers()
+	if len(criticalServers) > 0 && healthCheckInterval == 0 {
+		return fmt.Errorf("critical servers require health checks, enable them with --health-check-interval")
+	}
+
 	// create the MCP proxy servers
 	mcpProxyServer := server.NewMCPServer(
 		"MCPJungle Proxy MCP Server",
@@ -324,17 +747,39 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 		server.WithPromptCapabilities(true),
 	)
 
-	mcpService, err := mcp.NewMCPService(dbConn, mcpProxyServer, sseMcpProxyServer, mcpMetrics)
+	mcpService, err := mcp.NewMCPService(dbConn, mcpProxyServer, sseMcpProxyServer, mcpMetrics, serverLogger)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP service: %v", err)
 	}
+	mcpService.SetDefaultToolCallTimeout(toolCallTimeout)
+	mcpService.SetRequireStdioSandbox(requireStdioSandbox)
+	mcpService.SetLazyToolLoading(lazyToolLoading)
+	mcpService.GetSearchService().SetEmbedder(embedder)
+	// embed the tools registered while the gateway was down or with a different embedder,
+	// new tools are embedded as their servers get registered
+	go func() {
+		if err := mcpService.GetSearchService().SyncEmbeddings(cmd.Context()); err != nil {
+			serverLogger.Warn("failed to update tool embeddings", logger.ErrorField(err))
+		}
+	}()
+	// don't leave orphaned processes of stdio servers behind when the gateway exits
+	defer mcp.KillStdioProcesses()
+
+	if healthCheckInterval > 0 {
+		healthCheckCtx, stopHealthChecks := context.WithCancel(cmd.Context())
+		defer stopHealthChecks()
+		mcpService.StartHealthChecker(healthCheckCtx, mcp.HealthCheckOptions{
+			Interval:           healthCheckInterval,
+			HideUnhealthyTools: hideUnhealthyTools,
+		})
+	}
 
-	mcpClientService := mcpclient.NewMCPClientService(dbConn)
+	mcpClientService := mcpclient.NewMCPClientService(dbConn, serverLogger)
 
 	configService := config.NewServerConfigService(dbConn)
-	userService := user.NewUserService(dbConn)
+	userService := user.NewUserService(dbConn, serverLogger)
 
-	toolGroupService, err := toolgroup.NewToolGroupService(dbConn, mcpService)
+	toolGroupService, err := toolgroup.NewToolGroupService(dbConn, mcpService, serverLogger)
 	if err != nil {
 		return fmt.Errorf("failed to create Tool Group service: %v", err)
 	}
@@ -351,6 +796,9 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 		ToolGroupService:  toolGroupService,
 		OtelProviders:     otelProviders,
 		Metrics:           mcpMetrics,
+		Logger:            serverLogger,
+		DB:                dbConn,
+		CriticalServers:   criticalServers,
 	}
 	s, err := api.NewServer(opts)
 	if err != nil {
@@ -395,8 +843,23 @@ func runStartServer(cmd *cobra.Command, args []string) error {
 	// Display startup banner when the server is started
 	cmd.Print(asciiArt)
 	cmd.Printf("MCPJungle HTTP server listening on :%s\n\n", bindPort)
-	if err := s.Start(); err != nil {
-		return fmt.Errorf("failed to run the server: %v", err)
+
+	// stop on SIGINT/SIGTERM so that the processes of stdio servers are reaped instead of being orphaned
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.Start()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			return fmt.Errorf("failed to run the server: %v", err)
+		}
+	case <-ctx.Done():
+		cmd.Println("Shutting down MCPJungle server")
 	}
 
 	return nil
@@ -4,6 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/search"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 )
 
 func TestStartCommandStructure(t *testing.T) {
@@ -245,3 +251,295 @@ func TestGetPostgresDSN(t *testing.T) {
 		})
 	})
 }
+
+func TestGetToolCallTimeout(t *testing.T) {
+	t.Run("defaults when nothing is set", func(t *testing.T) {
+		withEnv(map[string]string{ToolCallTimeoutEnvVar: ""}, func() {
+			timeout, err := getToolCallTimeout()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeout != mcp.DefaultToolCallTimeout {
+				t.Errorf("expected %s, got %s", mcp.DefaultToolCallTimeout, timeout)
+			}
+		})
+	})
+
+	t.Run("reads env var", func(t *testing.T) {
+		withEnv(map[string]string{ToolCallTimeoutEnvVar: "90s"}, func() {
+			timeout, err := getToolCallTimeout()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeout != 90*time.Second {
+				t.Errorf("expected 90s, got %s", timeout)
+			}
+		})
+	})
+
+	t.Run("flag takes precedence over env var", func(t *testing.T) {
+		startServerCmdToolCallTimeout = "5m"
+		defer func() { startServerCmdToolCallTimeout = "" }()
+		withEnv(map[string]string{ToolCallTimeoutEnvVar: "90s"}, func() {
+			timeout, err := getToolCallTimeout()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if timeout != 5*time.Minute {
+				t.Errorf("expected 5m, got %s", timeout)
+			}
+		})
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		withEnv(map[string]string{ToolCallTimeoutEnvVar: "soon"}, func() {
+			if _, err := getToolCallTimeout(); err == nil {
+				t.Error("expected an error for an invalid duration")
+			}
+		})
+	})
+}
+
+func TestGetHealthCheckInterval(t *testing.T) {
+	t.Run("disabled when nothing is set", func(t *testing.T) {
+		withEnv(map[string]string{HealthCheckIntervalEnvVar: ""}, func() {
+			interval, err := getHealthCheckInterval()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if interval != 0 {
+				t.Errorf("expected 0, got %s", interval)
+			}
+		})
+	})
+
+	t.Run("enabled by the environment variable", func(t *testing.T) {
+		withEnv(map[string]string{HealthCheckIntervalEnvVar: "30s"}, func() {
+			interval, err := getHealthCheckInterval()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if interval != 30*time.Second {
+				t.Errorf("expected 30s, got %s", interval)
+			}
+		})
+	})
+
+	t.Run("zero disables health checks", func(t *testing.T) {
+		startServerCmdHealthCheckInterval = "0"
+		defer func() { startServerCmdHealthCheckInterval = "" }()
+		withEnv(map[string]string{HealthCheckIntervalEnvVar: "1m"}, func() {
+			interval, err := getHealthCheckInterval()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if interval != 0 {
+				t.Errorf("expected 0, got %s", interval)
+			}
+		})
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		withEnv(map[string]string{HealthCheckIntervalEnvVar: "-5s"}, func() {
+			if _, err := getHealthCheckInterval(); err == nil {
+				t.Error("expected an error for a negative duration")
+			}
+		})
+	})
+}
+
+func TestIsHideUnhealthyToolsEnabled(t *testing.T) {
+	withEnv(map[string]string{HideUnhealthyToolsEnvVar: ""}, func() {
+		enabled, err := isHideUnhealthyToolsEnabled()
+		if err != nil || enabled {
+			t.Errorf("expected hiding to be disabled by default, got %t, %v", enabled, err)
+		}
+	})
+	withEnv(map[string]string{HideUnhealthyToolsEnvVar: "TRUE"}, func() {
+		enabled, err := isHideUnhealthyToolsEnabled()
+		if err != nil || !enabled {
+			t.Errorf("expected hiding to be enabled by env var, got %t, %v", enabled, err)
+		}
+	})
+	withEnv(map[string]string{HideUnhealthyToolsEnvVar: "maybe"}, func() {
+		if _, err := isHideUnhealthyToolsEnabled(); err == nil {
+			t.Error("expected an error for an invalid value")
+		}
+	})
+}
+
+func TestIsStdioSandboxRequired(t *testing.T) {
+	withEnv(map[string]string{RequireStdioSandboxEnvVar: ""}, func() {
+		required, err := isStdioSandboxRequired()
+		if err != nil || required {
+			t.Errorf("expected the sandbox not to be required by default, got %t, %v", required, err)
+		}
+	})
+	withEnv(map[string]string{RequireStdioSandboxEnvVar: "true"}, func() {
+		required, err := isStdioSandboxRequired()
+		if err != nil || !required {
+			t.Errorf("expected the sandbox to be required by env var, got %t, %v", required, err)
+		}
+	})
+	withEnv(map[string]string{RequireStdioSandboxEnvVar: "yes"}, func() {
+		if _, err := isStdioSandboxRequired(); err == nil {
+			t.Error("expected an error for an invalid value")
+		}
+	})
+}
+
+func TestIsLazyToolLoadingEnabled(t *testing.T) {
+	withEnv(map[string]string{LazyToolLoadingEnvVar: ""}, func() {
+		enabled, err := isLazyToolLoadingEnabled()
+		if err != nil || enabled {
+			t.Errorf("expected lazy tool loading to be off by default, got %t, %v", enabled, err)
+		}
+	})
+	withEnv(map[string]string{LazyToolLoadingEnvVar: "1"}, func() {
+		enabled, err := isLazyToolLoadingEnabled()
+		if err != nil || !enabled {
+			t.Errorf("expected lazy tool loading to be enabled by env var, got %t, %v", enabled, err)
+		}
+	})
+	withEnv(map[string]string{LazyToolLoadingEnvVar: "sometimes"}, func() {
+		if _, err := isLazyToolLoadingEnabled(); err == nil {
+			t.Error("expected an error for an invalid value")
+		}
+	})
+}
+
+func TestNewServerLogger(t *testing.T) {
+	withEnv(map[string]string{LogLevelEnvVar: ""}, func() {
+		l, err := newServerLogger(model.ModeDev)
+		if err != nil || l.Level() != "info" {
+			t.Errorf("expected the default level to be info, got %v, %v", l, err)
+		}
+	})
+	withEnv(map[string]string{LogLevelEnvVar: "DEBUG"}, func() {
+		l, err := newServerLogger(model.ModeEnterprise)
+		if err != nil || l.Level() != "debug" {
+			t.Errorf("expected the level to be set by env var, got %v, %v", l, err)
+		}
+	})
+	withEnv(map[string]string{LogLevelEnvVar: "verbose"}, func() {
+		if _, err := newServerLogger(model.ModeDev); err == nil {
+			t.Error("expected an error for an invalid level")
+		}
+	})
+
+	startServerCmdLogLevel = "warn"
+	defer func() { startServerCmdLogLevel = "" }()
+	withEnv(map[string]string{LogLevelEnvVar: "debug"}, func() {
+		l, err := newServerLogger(model.ModeDev)
+		if err != nil || l.Level() != "warn" {
+			t.Errorf("expected the flag to take precedence, got %v, %v", l, err)
+		}
+	})
+}
+
+func TestGetTracesExporter(t *testing.T) {
+	withEnv(map[string]string{TracesExporterEnvVar: ""}, func() {
+		e, err := getTracesExporter()
+		if err != nil || e != telemetry.TraceExporterNone {
+			t.Errorf("expected tracing to be disabled by default, got %s, %v", e, err)
+		}
+	})
+	withEnv(map[string]string{TracesExporterEnvVar: "OTLP"}, func() {
+		e, err := getTracesExporter()
+		if err != nil || e != telemetry.TraceExporterOTLP {
+			t.Errorf("expected the exporter to be set by env var, got %s, %v", e, err)
+		}
+	})
+	withEnv(map[string]string{TracesExporterEnvVar: "zipkin"}, func() {
+		if _, err := getTracesExporter(); err == nil {
+			t.Error("expected an error for an invalid exporter")
+		}
+	})
+
+	startServerCmdTracesExporter = "file"
+	defer func() { startServerCmdTracesExporter = "" }()
+	withEnv(map[string]string{TracesExporterEnvVar: "otlp"}, func() {
+		e, err := getTracesExporter()
+		if err != nil || e != telemetry.TraceExporterFile {
+			t.Errorf("expected the flag to take precedence, got %s, %v", e, err)
+		}
+	})
+}
+
+func TestGetTracesFile(t *testing.T) {
+	withEnv(map[string]string{TracesFileEnvVar: ""}, func() {
+		if f := getTracesFile(); f != defaultTracesFile {
+			t.Errorf("expected %s, got %s", defaultTracesFile, f)
+		}
+	})
+
+	startServerCmdTracesFile = "/tmp/flag.jsonl"
+	defer func() { startServerCmdTracesFile = "" }()
+	withEnv(map[string]string{TracesFileEnvVar: "/tmp/env.jsonl"}, func() {
+		if f := getTracesFile(); f != "/tmp/flag.jsonl" {
+			t.Errorf("expected the flag to take precedence, got %s", f)
+		}
+	})
+}
+
+func TestGetCriticalServers(t *testing.T) {
+	withEnv(map[string]string{CriticalServersEnvVar: " github, ,context7"}, func() {
+		servers := getCriticalServers()
+		if len(servers) != 2 || servers[0] != "github" || servers[1] != "context7" {
+			t.Errorf("expected [github context7], got %v", servers)
+		}
+	})
+
+	startServerCmdCriticalServers = []string{"slack"}
+	defer func() { startServerCmdCriticalServers = nil }()
+	withEnv(map[string]string{CriticalServersEnvVar: "github"}, func() {
+		servers := getCriticalServers()
+		if len(servers) != 1 || servers[0] != "slack" {
+			t.Errorf("expected the flag to take precedence, got %v", servers)
+		}
+	})
+}
+
+func TestGetEmbedder(t *testing.T) {
+	withEnv(map[string]string{EmbeddingProviderEnvVar: ""}, func() {
+		e, err := getEmbedder()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := e.(*search.LocalEmbedder); !ok {
+			t.Errorf("expected the local embedder by default, got %T", e)
+		}
+	})
+
+	withEnv(map[string]string{
+		EmbeddingProviderEnvVar: "openai",
+		EmbeddingModelEnvVar:    "nomic-embed-text",
+		EmbeddingAPIKeyEnvVar:   "secret",
+	}, func() {
+		e, err := getEmbedder()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e.Name() != "openai:nomic-embed-text" {
+			t.Errorf("expected the openai embedder with the model from the env var, got %s", e.Name())
+		}
+	})
+
+	withEnv(map[string]string{EmbeddingProviderEnvVar: "cohere"}, func() {
+		if _, err := getEmbedder(); err == nil {
+			t.Error("expected an error for an unsupported provider")
+		}
+	})
+
+	startServerCmdEmbeddingProvider = "local"
+	defer func() { startServerCmdEmbeddingProvider = "" }()
+	withEnv(map[string]string{EmbeddingProviderEnvVar: "openai"}, func() {
+		e, err := getEmbedder()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := e.(*search.LocalEmbedder); !ok {
+			t.Errorf("expected the flag to take precedence, got %T", e)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the health of the MCPJungle server and the registered MCP servers",
+	Long: "Show whether the MCPJungle server is up and the result of the latest health checks\n" +
+		"of every MCP server registered in it.",
+	Args: cobra.NoArgs,
+	RunE: runStatus,
+	Annotations: map[string]string{
+		"group": string(subCommandGroupBasic),
+		"order": "8",
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	health, err := apiClient.GetHealth(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to get the status of the MCPJungle server at %s: %w", apiClient.BaseURL(), err)
+	}
+
+	fmt.Printf("MCPJungle server: %s\n", health.Status)
+	if len(health.Servers) == 0 {
+		fmt.Println("There are no MCP servers in the registry")
+		return nil
+	}
+
+	names := make([]string, 0, len(health.Servers))
+	for name := range health.Servers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println()
+	for i, name := range names {
+		h := health.Servers[name]
+		fmt.Printf("%d. %s: %s\n", i+1, name, formatServerHealth(h))
+
+		if h.LastSeen != nil {
+			fmt.Printf("Last seen: %s\n", h.LastSeen.Local().Format(time.RFC3339))
+		}
+		if h.LastError != "" {
+			fmt.Println("Last error: " + h.LastError)
+		}
+		if h.ToolsHidden {
+			fmt.Println("Tools are hidden from MCP clients until the server is healthy again")
+		}
+
+		if i < len(names)-1 {
+			fmt.Println()
+		}
+	}
+
+	return nil
+}
+
+// formatServerHealth returns a short, human-readable summary of an MCP server's health.
+func formatServerHealth(h *types.ServerHealth) string {
+	switch h.Status {
+	case types.HealthStatusHealthy:
+		return fmt.Sprintf("%s (%dms)", h.Status, h.LatencyMs)
+	case types.HealthStatusUnhealthy:
+		return fmt.Sprintf("%s (%d failed checks)", h.Status, h.ConsecutiveFailures)
+	default:
+		return string(h.Status)
+	}
+}
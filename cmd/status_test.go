@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestStatusCommandStructure(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "status", statusCmd.Use)
+	testhelpers.AssertTrue(t, len(statusCmd.Long) > 0, "Long description should not be empty")
+
+	annotationTests := []testhelpers.CommandAnnotationTest{
+		{Key: "group", Expected: string(subCommandGroupBasic)},
+		{Key: "order", Expected: "8"},
+	}
+	testhelpers.TestCommandAnnotations(t, statusCmd.Annotations, annotationTests)
+
+	testhelpers.AssertNotNil(t, statusCmd.RunE)
+	testhelpers.AssertNotNil(t, statusCmd.Args)
+}
+
+func TestFormatServerHealth(t *testing.T) {
+	t.Parallel()
+
+	testhelpers.AssertEqual(t, "healthy (42ms)", formatServerHealth(&types.ServerHealth{
+		Status: types.HealthStatusHealthy, LatencyMs: 42,
+	}))
+	testhelpers.AssertEqual(t, "unhealthy (3 failed checks)", formatServerHealth(&types.ServerHealth{
+		Status: types.HealthStatusUnhealthy, ConsecutiveFailures: 3,
+	}))
+	testhelpers.AssertEqual(t, "unknown", formatServerHealth(&types.ServerHealth{
+		Status: types.HealthStatusUnknown,
+	}))
+}
@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -65,8 +68,20 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 	noChangeInTools := len(toolsAdded) == 0 && len(toolsRemoved) == 0
 	noChangeInServers := len(serversAdded) == 0 && len(serversRemoved) == 0
 	noChangeInExcluded := len(excludedAdded) == 0 && len(excludedRemoved) == 0
-
-	if resp.Old.Description == resp.New.Description && noChangeInTools && noChangeInServers && noChangeInExcluded {
+	noChangeInFilter := resp.Old.IncludedServersFilter.String() == resp.New.IncludedServersFilter.String()
+	noChangeInLazy := resp.Old.LazyToolLoading == resp.New.LazyToolLoading
+	noChangeInRules := slices.EqualFunc(resp.Old.ToolRules, resp.New.ToolRules, func(a, b types.ToolGroupRule) bool {
+		return a.String() == b.String()
+	})
+	noChangeInAliases := slices.EqualFunc(resp.Old.ToolAliases, resp.New.ToolAliases, func(a, b types.ToolAlias) bool {
+		return a.String() == b.String() && a.Description == b.Description
+	})
+	noChangeInArguments := maps.EqualFunc(resp.Old.ToolArguments, resp.New.ToolArguments, func(a, b types.ToolArguments) bool {
+		return a.String() == b.String()
+	})
+
+	if resp.Old.Description == resp.New.Description && noChangeInTools && noChangeInServers && noChangeInExcluded &&
+		noChangeInFilter && noChangeInLazy && noChangeInRules && noChangeInAliases && noChangeInArguments {
 		cmd.Printf("No changes detected for Tool Group %s. Nothing was updated.\n", resp.Name)
 		return nil
 	}
@@ -130,5 +145,74 @@ func runUpdateGroup(cmd *cobra.Command, args []string) error {
 		cmd.Println()
 	}
 
+	if !noChangeInFilter {
+		cmd.Printf(
+			"* Tools included from included_servers changed from:\n    %s\nto:\n    %s\n\n",
+			resp.Old.IncludedServersFilter, resp.New.IncludedServersFilter,
+		)
+	}
+
+	if !noChangeInRules {
+		cmd.Println("* Tool rules changed from:")
+		printToolRules(cmd, resp.Old.ToolRules)
+		cmd.Println("to:")
+		printToolRules(cmd, resp.New.ToolRules)
+		cmd.Println()
+	}
+
+	if !noChangeInAliases {
+		cmd.Println("* Tool aliases changed from:")
+		printToolAliases(cmd, resp.Old.ToolAliases)
+		cmd.Println("to:")
+		printToolAliases(cmd, resp.New.ToolAliases)
+		cmd.Println()
+	}
+
+	if !noChangeInArguments {
+		cmd.Println("* Tool arguments changed from:")
+		printToolArguments(cmd, resp.Old.ToolArguments)
+		cmd.Println("to:")
+		printToolArguments(cmd, resp.New.ToolArguments)
+		cmd.Println()
+	}
+
+	if !noChangeInLazy {
+		cmd.Printf("* Lazy tool loading changed from %t to %t\n\n", resp.Old.LazyToolLoading, resp.New.LazyToolLoading)
+	}
+
 	return nil
 }
+
+// printToolRules prints the tool rules of a group as an indented list.
+func printToolRules(cmd *cobra.Command, rules []types.ToolGroupRule) {
+	if len(rules) == 0 {
+		cmd.Println("    none")
+		return
+	}
+	for _, r := range rules {
+		cmd.Printf("    - %s\n", r.String())
+	}
+}
+
+// printToolAliases prints the tool aliases of a group as an indented list.
+func printToolAliases(cmd *cobra.Command, aliases []types.ToolAlias) {
+	if len(aliases) == 0 {
+		cmd.Println("    none")
+		return
+	}
+	for _, a := range aliases {
+		cmd.Printf("    - %s\n", a.String())
+	}
+}
+
+// printToolArguments prints the argument presets of a group's tools as an indented list sorted by tool name.
+func printToolArguments(cmd *cobra.Command, presets map[string]types.ToolArguments) {
+	if len(presets) == 0 {
+		cmd.Println("    none")
+		return
+	}
+	for _, name := range slices.Sorted(maps.Keys(presets)) {
+		args := presets[name]
+		cmd.Printf("    - %s: %s\n", name, args.String())
+	}
+}
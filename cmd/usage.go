@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +23,46 @@ var usageCmd = &cobra.Command{
 	},
 }
 
+var (
+	usageReportCmdSince string
+	usageReportCmdBy    string
+	usageReportCmdTop   int
+)
+
+var usageReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report which tools are called, by whom and how often",
+	Long: "Report the tool calls proxied by MCPJungle in a time range, aggregated by tool, MCP client or MCP server.\n" +
+		"Enabled tools that haven't been called in the time range are listed as candidates to disable.\n" +
+		"In enterprise mode, this command requires admin privileges.",
+	Example: `  mcpjungle usage report
+  mcpjungle usage report --since 30d --by client
+  mcpjungle usage report --since 12h --by server --top 5`,
+	Args: cobra.NoArgs,
+	RunE: runUsageReport,
+}
+
 func init() {
+	usageReportCmd.Flags().StringVar(
+		&usageReportCmdSince,
+		"since",
+		"7d",
+		"Start of the time range, as a number of days (eg- 7d), a duration (eg- 12h) or an RFC3339 timestamp",
+	)
+	usageReportCmd.Flags().StringVar(
+		&usageReportCmdBy,
+		"by",
+		string(types.UsageGroupByTool),
+		"Aggregate the calls by 'tool', 'client' or 'server'",
+	)
+	usageReportCmd.Flags().IntVar(
+		&usageReportCmdTop,
+		"top",
+		10,
+		"Number of rows to show, 0 to show all",
+	)
+
+	usageCmd.AddCommand(usageReportCmd)
 	rootCmd.AddCommand(usageCmd)
 }
 
@@ -31,37 +73,135 @@ func runGetToolUsage(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Println(t.Name)
+	if t.Annotations != nil && t.Annotations.Title != "" {
+		fmt.Println(t.Annotations.Title)
+	}
 	fmt.Println(t.Description)
 
+	if t.Annotations != nil {
+		fmt.Println()
+		fmt.Println("Behavior: " + toolBehavior(t.Annotations))
+		if t.Annotations.IsDestructive() {
+			fmt.Println("WARNING: This tool may perform destructive updates.")
+		}
+	}
+
 	if len(t.InputSchema.Properties) == 0 {
 		fmt.Println("This tool does not require any input parameters.")
-		return nil
-	}
+	} else {
+		fmt.Println()
+		fmt.Println("Input Parameters:")
+		for k, v := range t.InputSchema.Properties {
+			requiredOrOptional := "optional"
+			if slices.Contains(t.InputSchema.Required, k) {
+				requiredOrOptional = "required"
+			}
 
-	fmt.Println()
-	fmt.Println("Input Parameters:")
-	for k, v := range t.InputSchema.Properties {
-		requiredOrOptional := "optional"
-		if slices.Contains(t.InputSchema.Required, k) {
-			requiredOrOptional = "required"
-		}
+			boundary := strings.Repeat("=", len(k)+len(requiredOrOptional)+20)
 
-		boundary := strings.Repeat("=", len(k)+len(requiredOrOptional)+20)
+			fmt.Println(boundary)
+			fmt.Printf("%s (%s)\n", k, requiredOrOptional)
 
-		fmt.Println(boundary)
-		fmt.Printf("%s (%s)\n", k, requiredOrOptional)
+			j, err := json.MarshalIndent(v, "", "  ")
+			if err != nil {
+				// Simply print the raw object if we fail to marshal it
+				fmt.Println(v)
+			} else {
+				fmt.Println(string(j))
+			}
+			fmt.Println(boundary)
+
+			fmt.Println()
+		}
+	}
 
-		j, err := json.MarshalIndent(v, "", "  ")
+	if len(t.OutputSchema) > 0 {
+		fmt.Println()
+		fmt.Println("Output Schema:")
+		j, err := json.MarshalIndent(t.OutputSchema, "", "  ")
 		if err != nil {
-			// Simply print the raw object if we fail to marshal it
-			fmt.Println(v)
+			fmt.Println(t.OutputSchema)
 		} else {
 			fmt.Println(string(j))
 		}
-		fmt.Println(boundary)
+	}
 
-		fmt.Println()
+	return nil
+}
+
+// toolBehavior describes the behavior of a tool based on its annotations, eg- "read-only, idempotent, open-world".
+func toolBehavior(a *types.ToolAnnotations) string {
+	var traits []string
+	switch {
+	case a.IsReadOnly():
+		traits = append(traits, "read-only")
+	case a.IsDestructive():
+		traits = append(traits, "destructive")
+	default:
+		traits = append(traits, "non-destructive")
+	}
+	if a.IsIdempotent() {
+		traits = append(traits, "idempotent")
+	}
+	if a.IsOpenWorld() {
+		traits = append(traits, "open-world")
+	} else {
+		traits = append(traits, "closed-world")
+	}
+	return strings.Join(traits, ", ")
+}
+
+func runUsageReport(cmd *cobra.Command, args []string) error {
+	by, err := types.ValidateUsageGroupBy(usageReportCmdBy)
+	if err != nil {
+		return err
+	}
+	if usageReportCmdTop < 0 {
+		return fmt.Errorf("--top must not be negative")
 	}
 
+	report, err := apiClient.GetUsageReport(usageReportCmdSince, by, usageReportCmdTop)
+	if err != nil {
+		return fmt.Errorf("failed to get usage report: %w", err)
+	}
+	printUsageReport(cmd, report)
 	return nil
 }
+
+// printUsageReport prints the rows of a usage report as a table, followed by the unused tools.
+func printUsageReport(cmd *cobra.Command, report *types.UsageReport) {
+	cmd.Printf(
+		"Tool calls by %s from %s to %s:\n\n",
+		report.GroupBy, report.Since.Local().Format(time.DateTime), report.Until.Local().Format(time.DateTime),
+	)
+
+	if len(report.Rows) == 0 {
+		cmd.Println("No tool calls were made in this time range")
+	} else {
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "#\t"+strings.ToUpper(string(report.GroupBy))+"\tCALLS\tERRORS\tAVG DURATION\tLAST CALLED")
+		for i, r := range report.Rows {
+			failed := r.Calls - r.Outcomes["success"]
+			_, _ = fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\t%s\n",
+				i+1,
+				r.Name,
+				r.Calls,
+				failed,
+				(time.Duration(r.AvgDurationMs) * time.Millisecond).String(),
+				r.LastCalledAt.Local().Format("2006-01-02 15:00"),
+			)
+		}
+		_ = w.Flush()
+	}
+
+	if len(report.UnusedTools) == 0 {
+		return
+	}
+	cmd.Println()
+	cmd.Printf("%d tools were not called in this time range and are candidates to disable:\n", len(report.UnusedTools))
+	for _, t := range report.UnusedTools {
+		cmd.Printf("- %s\n", t)
+	}
+	cmd.Println()
+	cmd.Println("Run 'disable tool <tool name>' to disable a tool")
+}
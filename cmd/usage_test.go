@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/spf13/cobra"
 )
 
 func TestUsageCommandStructure(t *testing.T) {
@@ -48,3 +54,89 @@ func TestUsageCommandStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestUsageReportCommandStructure(t *testing.T) {
+	found := false
+	for _, c := range usageCmd.Commands() {
+		if c == usageReportCmd {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("usage command is missing the report subcommand")
+	}
+
+	tests := []struct {
+		flag     string
+		defValue string
+	}{
+		{"since", "7d"},
+		{"by", "tool"},
+		{"top", "10"},
+	}
+	for _, tt := range tests {
+		f := usageReportCmd.Flags().Lookup(tt.flag)
+		if f == nil {
+			t.Fatalf("usage report command is missing the --%s flag", tt.flag)
+		}
+		if f.DefValue != tt.defValue {
+			t.Errorf("Expected default value of --%s to be %s, got %s", tt.flag, tt.defValue, f.DefValue)
+		}
+	}
+}
+
+func TestPrintUsageReport(t *testing.T) {
+	now := time.Now()
+	report := &types.UsageReport{
+		Since:   now.AddDate(0, 0, -7),
+		Until:   now,
+		GroupBy: types.UsageGroupByTool,
+		Rows: []types.UsageRow{
+			{
+				Name:          "github__search_code",
+				Calls:         10,
+				Outcomes:      map[string]int64{"success": 8, "timeout": 2},
+				AvgDurationMs: 1500,
+				LastCalledAt:  now.Truncate(time.Hour),
+			},
+			{Name: "time__now", Calls: 3, Outcomes: map[string]int64{"success": 3}, LastCalledAt: now.Truncate(time.Hour)},
+		},
+		UnusedTools: []string{"github__get_issue"},
+	}
+
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	printUsageReport(cmd, report)
+
+	out := output.String()
+	for _, want := range []string{"Tool calls by tool", "TOOL", "github__search_code", "1.5s", "time__now", "github__get_issue"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	lines := strings.Split(out, "\n")
+	for _, l := range lines {
+		if strings.Contains(l, "github__search_code") {
+			fields := strings.Fields(l)
+			if fields[2] != "10" || fields[3] != "2" {
+				t.Errorf("Expected 10 calls and 2 errors, got %q", l)
+			}
+		}
+	}
+}
+
+func TestPrintUsageReportWithoutCalls(t *testing.T) {
+	var output bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&output)
+	printUsageReport(cmd, &types.UsageReport{GroupBy: types.UsageGroupByClient})
+
+	out := output.String()
+	if !strings.Contains(out, "No tool calls were made") {
+		t.Errorf("Expected a message about no calls, got:\n%s", out)
+	}
+	if strings.Contains(out, "candidates to disable") {
+		t.Errorf("Expected no unused tools, got:\n%s", out)
+	}
+}
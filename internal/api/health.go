@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// readinessCheckTimeout is the maximum time the database checks of the readiness probe may take.
+const readinessCheckTimeout = 5 * time.Second
+
+// requireAdminForVerboseHealth is middleware for the health endpoint.
+// The plain health check is public, but the verbose one reveals the names of the MCP servers and their errors,
+// so in enterprise mode it is only served to admin users.
+func (s *Server) requireAdminForVerboseHealth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query("verbose") != "true" {
+			c.Next()
+			return
+		}
+
+		cfg, err := s.configService.GetConfig()
+		if err != nil || !cfg.Initialized {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "server is not initialized"})
+			return
+		}
+		if cfg.Mode == model.ModeDev {
+			c.Next()
+			return
+		}
+
+		u, ok := s.authenticateUser(c)
+		if !ok || !s.authorizeAdmin(c, u) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// healthHandler reports whether mcpjungle is up.
+// With the verbose=true query parameter, it also reports the health of every registered MCP server.
+// Access to the verbose report is restricted by requireAdminForVerboseHealth.
+func (s *Server) healthHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resp := &types.HealthResponse{Status: "ok"}
+
+		if c.Query("verbose") != "true" {
+			c.JSON(http.StatusOK, resp)
+			return
+		}
+
+		records, err := s.mcpService.ListMcpServers()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp.Servers = make(map[string]*types.ServerHealth, len(records))
+		for _, record := range records {
+			health, ok := s.mcpService.GetServerHealth(record.Name)
+			if !ok {
+				health = &types.ServerHealth{Status: types.HealthStatusUnknown}
+			}
+			if health.Status == types.HealthStatusUnhealthy {
+				resp.Status = "degraded"
+			}
+			resp.Servers[record.Name] = health
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// livenessHandler reports that the mcpjungle process is up and serving requests.
+// It performs no other checks, so that a broken dependency never causes the process to be restarted.
+func (s *Server) livenessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	}
+}
+
+// readinessHandler reports whether mcpjungle is ready to serve traffic.
+// It checks the database connection, the DB migrations, whether the server is initialized
+// and the health of the critical upstream MCP servers.
+// It responds with 503 if any check fails.
+func (s *Server) readinessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+		defer cancel()
+
+		resp := &types.ReadinessResponse{Status: "ready"}
+		addCheck := func(name string, err error) {
+			check := &types.ReadinessCheck{Name: name, Status: types.ReadinessCheckPass}
+			if err != nil {
+				check.Status = types.ReadinessCheckFail
+				check.Error = err.Error()
+				resp.Status = "not_ready"
+			}
+			resp.Checks = append(resp.Checks, check)
+		}
+
+		dbErr := s.checkDatabase(ctx)
+		addCheck("database", dbErr)
+		if dbErr == nil {
+			migrationsErr := s.checkMigrations(ctx)
+			addCheck("migrations", migrationsErr)
+			if migrationsErr == nil {
+				addCheck("initialized", s.checkInitialized())
+			}
+		}
+		for _, name := range s.criticalServers {
+			addCheck("upstream:"+name, s.checkCriticalServer(name))
+		}
+
+		status := http.StatusOK
+		if resp.Status != "ready" {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, resp)
+	}
+}
+
+func (s *Server) checkDatabase(ctx context.Context) error {
+	if s.db == nil {
+		return errors.New("no database connection")
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) checkMigrations(ctx context.Context) error {
+	if s.migrationsChecked.Load() {
+		return nil
+	}
+	if err := migrations.Check(s.db.WithContext(ctx)); err != nil {
+		return fmt.Errorf("database migrations have not been applied: %w", err)
+	}
+	s.migrationsChecked.Store(true)
+	return nil
+}
+
+func (s *Server) checkInitialized() error {
+	ok, err := s.IsInitialized()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("server is not initialized")
+	}
+	return nil
+}
+
+// checkCriticalServer returns an error unless the latest health check of the given MCP server succeeded.
+func (s *Server) checkCriticalServer(name string) error {
+	health, ok := s.mcpService.GetServerHealth(name)
+	if !ok {
+		if _, err := s.mcpService.GetMcpServer(name); err != nil {
+			return fmt.Errorf("failed to get server: %w", err)
+		}
+		return errors.New("server has not been health-checked yet")
+	}
+	if health.Status != types.HealthStatusHealthy {
+		return fmt.Errorf("server is unhealthy: %s", health.LastError)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/migrations"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/config"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+
+	mcpService, err := mcp.NewMCPService(db, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	configService := config.NewServerConfigService(db)
+
+	s, err := NewServer(&ServerOptions{
+		Port:            "8080",
+		MCPService:      mcpService,
+		ConfigService:   configService,
+		Metrics:         telemetry.NewNoopCustomMetrics(),
+		DB:              db,
+		CriticalServers: []string{"github"},
+	})
+	testhelpers.AssertNoError(t, err)
+
+	probe := func() (int, map[string]*types.ReadinessCheck) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+		s.router.ServeHTTP(w, req)
+
+		var resp types.ReadinessResponse
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		checks := make(map[string]*types.ReadinessCheck)
+		for _, c := range resp.Checks {
+			checks[c.Name] = c
+		}
+		return w.Code, checks
+	}
+
+	code, checks := probe()
+	testhelpers.AssertEqual(t, http.StatusServiceUnavailable, code)
+	testhelpers.AssertEqual(t, types.ReadinessCheckPass, checks["database"].Status)
+	testhelpers.AssertEqual(t, types.ReadinessCheckPass, checks["migrations"].Status)
+	testhelpers.AssertEqual(t, types.ReadinessCheckFail, checks["initialized"].Status)
+	testhelpers.AssertEqual(t, types.ReadinessCheckFail, checks["upstream:github"].Status)
+
+	_, err = configService.Init(model.ModeDev)
+	testhelpers.AssertNoError(t, err)
+	code, checks = probe()
+	testhelpers.AssertEqual(t, http.StatusServiceUnavailable, code)
+	testhelpers.AssertEqual(t, types.ReadinessCheckPass, checks["initialized"].Status)
+	testhelpers.AssertStringContains(t, checks["upstream:github"].Error, "failed to get server")
+
+	// liveness doesn't depend on any of the above
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/livez", nil)
+	s.router.ServeHTTP(w, req)
+	testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+	sqlDB, err := db.DB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, sqlDB.Close())
+	code, checks = probe()
+	testhelpers.AssertEqual(t, http.StatusServiceUnavailable, code)
+	testhelpers.AssertEqual(t, types.ReadinessCheckFail, checks["database"].Status)
+	_, ok := checks["migrations"]
+	testhelpers.AssertFalse(t, ok, "expected the migrations check to be skipped when the database is down")
+}
+
+func TestVerboseHealthRequiresAdminInEnterpriseMode(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+
+	mcpService, err := mcp.NewMCPService(db, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	configService := config.NewServerConfigService(db)
+	_, err = configService.Init(model.ModeEnterprise)
+	testhelpers.AssertNoError(t, err)
+	userService := user.NewUserService(db, logger.NewNop())
+	admin, err := userService.CreateAdminUser()
+	testhelpers.AssertNoError(t, err)
+	alice, err := userService.CreateUser("alice")
+	testhelpers.AssertNoError(t, err)
+
+	s, err := NewServer(&ServerOptions{
+		Port:          "8080",
+		MCPService:    mcpService,
+		ConfigService: configService,
+		UserService:   userService,
+		Metrics:       telemetry.NewNoopCustomMetrics(),
+		DB:            db,
+	})
+	testhelpers.AssertNoError(t, err)
+
+	get := func(url, token string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		s.router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	testhelpers.AssertEqual(t, http.StatusOK, get("/health", ""))
+	testhelpers.AssertEqual(t, http.StatusUnauthorized, get("/health?verbose=true", ""))
+	testhelpers.AssertEqual(t, http.StatusForbidden, get("/health?verbose=true", alice.AccessToken))
+	testhelpers.AssertEqual(t, http.StatusOK, get("/health?verbose=true", admin.AccessToken))
+}
+
+func TestServerHealthErrorsAreOnlyShownToAdmins(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := testhelpers.CreateTestDB()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, migrations.Migrate(db))
+
+	mcpService, err := mcp.NewMCPService(db, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	configService := config.NewServerConfigService(db)
+	_, err = configService.Init(model.ModeEnterprise)
+	testhelpers.AssertNoError(t, err)
+	userService := user.NewUserService(db, logger.NewNop())
+	admin, err := userService.CreateAdminUser()
+	testhelpers.AssertNoError(t, err)
+	alice, err := userService.CreateUser("alice")
+	testhelpers.AssertNoError(t, err)
+
+	// nothing listens on this port, so the health check fails
+	record, err := model.NewStreamableHTTPServer("github", "", "http://127.0.0.1:1/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(record).Error)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mcpService.StartHealthChecker(ctx, mcp.HealthCheckOptions{Interval: time.Hour})
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, ok := mcpService.GetServerHealth("github"); ok || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	s, err := NewServer(&ServerOptions{
+		Port:          "8080",
+		MCPService:    mcpService,
+		ConfigService: configService,
+		UserService:   userService,
+		Metrics:       telemetry.NewNoopCustomMetrics(),
+		DB:            db,
+	})
+	testhelpers.AssertNoError(t, err)
+
+	getServer := func(token string) *types.McpServer {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/v0/servers/github", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		s.router.ServeHTTP(w, req)
+		testhelpers.AssertEqual(t, http.StatusOK, w.Code)
+
+		var resp types.McpServer
+		testhelpers.AssertNoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		testhelpers.AssertTrue(t, resp.Health != nil, "expected the server's health to be reported")
+		return &resp
+	}
+
+	resp := getServer(alice.AccessToken)
+	testhelpers.AssertEqual(t, types.HealthStatusUnhealthy, resp.Health.Status)
+	testhelpers.AssertEqual(t, "", resp.Health.LastError)
+
+	resp = getServer(admin.AccessToken)
+	testhelpers.AssertEqual(t, types.HealthStatusUnhealthy, resp.Health.Status)
+	testhelpers.AssertTrue(t, resp.Health.LastError != "", "expected admins to see the health check error")
+}
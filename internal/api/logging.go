@@ -0,0 +1,98 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// RequestIDHeader is the HTTP header that carries the ID of a request.
+// If a client doesn't supply one, mcpjungle generates it.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of a request ID accepted from a client
+const maxRequestIDLength = 128
+
+// requestLogger is middleware that assigns an ID to every request and attaches a logger carrying
+// this ID to the request's context.
+// Once the request is complete, it is logged along with the identity of the user or MCP client that made it.
+func (s *Server) requestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+
+		l := s.logger.WithFields(logger.String("request_id", id))
+		c.Request = c.Request.WithContext(logger.NewContext(c.Request.Context(), l))
+
+		c.Next()
+
+		// the auth middleware may have enriched the logger with the identity of the caller
+		l = logger.FromContext(c.Request.Context(), l)
+		fields := []logger.Field{
+			logger.String("method", c.Request.Method),
+			logger.String("path", c.Request.URL.Path),
+			logger.Int("status", c.Writer.Status()),
+			logger.Int64("latency_ms", time.Since(start).Milliseconds()),
+			logger.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, logger.String("errors", c.Errors.String()))
+		}
+
+		switch status := c.Writer.Status(); {
+		case status >= http.StatusInternalServerError:
+			l.Error("request failed", fields...)
+		default:
+			l.Info("request completed", fields...)
+		}
+	}
+}
+
+// withLogFields adds fields to the logger of the request, if the request has one.
+func withLogFields(c *gin.Context, fields ...logger.Field) {
+	ctx := c.Request.Context()
+	if l := logger.FromContext(ctx, nil); l != nil {
+		c.Request = c.Request.WithContext(logger.NewContext(ctx, l.WithFields(fields...)))
+	}
+}
+
+// newRequestID generates a random ID for a request
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// getLogLevelHandler returns the current log level of the server
+func (s *Server) getLogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, types.LogLevel{Level: s.logger.Level()})
+	}
+}
+
+// setLogLevelHandler changes the log level of the server at runtime
+func (s *Server) setLogLevelHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input types.LogLevel
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := s.logger.SetLevel(input.Level); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		s.logger.Info("log level changed", logger.String("level", s.logger.Level()))
+		c.JSON(http.StatusOK, types.LogLevel{Level: s.logger.Level()})
+	}
+}
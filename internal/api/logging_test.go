@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+type logEntry struct {
+	msg    string
+	fields map[string]any
+}
+
+// recordingLogger is a logger.Logger that keeps the logged entries in memory
+type recordingLogger struct {
+	mu      *sync.Mutex
+	entries *[]logEntry
+	fields  []logger.Field
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{mu: &sync.Mutex{}, entries: &[]logEntry{}}
+}
+
+func (l *recordingLogger) log(msg string, fields ...logger.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := logEntry{msg: msg, fields: make(map[string]any)}
+	for _, f := range append(append([]logger.Field{}, l.fields...), fields...) {
+		e.fields[f.Key] = f.Value
+	}
+	*l.entries = append(*l.entries, e)
+}
+
+func (l *recordingLogger) Debug(msg string, fields ...logger.Field) { l.log(msg, fields...) }
+func (l *recordingLogger) Info(msg string, fields ...logger.Field)  { l.log(msg, fields...) }
+func (l *recordingLogger) Warn(msg string, fields ...logger.Field)  { l.log(msg, fields...) }
+func (l *recordingLogger) Error(msg string, fields ...logger.Field) { l.log(msg, fields...) }
+func (l *recordingLogger) Sync() error                              { return nil }
+func (l *recordingLogger) Level() string                            { return "info" }
+func (l *recordingLogger) SetLevel(string) error                    { return nil }
+
+func (l *recordingLogger) WithFields(fields ...logger.Field) logger.Logger {
+	return &recordingLogger{
+		mu:      l.mu,
+		entries: l.entries,
+		fields:  append(append([]logger.Field{}, l.fields...), fields...),
+	}
+}
+
+func (l *recordingLogger) find(msg string) (logEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, e := range *l.entries {
+		if e.msg == msg {
+			return e, true
+		}
+	}
+	return logEntry{}, false
+}
+
+func TestRequestLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rec := newRecordingLogger()
+	server := &Server{logger: rec}
+
+	router := gin.New()
+	router.Use(server.requestLogger())
+	router.GET("/test", func(c *gin.Context) {
+		withLogFields(c, logger.String("user", "alice"))
+		c.Status(http.StatusOK)
+	})
+
+	t.Run("generates a request ID", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		id := w.Header().Get(RequestIDHeader)
+		if id == "" {
+			t.Fatal("Expected the response to carry a request ID")
+		}
+		e, ok := rec.find("request completed")
+		if !ok {
+			t.Fatal("Expected the request to be logged")
+		}
+		if e.fields["request_id"] != id {
+			t.Errorf("Expected request_id %s, got %v", id, e.fields["request_id"])
+		}
+		if e.fields["user"] != "alice" {
+			t.Errorf("Expected the log line to carry the user, got %v", e.fields["user"])
+		}
+		if e.fields["status"] != http.StatusOK || e.fields["path"] != "/test" {
+			t.Errorf("Unexpected log fields: %v", e.fields)
+		}
+	})
+
+	t.Run("keeps the request ID of the client", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(RequestIDHeader, "client-supplied-id")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Header().Get(RequestIDHeader); got != "client-supplied-id" {
+			t.Errorf("Expected request ID client-supplied-id, got %s", got)
+		}
+	})
+}
+
+func TestLogLevelHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	l, err := logger.New(&logger.Config{Level: "info"})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	server := &Server{logger: l}
+
+	router := gin.New()
+	router.GET("/log-level", server.getLogLevelHandler())
+	router.PUT("/log-level", server.setLogLevelHandler())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"debug"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if l.Level() != "debug" {
+		t.Errorf("Expected level debug, got %s", l.Level())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/log-level", nil))
+	if w.Code != http.StatusOK || w.Body.String() != `{"level":"debug"}` {
+		t.Errorf("Unexpected response: %d %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/log-level", strings.NewReader(`{"level":"verbose"}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid level, got %d", w.Code)
+	}
+	if l.Level() != "debug" {
+		t.Errorf("Invalid level must not change the level, got %s", l.Level())
+	}
+}
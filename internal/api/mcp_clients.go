@@ -50,6 +50,10 @@ func (s *Server) deleteMcpClientHandler() gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
+		if err := s.mcpService.GetRateLimitService().DeleteClientRateLimits(name); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 		c.Status(http.StatusNoContent)
 	}
 }
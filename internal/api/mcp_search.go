@@ -2,10 +2,13 @@ package api
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/search"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 // searchToolsHandler handles the /api/v0/tools/search endpoint
@@ -50,11 +53,19 @@ func (s *Server) searchToolsHandler() gin.HandlerFunc {
 			opts.OnlyEnabled = onlyEnabled
 		}
 
+		// Get search mode, keyword search is the default
+		mode, err := search.ValidateSearchMode(c.Query("mode"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		opts.Mode = mode
+
 		// Get search service from the mcp service
 		searchService := s.mcpService.GetSearchService()
 
 		// Perform search
-		results, err := searchService.SearchTools(opts)
+		results, err := searchService.Search(c.Request.Context(), opts)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
@@ -63,8 +74,83 @@ func (s *Server) searchToolsHandler() gin.HandlerFunc {
 		// Return results
 		c.JSON(http.StatusOK, gin.H{
 			"query":   query,
+			"mode":    mode,
 			"results": results,
 			"count":   len(results),
 		})
 	}
 }
+
+// searchHandler handles the /api/v0/search endpoint.
+// It searches tools, prompts, MCP servers and tool groups, optionally limited to the kinds given in 'kind'.
+func (s *Server) searchHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Query("q")
+		if query == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing 'q' query parameter"})
+			return
+		}
+
+		opts := search.UnifiedSearchOptions{
+			Query:      query,
+			MaxResults: 20,
+		}
+
+		if maxResultsStr := c.Query("max_results"); maxResultsStr != "" {
+			maxResults, err := strconv.Atoi(maxResultsStr)
+			if err != nil || maxResults < 1 || maxResults > 100 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'max_results' parameter (must be 1-100)"})
+				return
+			}
+			opts.MaxResults = maxResults
+		}
+
+		kinds, err := types.ValidateSearchKinds(c.QueryArray("kind"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if !isAdminRequest(c) && slices.Contains(kinds, types.SearchKindToolGroup) {
+			// like the tool group APIs, tool groups are only visible to admins
+			if len(c.QueryArray("kind")) > 0 {
+				c.JSON(http.StatusForbidden, gin.H{"error": "only admins can search tool groups"})
+				return
+			}
+			kinds = slices.DeleteFunc(kinds, func(k types.SearchKind) bool { return k == types.SearchKindToolGroup })
+		}
+		opts.Kinds = kinds
+
+		if onlyEnabledStr := c.Query("only_enabled"); onlyEnabledStr != "" {
+			onlyEnabled, err := strconv.ParseBool(onlyEnabledStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'only_enabled' parameter (must be true/false)"})
+				return
+			}
+			opts.OnlyEnabled = onlyEnabled
+		}
+
+		results, err := s.mcpService.GetSearchService().SearchAll(opts)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, types.SearchResponse{
+			Query:   query,
+			Kinds:   kinds,
+			Results: results,
+			Count:   len(results),
+		})
+	}
+}
+
+// isAdminRequest returns true if the request was made by an admin user, or if the server runs in development mode,
+// where everyone has admin privileges.
+func isAdminRequest(c *gin.Context) bool {
+	if mode, _ := c.Get("mode"); mode == model.ModeDev {
+		return true
+	}
+	user, _ := c.Get("user")
+	u, ok := user.(*model.User)
+	return ok && u.Role == types.UserRoleAdmin
+}
@@ -1,12 +1,15 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
 )
 
 func (s *Server) registerServerHandler() gin.HandlerFunc {
@@ -72,7 +75,39 @@ func (s *Server) registerServerHandler() gin.HandlerFunc {
 			}
 		}
 
+		if err := server.SetStdioSupervision(input.StdioSupervision); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := server.SetConcurrencyLimits(
+			input.MaxConcurrency, input.MaxQueueDepth, input.QueueTimeoutSeconds,
+		); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := server.SetCallTimeouts(input.CallTimeoutSeconds, input.ToolCallTimeouts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := server.SetRetryPolicy(input.RetryPolicy); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err := server.SetCircuitBreaker(
+			input.CircuitBreakerThreshold, input.CircuitBreakerCooldownSeconds,
+		); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		if err := s.mcpService.RegisterMcpServer(c, server); err != nil {
+			if errors.Is(err, mcp.ErrStdioSandboxRequired) {
+				c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+				return
+			}
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 			return
 		}
@@ -103,59 +138,95 @@ func (s *Server) listServersHandler() gin.HandlerFunc {
 		}
 
 		servers := make([]*types.McpServer, len(records))
-
-		for i, record := range records {
-			servers[i] = &types.McpServer{
-				Name:        record.Name,
-				Transport:   string(record.Transport),
-				Description: record.Description,
+		for i := range records {
+			servers[i], err = s.toServerView(&records[i], isAdminRequest(c))
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
 			}
+		}
+
+		c.JSON(http.StatusOK, servers)
+	}
+}
 
-			switch record.Transport {
-			case types.TransportStreamableHTTP:
-				conf, err := record.GetStreamableHTTPConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting streamable HTTP config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].URL = conf.URL
-			case types.TransportStdio:
-				conf, err := record.GetStdioConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting stdio config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].Command = conf.Command
-				servers[i].Args = conf.Args
-				servers[i].Env = conf.Env
-			default:
-				// transport is SSE
-				conf, err := record.GetSSEConfig()
-				if err != nil {
-					c.JSON(
-						http.StatusInternalServerError,
-						gin.H{
-							"error": fmt.Sprintf("Error getting SSE config for server %s: %v", record.Name, err),
-						},
-					)
-					return
-				}
-				servers[i].URL = conf.URL
+func (s *Server) getServerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		record, err := s.mcpService.GetMcpServer(name)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("MCP server %s not found", name)})
+				return
 			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
 		}
 
-		c.JSON(http.StatusOK, servers)
+		server, err := s.toServerView(record, isAdminRequest(c))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, server)
+	}
+}
+
+// toServerView converts a registered MCP server into its API representation,
+// including its transport-specific configuration and runtime state.
+// The error of the latest health check may reveal internals of the upstream server, so it is only shown to admins.
+func (s *Server) toServerView(record *model.McpServer, admin bool) (*types.McpServer, error) {
+	server := &types.McpServer{
+		Name:        record.Name,
+		Transport:   string(record.Transport),
+		Description: record.Description,
+
+		MaxConcurrency:      record.MaxConcurrency,
+		MaxQueueDepth:       record.MaxQueueDepth,
+		QueueTimeoutSeconds: record.QueueTimeoutSeconds,
+
+		CallTimeoutSeconds: record.CallTimeoutSeconds,
+	}
+	if toolTimeouts, err := record.GetToolCallTimeouts(); err == nil && len(toolTimeouts) > 0 {
+		server.ToolCallTimeouts = toolTimeouts
+	}
+	if policy, err := record.GetRetryPolicy(); err == nil {
+		server.RetryPolicy = policy
+	}
+	server.CircuitBreakerState = string(s.mcpService.GetCircuitBreakerState(record.Name))
+	if health, ok := s.mcpService.GetServerHealth(record.Name); ok {
+		if !admin {
+			health.LastError = ""
+		}
+		server.Health = health
+	}
+
+	switch record.Transport {
+	case types.TransportStreamableHTTP:
+		conf, err := record.GetStreamableHTTPConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get streamable HTTP config for server %s: %w", record.Name, err)
+		}
+		server.URL = conf.URL
+	case types.TransportStdio:
+		conf, err := record.GetStdioConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stdio config for server %s: %w", record.Name, err)
+		}
+		server.Command = conf.Command
+		server.Args = conf.Args
+		server.Env = conf.Env
+		server.StdioSupervision = conf.StdioSupervision
+	default:
+		// transport is SSE
+		conf, err := record.GetSSEConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SSE config for server %s: %w", record.Name, err)
+		}
+		server.URL = conf.URL
 	}
+	return server, nil
 }
 
 func (s *Server) enableServerHandler() gin.HandlerFunc {
@@ -8,7 +8,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/internal/util"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -46,17 +48,8 @@ func (s *Server) verifyUserAuthForAPIAccess() gin.HandlerFunc {
 			return
 		}
 
-		authHeader := c.GetHeader("Authorization")
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-		if token == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
-			return
-		}
-
-		// Verify that the token is valid and corresponds to a user
-		authenticatedUser, err := s.userService.GetUserByAccessToken(token)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid access token: " + err.Error()})
+		authenticatedUser, ok := s.authenticateUser(c)
+		if !ok {
 			return
 		}
 
@@ -72,6 +65,7 @@ func (s *Server) verifyUserAuthForAPIAccess() gin.HandlerFunc {
 		}
 		ctx := util.SetAuditContext(c.Request.Context(), auditCtx)
 		c.Request = c.Request.WithContext(ctx)
+		withLogFields(c, logger.String("user", authenticatedUser.Username))
 
 		c.Next()
 	}
@@ -99,18 +93,48 @@ func (s *Server) requireAdminUser() gin.HandlerFunc {
 
 		authenticatedUser, exists := c.Get("user")
 		if !exists {
+			s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureMissingToken)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "user is not authenticated"})
 			return
 		}
 
-		u, ok := authenticatedUser.(*model.User)
-		if ok && u.Role == types.UserRoleAdmin {
-			c.Next()
+		u, _ := authenticatedUser.(*model.User)
+		if !s.authorizeAdmin(c, u) {
 			return
 		}
+		c.Next()
+	}
+}
+
+// authenticateUser returns the user that the access token of the request belongs to.
+// If the token is missing or invalid, it aborts the request and returns false.
+func (s *Server) authenticateUser(c *gin.Context) (*model.User, bool) {
+	authHeader := c.GetHeader("Authorization")
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+	if token == "" {
+		s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureMissingToken)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing access token"})
+		return nil, false
+	}
+
+	// Verify that the token is valid and corresponds to a user
+	u, err := s.userService.GetUserByAccessToken(token)
+	if err != nil {
+		s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureInvalidToken)
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid access token: " + err.Error()})
+		return nil, false
+	}
+	return u, true
+}
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not authorized to perform this action"})
+// authorizeAdmin aborts the request and returns false unless the given user is an admin.
+func (s *Server) authorizeAdmin(c *gin.Context, u *model.User) bool {
+	if u != nil && u.Role == types.UserRoleAdmin {
+		return true
 	}
+	s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureInsufficientRole)
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "user is not authorized to perform this action"})
+	return false
 }
 
 // requireServerMode is middleware that checks if the server is in a specific mode.
@@ -178,11 +202,13 @@ func (s *Server) checkAuthForMcpProxyAccess() gin.HandlerFunc {
 		authHeader := c.GetHeader("Authorization")
 		token := strings.TrimPrefix(authHeader, "Bearer ")
 		if token == "" {
+			s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureMissingToken)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing MCP client access token"})
 			return
 		}
 		client, err := s.mcpClientService.GetClientByToken(token)
 		if err != nil {
+			s.metrics.RecordAuthFailure(c.Request.Context(), telemetry.AuthFailureInvalidToken)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid MCP client token"})
 			return
 		}
@@ -203,6 +229,7 @@ func (s *Server) checkAuthForMcpProxyAccess() gin.HandlerFunc {
 		}
 		ctx = util.SetAuditContext(ctx, auditCtx)
 		c.Request = c.Request.WithContext(ctx)
+		withLogFields(c, logger.String("mcp_client", client.Name))
 
 		c.Next()
 	}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,8 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/service/config"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcpclient"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
@@ -88,7 +91,7 @@ func TestVerifyUserAuthForAPIAccess(t *testing.T) {
 	defer setup.Cleanup()
 	testDB := setup.DB
 
-	userService := user.NewUserService(testDB)
+	userService := user.NewUserService(testDB, logger.NewNop())
 
 	tests := []struct {
 		name           string
@@ -149,7 +152,7 @@ func TestVerifyUserAuthForAPIAccess(t *testing.T) {
 					c.Set("mode", tt.mode)
 				}
 			})
-			server := &Server{userService: userService}
+			server := &Server{userService: userService, metrics: telemetry.NewNoopCustomMetrics()}
 			router.Use(server.verifyUserAuthForAPIAccess())
 			router.GET("/test", func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -177,7 +180,7 @@ func TestRequireAdminUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	testDB := testhelpers.SetupTestDB(t).DB
-	userService := user.NewUserService(testDB)
+	userService := user.NewUserService(testDB, logger.NewNop())
 
 	tests := []struct {
 		name           string
@@ -228,7 +231,7 @@ func TestRequireAdminUser(t *testing.T) {
 					c.Set("user", tt.user)
 				}
 			})
-			server := &Server{userService: userService}
+			server := &Server{userService: userService, metrics: telemetry.NewNoopCustomMetrics()}
 			router.Use(server.requireAdminUser())
 			router.GET("/test", func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -318,7 +321,7 @@ func TestRequireServerMode(t *testing.T) {
 					c.Set("mode", tt.contextMode)
 				}
 			})
-			server := &Server{}
+			server := &Server{metrics: telemetry.NewNoopCustomMetrics()}
 			router.Use(server.requireServerMode(tt.requiredMode))
 			router.GET("/test", func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -345,7 +348,7 @@ func TestCheckAuthForMcpProxyAccess(t *testing.T) {
 	defer setup.Cleanup()
 	testDB := setup.DB
 
-	mcpClientService := mcpclient.NewMCPClientService(testDB)
+	mcpClientService := mcpclient.NewMCPClientService(testDB, logger.NewNop())
 
 	tests := []struct {
 		name           string
@@ -411,7 +414,7 @@ func TestCheckAuthForMcpProxyAccess(t *testing.T) {
 					c.Set("mode", tt.mode)
 				}
 			})
-			server := &Server{mcpClientService: mcpClientService}
+			server := &Server{mcpClientService: mcpClientService, metrics: telemetry.NewNoopCustomMetrics()}
 			router.Use(server.checkAuthForMcpProxyAccess())
 			router.GET("/test", func(c *gin.Context) {
 				c.JSON(http.StatusOK, gin.H{"status": "success"})
@@ -442,7 +445,7 @@ func TestMiddlewareIntegration(t *testing.T) {
 	testDB := setup.DB
 
 	configService := config.NewServerConfigService(testDB)
-	userService := user.NewUserService(testDB)
+	userService := user.NewUserService(testDB, logger.NewNop())
 
 	// Setup config
 	_, err := configService.Init(model.ModeEnterprise)
@@ -472,6 +475,7 @@ func TestMiddlewareIntegration(t *testing.T) {
 	server := &Server{
 		configService: configService,
 		userService:   userService,
+		metrics:       telemetry.NewNoopCustomMetrics(),
 	}
 	router := gin.New()
 	router.Use(server.requireInitialized())
@@ -495,3 +499,43 @@ func TestMiddlewareIntegration(t *testing.T) {
 		t.Errorf("Expected body %s, got %s", expectedBody, w.Body.String())
 	}
 }
+
+// authFailureMetrics is a telemetry.CustomMetrics that records the reasons of auth failures
+type authFailureMetrics struct {
+	telemetry.NoopCustomMetrics
+	reasons []telemetry.AuthFailureReason
+}
+
+func (m *authFailureMetrics) RecordAuthFailure(_ context.Context, reason telemetry.AuthFailureReason) {
+	m.reasons = append(m.reasons, reason)
+}
+
+func TestAuthFailureMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	metrics := &authFailureMetrics{}
+	server := &Server{metrics: metrics}
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("mode", model.ModeEnterprise)
+		if c.GetHeader("Authorization") != "" {
+			// stand in for verifyUserAuthForAPIAccess to test the role check on its own
+			c.Set("user", &model.User{Username: "bob", Role: types.UserRoleUser})
+		}
+	})
+	router.GET("/auth", server.verifyUserAuthForAPIAccess())
+	router.GET("/admin", server.requireAdminUser())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/auth", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	expected := []telemetry.AuthFailureReason{telemetry.AuthFailureMissingToken, telemetry.AuthFailureInsufficientRole}
+	testhelpers.AssertEqual(t, len(expected), len(metrics.reasons))
+	for i := range expected {
+		testhelpers.AssertEqual(t, expected[i], metrics.reasons[i])
+	}
+}
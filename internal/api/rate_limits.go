@@ -0,0 +1,80 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// listRateLimitsHandler returns all rate limits and quotas configured in mcpjungle.
+func (s *Server) listRateLimitsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limits, err := s.mcpService.GetRateLimitService().ListRateLimits()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := make([]*types.RateLimit, len(limits))
+		for i, l := range limits {
+			resp[i] = &types.RateLimit{
+				Scope:             string(l.Scope),
+				Target:            l.Target,
+				RequestsPerMinute: l.RequestsPerMinute,
+				Burst:             l.Burst,
+				DailyQuota:        l.DailyQuota,
+				MonthlyQuota:      l.MonthlyQuota,
+			}
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// setRateLimitHandler creates a rate limit or replaces the existing one for the same scope and target.
+func (s *Server) setRateLimitHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input types.RateLimit
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		scope, err := types.ValidateRateLimitScope(input.Scope)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		l := &model.RateLimit{
+			Scope:             scope,
+			Target:            input.Target,
+			RequestsPerMinute: input.RequestsPerMinute,
+			Burst:             input.Burst,
+			DailyQuota:        input.DailyQuota,
+			MonthlyQuota:      input.MonthlyQuota,
+		}
+		if err := s.mcpService.GetRateLimitService().SetRateLimit(l); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, input)
+	}
+}
+
+// deleteRateLimitHandler removes the rate limit for a scope and target.
+func (s *Server) deleteRateLimitHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope, err := types.ValidateRateLimitScope(c.Param("scope"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		target := c.Param("target")
+		if err := s.mcpService.GetRateLimitService().DeleteRateLimit(scope, target); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
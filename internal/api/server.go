@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mark3labs/mcp-go/server"
@@ -15,10 +16,12 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/service/toolgroup"
 	"github.com/mcpjungle/mcpjungle/internal/service/user"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/version"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"gorm.io/gorm"
 )
 
 const (
@@ -48,6 +51,14 @@ type ServerOptions struct {
 
 	OtelProviders *telemetry.Providers
 	Metrics       telemetry.CustomMetrics
+
+	// Logger is used to log requests. If nil, nothing is logged.
+	Logger logger.Logger
+
+	// DB is the database connection, used by the readiness probe to check the database.
+	DB *gorm.DB
+	// CriticalServers lists the MCP servers that must be healthy for mcpjungle to report itself as ready.
+	CriticalServers []string
 }
 
 // Server represents the MCPJungle registry server that handles MCP proxy and API requests
@@ -68,6 +79,14 @@ type Server struct {
 	otelProviders *telemetry.Providers
 	metrics       telemetry.CustomMetrics
 
+	logger logger.Logger
+
+	db              *gorm.DB
+	criticalServers []string
+	// migrationsChecked is set once the readiness probe found the DB migrations to be applied,
+	// so that the schema is not inspected on every probe.
+	migrationsChecked atomic.Bool
+
 	// groupMcpServers keeps track of mcp-go's server.SSEServer instances created for each tool group.
 	// These instances serve the requests made to tool groups' SSE tools.
 	// We need to maintain one instance for each group for sse to work correctly.
@@ -87,6 +106,15 @@ func NewServer(opts *ServerOptions) (*Server, error) {
 		toolGroupService:  opts.ToolGroupService,
 		otelProviders:     opts.OtelProviders,
 		metrics:           opts.Metrics,
+		logger:            opts.Logger,
+		db:                opts.DB,
+		criticalServers:   opts.CriticalServers,
+	}
+	if s.logger == nil {
+		s.logger = logger.NewNop()
+	}
+	if s.metrics == nil {
+		s.metrics = telemetry.NewNoopCustomMetrics()
 	}
 
 	// Set up the router after the server is fully initialized
@@ -145,23 +173,23 @@ func (s *Server) Start() error {
 // setupRouter sets up the Gin router with the MCP proxy server and API endpoints.
 func (s *Server) setupRouter() (*gin.Engine, error) {
 	gin.SetMode(gin.ReleaseMode)
-	r := gin.Default()
+	r := gin.New()
+	r.Use(gin.Recovery(), s.requestLogger())
 
-	// if otel is enabled, setup prometheus metrics endpoint
-	if s.otelProviders != nil && s.otelProviders.IsEnabled() {
+	if s.otelProviders != nil && (s.otelProviders.IsEnabled() || s.otelProviders.IsTracingEnabled()) {
 		// instrument gin
+		// this also starts the span of every inbound request, including MCP requests
 		r.Use(otelgin.Middleware(s.otelProviders.ServiceName()))
+	}
 
-		// expose prometheus metrics endpoint
+	// if otel is enabled, setup prometheus metrics endpoint
+	if s.otelProviders != nil && s.otelProviders.IsEnabled() {
 		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	}
 
-	r.GET(
-		"/health",
-		func(c *gin.Context) {
-			c.JSON(200, gin.H{"status": "ok"})
-		},
-	)
+	r.GET("/health", s.requireAdminForVerboseHealth(), s.healthHandler())
+	r.GET("/livez", s.livenessHandler())
+	r.GET("/readyz", s.readinessHandler())
 
 	r.GET(
 		"/metadata",
@@ -232,9 +260,11 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 	userAPI := apiV0.Group("/")
 	{
 		userAPI.GET("/servers", s.listServersHandler())
+		userAPI.GET("/servers/:name", s.getServerHandler())
 
 		userAPI.GET("/tools", s.listToolsHandler())
 		userAPI.GET("/tools/search", s.searchToolsHandler())
+		userAPI.GET("/search", s.searchHandler())
 		userAPI.POST("/tools/invoke", s.invokeToolHandler())
 		userAPI.GET("/tool", s.getToolHandler())
 
@@ -253,6 +283,7 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		adminAPI.DELETE("/servers/:name", s.deregisterServerHandler())
 		adminAPI.POST("/servers/:name/enable", s.enableServerHandler())
 		adminAPI.POST("/servers/:name/disable", s.disableServerHandler())
+		adminAPI.GET("/servers/:name/logs", s.serverLogsHandler())
 
 		adminAPI.POST("/tools/enable", s.enableToolsHandler())
 		adminAPI.POST("/tools/disable", s.disableToolsHandler())
@@ -297,6 +328,18 @@ func (s *Server) setupRouter() (*gin.Engine, error) {
 		adminAPI.GET("/tool-groups", s.listToolGroupsHandler())
 		adminAPI.DELETE("/tool-groups/:name", s.deleteToolGroupHandler())
 		adminAPI.PUT("/tool-groups/:name", s.updateToolGroupHandler())
+
+		// endpoints for managing rate limits and quotas
+		adminAPI.GET("/rate-limits", s.listRateLimitsHandler())
+		adminAPI.PUT("/rate-limits", s.setRateLimitHandler())
+		adminAPI.DELETE("/rate-limits/:scope/:target", s.deleteRateLimitHandler())
+
+		// endpoint for usage analytics of tools
+		adminAPI.GET("/usage", s.usageReportHandler())
+
+		// endpoints for changing the log level at runtime
+		adminAPI.GET("/log-level", s.getLogLevelHandler())
+		adminAPI.PUT("/log-level", s.setLogLevelHandler())
 	}
 
 	return r, nil
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"gorm.io/gorm"
+)
+
+// defaultServerLogLines is the number of recent log lines returned if the client doesn't ask for a specific number
+const defaultServerLogLines = 100
+
+// serverLogsHandler handles the /api/v0/servers/:name/logs endpoint.
+// It returns the recent stderr output of a stdio MCP server.
+// If the 'follow' query parameter is true, new lines are streamed to the client as server-sent events.
+func (s *Server) serverLogsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		lines := defaultServerLogLines
+		if linesStr := c.Query("lines"); linesStr != "" {
+			n, err := strconv.Atoi(linesStr)
+			if err != nil || n < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'lines' parameter (must be a non-negative integer)"})
+				return
+			}
+			lines = n
+		}
+
+		follow := false
+		if followStr := c.Query("follow"); followStr != "" {
+			f, err := strconv.ParseBool(followStr)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'follow' parameter (must be true/false)"})
+				return
+			}
+			follow = f
+		}
+
+		if !follow {
+			logs, err := s.mcpService.GetServerLogs(name, lines)
+			if err != nil {
+				s.writeServerLogsError(c, name, err)
+				return
+			}
+			c.JSON(http.StatusOK, logs)
+			return
+		}
+
+		recent, ch, cancel, err := s.mcpService.FollowServerLogs(name, lines)
+		if err != nil {
+			s.writeServerLogsError(c, name, err)
+			return
+		}
+		defer cancel()
+
+		for _, l := range recent {
+			c.SSEvent("log", l)
+		}
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case l, ok := <-ch:
+				if !ok {
+					// the server was deregistered
+					return false
+				}
+				c.SSEvent("log", l)
+				return true
+			case <-c.Request.Context().Done():
+				return false
+			}
+		})
+	}
+}
+
+func (s *Server) writeServerLogsError(c *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("MCP server %s not found", name)})
+	case errors.Is(err, mcp.ErrServerLogsUnsupported):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -73,8 +73,9 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 
 		resp := &types.GetToolGroupResponse{
 			ToolGroup: &types.ToolGroup{
-				Name:        group.Name,
-				Description: group.Description,
+				Name:            group.Name,
+				Description:     group.Description,
+				LazyToolLoading: group.LazyToolLoading,
 			},
 			ToolGroupEndpoints: getToolGroupEndpoints(c, group.Name),
 		}
@@ -103,6 +104,46 @@ func (s *Server) getToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.IncludedServers = servers
 
+		// Get the filter of the tools included from servers
+		resp.IncludedServersFilter, err = group.GetIncludedServersFilter()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting included servers filter of group: %s", err.Error())},
+			)
+			return
+		}
+
+		// Get tool rules
+		resp.ToolRules, err = group.GetToolRules()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool rules of group: %s", err.Error())},
+			)
+			return
+		}
+
+		// Get tool aliases
+		resp.ToolAliases, err = group.GetToolAliases()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool aliases of group: %s", err.Error())},
+			)
+			return
+		}
+
+		// Get tool argument presets
+		resp.ToolArguments, err = group.GetToolArguments()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool arguments of group: %s", err.Error())},
+			)
+			return
+		}
+
 		// Get excluded tools
 		var excludedTools []string
 		excludedTools, err = group.GetExcludedTools()
@@ -192,12 +233,14 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 		resp := &types.UpdateToolGroupResponse{
 			Name: name,
 			Old: &types.ToolGroup{
-				Name:        originalConf.Name,
-				Description: originalConf.Description,
+				Name:            originalConf.Name,
+				Description:     originalConf.Description,
+				LazyToolLoading: originalConf.LazyToolLoading,
 			},
 			New: &types.ToolGroup{
-				Name:        input.Name,
-				Description: input.Description,
+				Name:            input.Name,
+				Description:     input.Description,
+				LazyToolLoading: input.LazyToolLoading,
 			},
 		}
 
@@ -223,6 +266,42 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.Old.IncludedServers = origServers
 
+		resp.Old.IncludedServersFilter, err = originalConf.GetIncludedServersFilter()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting included servers filter of the original group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.Old.ToolRules, err = originalConf.GetToolRules()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool rules of the original group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.Old.ToolAliases, err = originalConf.GetToolAliases()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool aliases of the original group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.Old.ToolArguments, err = originalConf.GetToolArguments()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool arguments of the original group config: %s", err.Error())},
+			)
+			return
+		}
+
 		var origExcluded []string
 		origExcluded, err = originalConf.GetExcludedTools()
 		if err != nil {
@@ -256,6 +335,42 @@ func (s *Server) updateToolGroupHandler() gin.HandlerFunc {
 		}
 		resp.New.IncludedServers = newServers
 
+		resp.New.IncludedServersFilter, err = input.GetIncludedServersFilter()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting included servers filter of the new group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.New.ToolRules, err = input.GetToolRules()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool rules of the new group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.New.ToolAliases, err = input.GetToolAliases()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool aliases of the new group config: %s", err.Error())},
+			)
+			return
+		}
+
+		resp.New.ToolArguments, err = input.GetToolArguments()
+		if err != nil {
+			c.JSON(
+				http.StatusInternalServerError,
+				gin.H{"error": fmt.Sprintf("error getting tool arguments of the new group config: %s", err.Error())},
+			)
+			return
+		}
+
 		var newExcluded []string
 		newExcluded, err = input.GetExcludedTools()
 		if err != nil {
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mcpjungle/mcpjungle/internal/service/usage"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// defaultUsageReportSince is the time range of a usage report if the client doesn't specify one
+const defaultUsageReportSince = "7d"
+
+// usageReportHandler returns the aggregated tool usage in a time range.
+// It accepts the query parameters "since" (eg- "7d", "12h" or an RFC3339 timestamp), "until" (RFC3339),
+// "by" (tool, client or server) and "limit" (the maximum number of rows, 0 for all).
+func (s *Server) usageReportHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+
+		since, err := usage.ParseSince(c.DefaultQuery("since", defaultUsageReportSince), now)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		until := now
+		if u := c.Query("until"); u != "" {
+			if until, err = time.Parse(time.RFC3339, u); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid until time, expected an RFC3339 timestamp"})
+				return
+			}
+		}
+		if !since.Before(until) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "since must be before until"})
+			return
+		}
+		by, err := types.ValidateUsageGroupBy(c.Query("by"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		limit := 0
+		if l := c.Query("limit"); l != "" {
+			if limit, err = strconv.Atoi(l); err != nil || limit < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a non-negative integer"})
+				return
+			}
+		}
+
+		svc := s.mcpService.GetUsageService()
+		rows, err := svc.Report(since, until, by, limit)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		tools, err := s.mcpService.ListTools()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		unused, err := svc.UnusedTools(tools, since)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, &types.UsageReport{
+			Since:       since,
+			Until:       until,
+			GroupBy:     by,
+			Rows:        rows,
+			UnusedTools: unused,
+		})
+	}
+}
@@ -38,6 +38,13 @@ func getSQLiteDBPath() string {
 	return dbFilename
 }
 
+// SQLiteDBFiles returns the paths of the files of the embedded SQLite database that NewDBConnection uses
+// if the DSN is empty: the database file and its write-ahead log, shared memory and rollback journal files.
+func SQLiteDBFiles() []string {
+	dbPath := getSQLiteDBPath()
+	return []string{dbPath, dbPath + "-wal", dbPath + "-shm", dbPath + "-journal"}
+}
+
 // NewDBConnection creates a new database connection based on the provided DSN.
 // If the DSN is empty, it falls back to an embedded SQLite database.
 // For backward compatibility, it will use an existing "mcp.db" file if present,
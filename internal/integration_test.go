@@ -10,6 +10,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	mcpService "github.com/mcpjungle/mcpjungle/internal/service/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
@@ -40,7 +41,7 @@ func TestPromptsIntegration(t *testing.T) {
 	mcpMetrics := telemetry.NewNoopCustomMetrics()
 
 	// Create MCP service
-	service, err := mcpService.NewMCPService(db, mcpProxyServer, sseMcpProxyServer, mcpMetrics)
+	service, err := mcpService.NewMCPService(db, mcpProxyServer, sseMcpProxyServer, mcpMetrics, logger.NewNop())
 	require.NoError(t, err)
 
 	// Create test server in database
@@ -3,36 +3,63 @@ package migrations
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"gorm.io/gorm"
 )
 
+// models lists all the models whose tables are managed by the migrations, in the order they are migrated.
+var models = []any{
+	&model.McpServer{},
+	&model.Tool{},
+	&model.ServerConfig{},
+	&model.User{},
+	&model.McpClient{},
+	&model.ToolGroup{},
+	&model.Prompt{},
+	&model.AuditLog{},
+	&model.RateLimit{},
+	&model.ClientQuotaUsage{},
+	&model.ToolUsage{},
+	&model.ToolEmbedding{},
+}
+
 // Migrate performs the database migration for the application.
 func Migrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&model.McpServer{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpServer model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.Tool{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Tool model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.ServerConfig{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ServerConfig model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.User{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for User model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.McpClient{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for McpClient model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.ToolGroup{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for ToolGroup model: %v", err)
+	for _, m := range models {
+		if err := db.AutoMigrate(m); err != nil {
+			return fmt.Errorf("auto‑migration failed for %s model: %v", modelName(m), err)
+		}
 	}
-	if err := db.AutoMigrate(&model.Prompt{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for Prompt model: %v", err)
-	}
-	if err := db.AutoMigrate(&model.AuditLog{}); err != nil {
-		return fmt.Errorf("auto‑migration failed for AuditLog model: %v", err)
+	return nil
+}
+
+// Check verifies that the migrations have been applied to the database,
+// ie, the tables and columns of all models exist.
+func Check(db *gorm.DB) error {
+	migrator := db.Migrator()
+	for _, m := range models {
+		if !migrator.HasTable(m) {
+			return fmt.Errorf("table for %s model does not exist", modelName(m))
+		}
+
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return fmt.Errorf("failed to parse %s model: %v", modelName(m), err)
+		}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName == "" {
+				continue
+			}
+			if !migrator.HasColumn(m, f.DBName) {
+				return fmt.Errorf("column %s for %s model does not exist", f.DBName, modelName(m))
+			}
+		}
 	}
 	return nil
 }
+
+func modelName(m any) string {
+	return reflect.TypeOf(m).Elem().Name()
+}
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"gorm.io/gorm"
+)
+
+func TestCheck(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	testhelpers.AssertNoError(t, err)
+
+	err = Check(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "McpServer")
+
+	testhelpers.AssertNoError(t, Migrate(db))
+	testhelpers.AssertNoError(t, Check(db))
+
+	// a column added by a newer version of mcpjungle is missing
+	testhelpers.AssertNoError(t, db.Migrator().DropColumn(&model.McpServer{}, "call_timeout_seconds"))
+	err = Check(db)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "call_timeout_seconds")
+}
@@ -14,6 +14,27 @@ type ToolGroupToolChecker interface {
 	GetToolGroup(name string) (*ToolGroup, error)
 }
 
+// ToolAccessChecker defines the interface needed to check whether an MCP client can access a tool.
+// Implementations are expected to cache access decisions so that checks are cheap on the tool call path.
+type ToolAccessChecker interface {
+	// CheckClientToolAccess returns true if the client is allowed to access the tool with the given canonical name.
+	CheckClientToolAccess(c *McpClient, toolName string) (bool, error)
+}
+
+// GroupToolAccessChecker defines the interface needed to check whether an MCP client can access a tool
+// that is scoped to a tool group, ie- that MCP clients can only call through the group's MCP proxy server.
+type GroupToolAccessChecker interface {
+	// CheckClientGroupToolAccess returns true if the client is allowed to access the tool that the group exposes
+	// under the given name. canonicalName is the canonical name of the underlying tool.
+	CheckClientGroupToolAccess(c *McpClient, groupName, toolName, canonicalName string) (bool, error)
+}
+
+// PromptAccessChecker defines the interface needed to check whether an MCP client can access a prompt.
+type PromptAccessChecker interface {
+	// CheckClientPromptAccess returns true if the client is allowed to access the prompt with the given canonical name.
+	CheckClientPromptAccess(c *McpClient, promptName string) (bool, error)
+}
+
 // McpClient represents MCP clients and their access to the MCP Servers provided MCPJungle MCP server
 type McpClient struct {
 	gorm.Model
@@ -87,6 +108,18 @@ func (c *McpClient) CheckHasToolAccess(toolName string, checker ToolGroupToolChe
 	return c.CheckHasServerAccess(serverName), nil
 }
 
+// CheckHasPromptAccess returns true if this client has server-level access to the MCP server
+// that provides the given prompt.
+// The prompt name must be in its canonical form (server__prompt).
+// It does not take tool groups into account, use a PromptAccessChecker for that.
+func (c *McpClient) CheckHasPromptAccess(promptName string) bool {
+	serverName, _, ok := splitServerToolName(promptName)
+	if !ok {
+		return false
+	}
+	return c.CheckHasServerAccess(serverName)
+}
+
 // toolExistsInAllowedGroups checks if a tool exists in any of the allowed tool groups.
 func (c *McpClient) toolExistsInAllowedGroups(toolName string, allowedGroups []string, checker ToolGroupToolChecker, resolver ToolGroupResolver) (bool, error) {
 	for _, groupName := range allowedGroups {
@@ -96,8 +129,8 @@ func (c *McpClient) toolExistsInAllowedGroups(toolName string, allowedGroups []s
 			continue
 		}
 
-		// Resolve effective tools for this group
-		effectiveTools, err := group.ResolveEffectiveTools(resolver)
+		// Resolve the tools this group grants access to under their canonical names
+		effectiveTools, err := group.ResolveAccessibleTools(resolver)
 		if err != nil {
 			return false, fmt.Errorf("failed to resolve tools for group %s: %w", groupName, err)
 		}
@@ -29,6 +29,14 @@ func (m *mockToolGroupResolver) ListToolsByServer(serverName string) ([]Tool, er
 	return []Tool{}, nil
 }
 
+func (m *mockToolGroupResolver) ListTools() ([]Tool, error) {
+	var tools []Tool
+	for _, serverTools := range m.serverTools {
+		tools = append(tools, serverTools...)
+	}
+	return tools, nil
+}
+
 func (m *mockToolGroupResolver) ListPromptsByServer(serverName string) ([]Prompt, error) {
 	return []Prompt{}, nil
 }
@@ -3,6 +3,10 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
@@ -28,6 +32,8 @@ type StdioConfig struct {
 
 	// Env describes the environment variables to pass to the MCP server
 	Env map[string]string `json:"env,omitempty"`
+
+	types.StdioSupervision
 }
 
 type SSEConfig struct {
@@ -49,6 +55,39 @@ type McpServer struct {
 	// Config describes the transport-specific configuration for the MCP server.
 	// It contains the JSON representation of either StreamableHTTPConfig or StdioConfig.
 	Config datatypes.JSON `json:"config" gorm:"type:jsonb;not null"`
+
+	// MaxConcurrency is the maximum number of calls that mcpjungle forwards to this server at the same time.
+	// Calls above the limit wait in a FIFO queue until a slot frees up.
+	// 0 means that the number of concurrent calls is not limited.
+	MaxConcurrency int `json:"max_concurrency" gorm:"not null;default:0"`
+
+	// MaxQueueDepth is the maximum number of calls that can wait for a slot when MaxConcurrency is reached.
+	// Calls arriving at a full queue fail immediately. 0 means that the default depth is used.
+	MaxQueueDepth int `json:"max_queue_depth" gorm:"not null;default:0"`
+
+	// QueueTimeoutSeconds is the maximum time a call waits in the queue before it fails.
+	// 0 means that the default timeout is used.
+	QueueTimeoutSeconds int `json:"queue_timeout_seconds" gorm:"not null;default:0"`
+
+	// CallTimeoutSeconds is the maximum time a tool call to this server may take.
+	// 0 means that mcpjungle's default call timeout is used.
+	CallTimeoutSeconds int `json:"call_timeout_seconds" gorm:"not null;default:0"`
+
+	// ToolCallTimeouts overrides CallTimeoutSeconds for individual tools of this server.
+	// It contains a JSON object mapping tool names (without the server prefix) to timeouts in seconds.
+	ToolCallTimeouts datatypes.JSON `json:"tool_call_timeouts" gorm:"type:jsonb"`
+
+	// RetryPolicy contains the JSON representation of types.RetryPolicy.
+	// If it is empty, failed calls to this server are not retried.
+	RetryPolicy datatypes.JSON `json:"retry_policy" gorm:"type:jsonb"`
+
+	// CircuitBreakerThreshold is the number of consecutive failures after which calls to this server
+	// are failed fast. 0 means that the default threshold is used.
+	CircuitBreakerThreshold int `json:"circuit_breaker_threshold" gorm:"not null;default:0"`
+
+	// CircuitBreakerCooldownSeconds is the time after which an open circuit breaker lets a call through again.
+	// 0 means that the default cooldown is used.
+	CircuitBreakerCooldownSeconds int `json:"circuit_breaker_cooldown_seconds" gorm:"not null;default:0"`
 }
 
 // NewStreamableHTTPServer creates a new MCP server with streamable HTTP transport configuration.
@@ -150,3 +189,178 @@ func (s *McpServer) GetSSEConfig() (*SSEConfig, error) {
 	}
 	return &config, nil
 }
+
+// SetConcurrencyLimits validates and sets the concurrency limits of the server.
+func (s *McpServer) SetConcurrencyLimits(maxConcurrency, maxQueueDepth, queueTimeoutSeconds int) error {
+	if maxConcurrency < 0 || maxQueueDepth < 0 || queueTimeoutSeconds < 0 {
+		return errors.New("max_concurrency, max_queue_depth and queue_timeout_seconds must not be negative")
+	}
+	s.MaxConcurrency = maxConcurrency
+	s.MaxQueueDepth = maxQueueDepth
+	s.QueueTimeoutSeconds = queueTimeoutSeconds
+	return nil
+}
+
+// SetCallTimeouts validates and sets the call timeouts of the server and its individual tools.
+func (s *McpServer) SetCallTimeouts(callTimeoutSeconds int, toolCallTimeouts map[string]int) error {
+	if callTimeoutSeconds < 0 {
+		return errors.New("call_timeout_seconds must not be negative")
+	}
+	for tool, t := range toolCallTimeouts {
+		if t <= 0 {
+			return fmt.Errorf("call timeout for tool %s must be a positive number of seconds", tool)
+		}
+	}
+	s.CallTimeoutSeconds = callTimeoutSeconds
+	s.ToolCallTimeouts = nil
+	if len(toolCallTimeouts) > 0 {
+		b, err := json.Marshal(toolCallTimeouts)
+		if err != nil {
+			return fmt.Errorf("failed to marshal tool call timeouts: %w", err)
+		}
+		s.ToolCallTimeouts = b
+	}
+	return nil
+}
+
+// GetToolCallTimeouts returns the per-tool call timeouts (in seconds) configured for this server.
+func (s *McpServer) GetToolCallTimeouts() (map[string]int, error) {
+	timeouts := make(map[string]int)
+	if len(s.ToolCallTimeouts) == 0 {
+		return timeouts, nil
+	}
+	if err := json.Unmarshal(s.ToolCallTimeouts, &timeouts); err != nil {
+		return nil, err
+	}
+	return timeouts, nil
+}
+
+// ResolveToolCallTimeout returns the timeout that applies to a call of the given tool of this server.
+// toolName must not contain the server prefix.
+// A tool-specific timeout takes precedence over the server's timeout, which takes precedence over defaultTimeout.
+func (s *McpServer) ResolveToolCallTimeout(toolName string, defaultTimeout time.Duration) time.Duration {
+	if timeouts, err := s.GetToolCallTimeouts(); err == nil {
+		if t, ok := timeouts[toolName]; ok && t > 0 {
+			return time.Duration(t) * time.Second
+		}
+	}
+	if s.CallTimeoutSeconds > 0 {
+		return time.Duration(s.CallTimeoutSeconds) * time.Second
+	}
+	return defaultTimeout
+}
+
+// SetRetryPolicy validates and sets the retry policy of the server. A nil policy disables retries.
+func (s *McpServer) SetRetryPolicy(p *types.RetryPolicy) error {
+	s.RetryPolicy = nil
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 1 {
+		return errors.New("retry_policy.max_attempts must be at least 1")
+	}
+	if p.InitialBackoffMs < 0 || p.MaxBackoffMs < 0 {
+		return errors.New("retry_policy backoff durations must not be negative")
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal retry policy: %w", err)
+	}
+	s.RetryPolicy = b
+	return nil
+}
+
+// GetRetryPolicy returns the retry policy of the server, or nil if it doesn't have one.
+func (s *McpServer) GetRetryPolicy() (*types.RetryPolicy, error) {
+	if len(s.RetryPolicy) == 0 || string(s.RetryPolicy) == "null" {
+		return nil, nil
+	}
+	var p types.RetryPolicy
+	if err := json.Unmarshal(s.RetryPolicy, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SetCircuitBreaker validates and sets the circuit breaker settings of the server.
+func (s *McpServer) SetCircuitBreaker(threshold, cooldownSeconds int) error {
+	if threshold < 0 || cooldownSeconds < 0 {
+		return errors.New("circuit_breaker_threshold and circuit_breaker_cooldown_seconds must not be negative")
+	}
+	s.CircuitBreakerThreshold = threshold
+	s.CircuitBreakerCooldownSeconds = cooldownSeconds
+	return nil
+}
+
+// MaxErrorLogLines is the maximum number of stderr lines of a stdio server that can be appended to an error.
+const MaxErrorLogLines = 100
+
+// SetStdioSupervision validates and sets the settings used to run the process of a stdio server.
+// It fails if any setting is given for a server that doesn't use the stdio transport.
+func (s *McpServer) SetStdioSupervision(sup types.StdioSupervision) error {
+	if s.Transport != types.TransportStdio {
+		if sup.WorkDir != "" || len(sup.EnvAllowList) > 0 || !sup.ResourceLimits.IsZero() ||
+			sup.RunAsUser != "" || sup.RunAsGroup != "" || sup.KillTimeoutSeconds != 0 || sup.Sandbox != nil ||
+			sup.ErrorLogLines != 0 {
+			return errors.New("work_dir, env_allow_list, resource_limits, run_as_user, run_as_group, " +
+				"kill_timeout_seconds, sandbox and error_log_lines are only supported for the stdio transport")
+		}
+		return nil
+	}
+
+	if sup.WorkDir != "" && !filepath.IsAbs(sup.WorkDir) {
+		return errors.New("work_dir must be an absolute path")
+	}
+	for _, name := range sup.EnvAllowList {
+		if name == "" || name == "*" || strings.Contains(name, "=") {
+			return fmt.Errorf("invalid env_allow_list entry '%s'", name)
+		}
+	}
+	if sup.KillTimeoutSeconds < 0 {
+		return errors.New("kill_timeout_seconds must not be negative")
+	}
+	if sup.ErrorLogLines < 0 || sup.ErrorLogLines > MaxErrorLogLines {
+		return fmt.Errorf("error_log_lines must be between 0 and %d", MaxErrorLogLines)
+	}
+	if sup.ResourceLimits.IsZero() {
+		sup.ResourceLimits = nil
+	}
+	if sup.Sandbox.IsEnabled() {
+		for i, p := range sup.Sandbox.WritablePaths {
+			if !filepath.IsAbs(p) {
+				return fmt.Errorf("sandbox writable path '%s' must be an absolute path", p)
+			}
+			p = filepath.Clean(p)
+			if p == "/tmp" || strings.HasPrefix(p, "/tmp/") {
+				return fmt.Errorf("sandbox writable path '%s' must not be inside /tmp, which is private to the sandbox", p)
+			}
+			sup.Sandbox.WritablePaths[i] = p
+		}
+	} else {
+		sup.Sandbox = nil
+	}
+
+	conf, err := s.GetStdioConfig()
+	if err != nil {
+		return err
+	}
+	conf.StdioSupervision = sup
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stdio config: %w", err)
+	}
+	s.Config = b
+	return nil
+}
+
+// IsSandboxed returns true if the server uses the stdio transport and its process runs in a sandbox.
+func (s *McpServer) IsSandboxed() bool {
+	if s.Transport != types.TransportStdio {
+		return false
+	}
+	conf, err := s.GetStdioConfig()
+	if err != nil {
+		return false
+	}
+	return conf.Sandbox.IsEnabled()
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestResolveToolCallTimeout(t *testing.T) {
+	s := &McpServer{Name: "github"}
+	if err := s.SetCallTimeouts(30, map[string]int{"search_code": 120}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		server   *McpServer
+		tool     string
+		expected time.Duration
+	}{
+		{"tool-specific timeout", s, "search_code", 120 * time.Second},
+		{"server timeout", s, "create_issue", 30 * time.Second},
+		{"default timeout", &McpServer{Name: "slack"}, "post_message", time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.server.ResolveToolCallTimeout(tt.tool, time.Minute)
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetCallTimeoutsValidation(t *testing.T) {
+	s := &McpServer{Name: "github"}
+	if err := s.SetCallTimeouts(-1, nil); err == nil {
+		t.Error("expected an error for a negative server timeout")
+	}
+	if err := s.SetCallTimeouts(0, map[string]int{"search_code": 0}); err == nil {
+		t.Error("expected an error for a non-positive tool timeout")
+	}
+}
+
+func TestSetStdioSupervision(t *testing.T) {
+	s, err := NewStdioServer("filesystem", "", "npx", []string{"-y", "server-filesystem"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sup := types.StdioSupervision{
+		WorkDir:        "/srv/mcp",
+		EnvAllowList:   []string{"AWS_*"},
+		ResourceLimits: &types.StdioResourceLimits{MemoryMB: 512},
+		RunAsUser:      "nobody",
+	}
+	if err := s.SetStdioSupervision(sup); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	conf, err := s.GetStdioConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.Command != "npx" || conf.WorkDir != "/srv/mcp" || conf.RunAsUser != "nobody" ||
+		conf.ResourceLimits == nil || conf.ResourceLimits.MemoryMB != 512 {
+		t.Errorf("unexpected stdio config: %+v", conf)
+	}
+
+	if err := s.SetStdioSupervision(types.StdioSupervision{WorkDir: "relative/dir"}); err == nil {
+		t.Error("expected an error for a relative work_dir")
+	}
+	if err := s.SetStdioSupervision(types.StdioSupervision{EnvAllowList: []string{"FOO=bar"}}); err == nil {
+		t.Error("expected an error for an invalid env_allow_list entry")
+	}
+	if err := s.SetStdioSupervision(types.StdioSupervision{ErrorLogLines: MaxErrorLogLines + 1}); err == nil {
+		t.Error("expected an error for too many error_log_lines")
+	}
+
+	h, err := NewStreamableHTTPServer("github", "", "https://example.com/mcp", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h.SetStdioSupervision(types.StdioSupervision{}); err != nil {
+		t.Errorf("unexpected error for empty settings: %v", err)
+	}
+	if err := h.SetStdioSupervision(types.StdioSupervision{RunAsUser: "nobody"}); err == nil {
+		t.Error("expected an error for stdio settings on a streamable http server")
+	}
+}
+
+func TestSetStdioSandbox(t *testing.T) {
+	s, err := NewStdioServer("filesystem", "", "npx", []string{"-y", "server-filesystem"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.IsSandboxed() {
+		t.Error("expected the server not to be sandboxed by default")
+	}
+
+	sandbox := &types.StdioSandbox{Enabled: true, WritablePaths: []string{"/srv/data/"}}
+	if err := s.SetStdioSupervision(types.StdioSupervision{Sandbox: sandbox}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.IsSandboxed() {
+		t.Error("expected the server to be sandboxed")
+	}
+	conf, err := s.GetStdioConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conf.Sandbox.WritablePaths) != 1 || conf.Sandbox.WritablePaths[0] != "/srv/data" {
+		t.Errorf("unexpected sandbox writable paths: %v", conf.Sandbox.WritablePaths)
+	}
+
+	// a disabled sandbox is dropped
+	if err := s.SetStdioSupervision(types.StdioSupervision{Sandbox: &types.StdioSandbox{AllowNetwork: true}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.IsSandboxed() {
+		t.Error("expected the server not to be sandboxed")
+	}
+
+	sandbox = &types.StdioSandbox{Enabled: true, WritablePaths: []string{"data"}}
+	if err := s.SetStdioSupervision(types.StdioSupervision{Sandbox: sandbox}); err == nil {
+		t.Error("expected an error for a relative writable path")
+	}
+	sandbox = &types.StdioSandbox{Enabled: true, WritablePaths: []string{"/tmp/data"}}
+	if err := s.SetStdioSupervision(types.StdioSupervision{Sandbox: sandbox}); err == nil {
+		t.Error("expected an error for a writable path inside /tmp")
+	}
+}
@@ -1,6 +1,9 @@
 package model
 
 import (
+	"encoding/json"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -24,7 +27,28 @@ type Tool struct {
 	// InputSchema is a JSON schema that describes the input parameters for the tool.
 	InputSchema datatypes.JSON `json:"input_schema" gorm:"type:jsonb"`
 
+	// Annotations contains the title and behavior hints of the tool (eg- readOnlyHint) as sent by its MCP server.
+	// It is empty if the server didn't annotate the tool.
+	Annotations datatypes.JSON `json:"annotations,omitempty" gorm:"type:jsonb"`
+
+	// OutputSchema is a JSON schema that describes the structured output of the tool.
+	// It is empty if the server didn't provide one.
+	OutputSchema datatypes.JSON `json:"output_schema,omitempty" gorm:"type:jsonb"`
+
 	// ServerID is the ID of the MCP server that provides this tool.
 	ServerID uint      `json:"-" gorm:"not null"`
 	Server   McpServer `json:"-" gorm:"foreignKey:ServerID;references:ID"`
 }
+
+// GetAnnotations unmarshals the tool's annotations.
+// It returns nil if the tool has no annotations.
+func (t *Tool) GetAnnotations() (*types.ToolAnnotations, error) {
+	if len(t.Annotations) == 0 {
+		return nil, nil
+	}
+	var annotations types.ToolAnnotations
+	if err := json.Unmarshal(t.Annotations, &annotations); err != nil {
+		return nil, err
+	}
+	return &annotations, nil
+}
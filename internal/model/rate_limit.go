@@ -0,0 +1,42 @@
+package model
+
+import (
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+)
+
+// RateLimit represents the rate limit and call quotas configured for an MCP client,
+// a tool or an upstream MCP server.
+// There can be at most one rate limit per (scope, target) pair.
+type RateLimit struct {
+	gorm.Model
+
+	Scope types.RateLimitScope `json:"scope" gorm:"type:varchar(20);not null;uniqueIndex:idx_rate_limit_scope_target"`
+
+	// Target is the name of the MCP client, the canonical name of the tool or the name of the MCP server,
+	// depending on the scope.
+	Target string `json:"target" gorm:"not null;uniqueIndex:idx_rate_limit_scope_target"`
+
+	// RequestsPerMinute is the rate at which the token bucket for this limit is refilled.
+	// 0 means that the rate is not limited.
+	RequestsPerMinute int `json:"requests_per_minute" gorm:"not null;default:0"`
+
+	// Burst is the capacity of the token bucket.
+	Burst int `json:"burst" gorm:"not null;default:0"`
+
+	// DailyQuota is the maximum number of calls allowed per UTC day (client scope only).
+	DailyQuota int64 `json:"daily_quota" gorm:"not null;default:0"`
+
+	// MonthlyQuota is the maximum number of calls allowed per UTC calendar month (client scope only).
+	MonthlyQuota int64 `json:"monthly_quota" gorm:"not null;default:0"`
+}
+
+// ClientQuotaUsage tracks the number of tool calls made by an MCP client in a quota period.
+// Period is either a day ("2006-01-02") or a month ("2006-01").
+type ClientQuotaUsage struct {
+	gorm.Model
+
+	ClientName string `json:"client_name" gorm:"not null;uniqueIndex:idx_client_quota_period"`
+	Period     string `json:"period" gorm:"type:varchar(10);not null;uniqueIndex:idx_client_quota_period"`
+	Calls      int64  `json:"calls" gorm:"not null;default:0"`
+}
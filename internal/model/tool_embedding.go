@@ -0,0 +1,22 @@
+package model
+
+import "gorm.io/gorm"
+
+// ToolEmbedding is the vector embedding of a tool's name, description and input schema,
+// used for semantic tool search.
+// There is at most one embedding per tool. It is recomputed when the tool changes or a different embedder is used.
+type ToolEmbedding struct {
+	gorm.Model
+
+	ToolID uint `json:"tool_id" gorm:"not null;uniqueIndex"`
+
+	// Embedder is the name of the embedder that computed the vector, eg- "openai:text-embedding-3-small".
+	Embedder string `json:"embedder" gorm:"not null"`
+
+	// ContentHash is the SHA-256 hash of the text the vector was computed from.
+	// It is used to detect tools whose embedding is outdated.
+	ContentHash string `json:"content_hash" gorm:"type:varchar(64);not null"`
+
+	// Vector holds the embedding as little-endian float32 values.
+	Vector []byte `json:"-" gorm:"not null"`
+}
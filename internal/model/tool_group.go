@@ -3,7 +3,9 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -12,6 +14,8 @@ import (
 type ToolResolver interface {
 	// ListToolsByServer returns a list of tools for the given MCP server name.
 	ListToolsByServer(serverName string) ([]Tool, error)
+	// ListTools returns all tools in the registry, with their canonical names.
+	ListTools() ([]Tool, error)
 }
 
 // PromptResolver defines the interface needed to resolve prompts by server.
@@ -41,15 +45,36 @@ type ToolGroup struct {
 	// IncludedServers contains a list of MCP server names. All tools from these servers will be included.
 	IncludedServers datatypes.JSON `json:"included_servers" gorm:"type:jsonb"`
 
+	// IncludedServersFilter narrows down the tools included from IncludedServers to the ones whose
+	// annotations match it, eg- only read-only tools.
+	// Tools listed in IncludedTools are always included.
+	IncludedServersFilter datatypes.JSON `json:"included_servers_filter" gorm:"type:jsonb"`
+
 	// ExcludedTools contains a list of tool names to exclude from the group.
 	ExcludedTools datatypes.JSON `json:"excluded_tools" gorm:"type:jsonb"`
 
+	// ToolRules contains a list of rules that include or exclude tools based on name patterns and annotations.
+	// Since rules are evaluated against all enabled tools, they also apply to tools registered or enabled later.
+	ToolRules datatypes.JSON `json:"tool_rules" gorm:"type:jsonb"`
+
+	// ToolAliases contains a list of aliases that expose tools in this group under different names.
+	// An aliased tool does not have to be part of the group's effective tools, it is then only exposed under its alias.
+	ToolAliases datatypes.JSON `json:"tool_aliases" gorm:"type:jsonb"`
+
+	// ToolArguments contains the argument presets of the group's tools as a JSON object,
+	// keyed by the name that a tool is exposed under in this group.
+	ToolArguments datatypes.JSON `json:"tool_arguments" gorm:"type:jsonb"`
+
 	// IncludedPrompts contains a list of prompt names that are included in this group.
 	// storing the list of prompt names as a JSON array is a convenient way for now.
 	IncludedPrompts datatypes.JSON `json:"included_prompts" gorm:"type:jsonb"`
 
 	// ExcludedPrompts contains a list of prompt names to exclude from the group.
 	ExcludedPrompts datatypes.JSON `json:"excluded_prompts" gorm:"type:jsonb"`
+
+	// LazyToolLoading makes sessions of the group's MCP endpoints initially list only mcpjungle's meta-tools.
+	// The group's tools can then be searched, described, called and activated through the meta-tools.
+	LazyToolLoading bool `json:"lazy_tool_loading" gorm:"not null;default:false"`
 }
 
 // GetTools unmarshals the IncludedTools JSON array into a slice of strings.
@@ -72,6 +97,19 @@ func (g *ToolGroup) GetServers() ([]string, error) {
 	return servers, err
 }
 
+// GetIncludedServersFilter unmarshals the IncludedServersFilter JSON object.
+// It returns nil if the group has no filter.
+func (g *ToolGroup) GetIncludedServersFilter() (*types.ToolAnnotationFilter, error) {
+	if len(g.IncludedServersFilter) == 0 || string(g.IncludedServersFilter) == "null" {
+		return nil, nil
+	}
+	var filter types.ToolAnnotationFilter
+	if err := json.Unmarshal(g.IncludedServersFilter, &filter); err != nil {
+		return nil, err
+	}
+	return &filter, nil
+}
+
 // GetExcludedTools unmarshals the ExcludedTools JSON array into a slice of strings.
 func (g *ToolGroup) GetExcludedTools() ([]string, error) {
 	if g.ExcludedTools == nil {
@@ -82,6 +120,63 @@ func (g *ToolGroup) GetExcludedTools() ([]string, error) {
 	return tools, err
 }
 
+// GetToolRules unmarshals the ToolRules JSON array and validates the rules.
+func (g *ToolGroup) GetToolRules() ([]types.ToolGroupRule, error) {
+	if len(g.ToolRules) == 0 || string(g.ToolRules) == "null" {
+		return []types.ToolGroupRule{}, nil
+	}
+	var rules []types.ToolGroupRule
+	if err := json.Unmarshal(g.ToolRules, &rules); err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid tool rule #%d: %w", i+1, err)
+		}
+	}
+	return rules, nil
+}
+
+// GetToolAliases unmarshals the ToolAliases JSON array and validates the aliases.
+// It returns an error if two aliases have the same name.
+func (g *ToolGroup) GetToolAliases() ([]types.ToolAlias, error) {
+	if len(g.ToolAliases) == 0 || string(g.ToolAliases) == "null" {
+		return []types.ToolAlias{}, nil
+	}
+	var aliases []types.ToolAlias
+	if err := json.Unmarshal(g.ToolAliases, &aliases); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool, len(aliases))
+	for i := range aliases {
+		if err := aliases[i].Validate(); err != nil {
+			return nil, err
+		}
+		if names[aliases[i].Name] {
+			return nil, fmt.Errorf("duplicate tool alias %s", aliases[i].Name)
+		}
+		names[aliases[i].Name] = true
+	}
+	return aliases, nil
+}
+
+// GetToolArguments unmarshals the ToolArguments JSON object and validates the presets.
+func (g *ToolGroup) GetToolArguments() (map[string]types.ToolArguments, error) {
+	if len(g.ToolArguments) == 0 || string(g.ToolArguments) == "null" {
+		return map[string]types.ToolArguments{}, nil
+	}
+	var presets map[string]types.ToolArguments
+	if err := json.Unmarshal(g.ToolArguments, &presets); err != nil {
+		return nil, err
+	}
+	for name, args := range presets {
+		if err := args.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+		}
+	}
+	return presets, nil
+}
+
 // GetPrompts unmarshals the IncludedPrompts JSON array into a slice of strings.
 func (g *ToolGroup) GetPrompts() ([]string, error) {
 	if g.IncludedPrompts == nil {
@@ -103,7 +198,8 @@ func (g *ToolGroup) GetExcludedPrompts() ([]string, error) {
 }
 
 // ResolveEffectiveTools resolves all effective tools for this group by combining
-// included_tools, included_servers, and applying excluded_tools.
+// included_tools, included_servers (narrowed down by included_servers_filter) and the tools matching
+// the inclusion rules, and applying excluded_tools and the exclusion rules.
 // Note that tool exclusions are applied at last, so if a tool is both included and excluded,
 // it will be excluded.
 // It requires an MCP service to lookup tools by server.
@@ -124,13 +220,51 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to get included servers: %w", err)
 	}
+	filter, err := g.GetIncludedServersFilter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get included servers filter: %w", err)
+	}
 	for _, serverName := range includedServers {
 		serverTools, err := mcpService.ListToolsByServer(serverName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get tools for server %s: %w", serverName, err)
 		}
 		for _, tool := range serverTools {
-			effectiveTools[tool.Name] = true
+			annotations, err := tool.GetAnnotations()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get annotations of tool %s: %w", tool.Name, err)
+			}
+			if filter.Matches(annotations) {
+				effectiveTools[tool.Name] = true
+			}
+		}
+	}
+
+	// Add tools matching the inclusion rules
+	rules, err := g.GetToolRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool rules: %w", err)
+	}
+	var annotations map[string]*types.ToolAnnotations
+	if len(rules) > 0 {
+		// rules are evaluated against all enabled tools in the registry
+		annotations, err = annotationsOfEnabledTools(mcpService)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i := range rules {
+		if rules[i].Exclude {
+			continue
+		}
+		matches, err := rules[i].NameMatcher()
+		if err != nil {
+			return nil, err
+		}
+		for name, a := range annotations {
+			if matches(name) && rules[i].Annotations.Matches(a) {
+				effectiveTools[name] = true
+			}
 		}
 	}
 
@@ -143,6 +277,23 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 		delete(effectiveTools, tool)
 	}
 
+	// Remove tools matching the exclusion rules
+	for i := range rules {
+		if !rules[i].Exclude {
+			continue
+		}
+		matches, err := rules[i].NameMatcher()
+		if err != nil {
+			return nil, err
+		}
+		for name := range effectiveTools {
+			// a tool that is not in the registry has no annotations
+			if matches(name) && rules[i].Annotations.Matches(annotations[name]) {
+				delete(effectiveTools, name)
+			}
+		}
+	}
+
 	// Convert map to slice
 	result := make([]string, 0, len(effectiveTools))
 	for tool := range effectiveTools {
@@ -152,6 +303,146 @@ func (g *ToolGroup) ResolveEffectiveTools(mcpService ToolResolver) ([]string, er
 	return result, nil
 }
 
+// IncludesTool returns true if the given tool of the given MCP server is one of this group's effective tools,
+// see ResolveEffectiveTools. The tool must have its canonical name.
+// Unlike ResolveEffectiveTools, it doesn't list any tools, so it is cheap to call whenever a tool is added.
+func (g *ToolGroup) IncludesTool(serverName string, tool *Tool) (bool, error) {
+	annotations, err := tool.GetAnnotations()
+	if err != nil {
+		return false, fmt.Errorf("failed to get annotations of tool %s: %w", tool.Name, err)
+	}
+	// like ResolveEffectiveTools, rules are only evaluated against the annotations of enabled tools
+	var ruleAnnotations *types.ToolAnnotations
+	if tool.Enabled {
+		ruleAnnotations = annotations
+	}
+
+	includedTools, err := g.GetTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get included tools: %w", err)
+	}
+	included := slices.Contains(includedTools, tool.Name)
+
+	if !included {
+		includedServers, err := g.GetServers()
+		if err != nil {
+			return false, fmt.Errorf("failed to get included servers: %w", err)
+		}
+		if slices.Contains(includedServers, serverName) {
+			filter, err := g.GetIncludedServersFilter()
+			if err != nil {
+				return false, fmt.Errorf("failed to get included servers filter: %w", err)
+			}
+			included = filter.Matches(annotations)
+		}
+	}
+
+	rules, err := g.GetToolRules()
+	if err != nil {
+		return false, fmt.Errorf("failed to get tool rules: %w", err)
+	}
+	for i := range rules {
+		if included || !tool.Enabled {
+			break
+		}
+		if rules[i].Exclude {
+			continue
+		}
+		matches, err := rules[i].NameMatcher()
+		if err != nil {
+			return false, err
+		}
+		included = matches(tool.Name) && rules[i].Annotations.Matches(ruleAnnotations)
+	}
+	if !included {
+		return false, nil
+	}
+
+	excludedTools, err := g.GetExcludedTools()
+	if err != nil {
+		return false, fmt.Errorf("failed to get excluded tools: %w", err)
+	}
+	if slices.Contains(excludedTools, tool.Name) {
+		return false, nil
+	}
+	for i := range rules {
+		if !rules[i].Exclude {
+			continue
+		}
+		matches, err := rules[i].NameMatcher()
+		if err != nil {
+			return false, err
+		}
+		if matches(tool.Name) && rules[i].Annotations.Matches(ruleAnnotations) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ResolveAccessibleTools resolves the canonical names of the tools that this group grants MCP clients access to
+// under their canonical names: its effective tools, except those whose arguments it presets.
+// Tools exposed under an alias or with argument presets can only be called through the group, see ResolveScopedTools.
+func (g *ToolGroup) ResolveAccessibleTools(mcpService ToolResolver) ([]string, error) {
+	tools, err := g.ResolveEffectiveTools(mcpService)
+	if err != nil {
+		return nil, err
+	}
+	presets, err := g.GetToolArguments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool arguments: %w", err)
+	}
+	return slices.DeleteFunc(tools, func(name string) bool {
+		_, ok := presets[name]
+		return ok
+	}), nil
+}
+
+// ResolveScopedTools returns the names of the tools that MCP clients can only call through this group's
+// MCP proxy servers, under the name the group exposes them as: the names of its tool aliases and of the tools
+// whose arguments it presets, so that the presets can't be bypassed by calling the tool elsewhere.
+func (g *ToolGroup) ResolveScopedTools() ([]string, error) {
+	aliases, err := g.GetToolAliases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool aliases: %w", err)
+	}
+	presets, err := g.GetToolArguments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool arguments: %w", err)
+	}
+	names := make([]string, 0, len(aliases)+len(presets))
+	for _, a := range aliases {
+		names = append(names, a.Name)
+	}
+	for name := range presets {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// annotationsOfEnabledTools returns the annotations of all enabled tools in the registry, keyed by canonical tool name.
+// Tools without annotations map to nil.
+func annotationsOfEnabledTools(resolver ToolResolver) (map[string]*types.ToolAnnotations, error) {
+	tools, err := resolver.ListTools()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tools: %w", err)
+	}
+	annotations := make(map[string]*types.ToolAnnotations, len(tools))
+	for i := range tools {
+		if !tools[i].Enabled {
+			continue
+		}
+		a, err := tools[i].GetAnnotations()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get annotations of tool %s: %w", tools[i].Name, err)
+		}
+		annotations[tools[i].Name] = a
+	}
+	return annotations, nil
+}
+
 // ResolveEffectivePrompts resolves all effective prompts for this group by combining
 // included_prompts, included_servers (for prompts), and applying excluded_prompts.
 // Note that prompt exclusions are applied at last, so if a prompt is both included and excluded,
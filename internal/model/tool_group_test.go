@@ -2,6 +2,7 @@ package model
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 
 	"gorm.io/datatypes"
@@ -19,6 +20,14 @@ func (m *mockToolResolver) ListToolsByServer(serverName string) ([]Tool, error)
 	return []Tool{}, nil
 }
 
+func (m *mockToolResolver) ListTools() ([]Tool, error) {
+	var tools []Tool
+	for _, serverTools := range m.serverTools {
+		tools = append(tools, serverTools...)
+	}
+	return tools, nil
+}
+
 func TestToolGroup_GetTools(t *testing.T) {
 	tools := []string{"tool1", "tool2"}
 	toolsJSON, _ := json.Marshal(tools)
@@ -285,3 +294,174 @@ func TestToolGroup_ResolveEffectiveTools_EmptyGroup(t *testing.T) {
 		t.Errorf("Expected 0 tools for empty group, got %d", len(result))
 	}
 }
+
+func TestToolGroup_ResolveEffectiveTools_IncludedServersFilter(t *testing.T) {
+	resolver := &mockToolResolver{
+		serverTools: map[string][]Tool{
+			"github": {
+				{Name: "github__list_repos", Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+				{Name: "github__create_issue", Annotations: datatypes.JSON(`{"destructiveHint":false}`)},
+				{Name: "github__delete_repo"},
+			},
+		},
+	}
+
+	t.Run("only matching tools of included servers are included", func(t *testing.T) {
+		group := &ToolGroup{
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			IncludedServersFilter: datatypes.JSON(`{"read_only":true}`),
+		}
+		result, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		if len(result) != 1 || result[0] != "github__list_repos" {
+			t.Errorf("Expected only github__list_repos, got %v", result)
+		}
+	})
+
+	t.Run("explicitly included tools are not filtered", func(t *testing.T) {
+		group := &ToolGroup{
+			IncludedTools:         datatypes.JSON(`["github__delete_repo"]`),
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			IncludedServersFilter: datatypes.JSON(`{"destructive":false}`),
+		}
+		result, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		if len(result) != 3 {
+			t.Errorf("Expected 3 tools, got %v", result)
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		group := &ToolGroup{
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			IncludedServersFilter: datatypes.JSON(`["read_only"]`),
+		}
+		if _, err := group.ResolveEffectiveTools(resolver); err == nil {
+			t.Error("Expected an error for an invalid filter")
+		}
+	})
+}
+
+func TestToolGroup_ResolveEffectiveTools_ToolRules(t *testing.T) {
+	resolver := &mockToolResolver{
+		serverTools: map[string][]Tool{
+			"github": {
+				{Name: "github__list_issues", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+				{Name: "github__create_issue", Enabled: true, Annotations: datatypes.JSON(`{"destructiveHint":false}`)},
+				{Name: "github__delete_issue", Enabled: true},
+				{Name: "github__close_issue", Enabled: false},
+			},
+			"time": {
+				{Name: "time__now", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+			},
+		},
+	}
+
+	resolve := func(t *testing.T, group *ToolGroup) map[string]bool {
+		t.Helper()
+		result, err := group.ResolveEffectiveTools(resolver)
+		if err != nil {
+			t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+		}
+		tools := make(map[string]bool)
+		for _, tool := range result {
+			tools[tool] = true
+		}
+		return tools
+	}
+
+	t.Run("inclusion rules match enabled tools by pattern", func(t *testing.T) {
+		tools := resolve(t, &ToolGroup{ToolRules: datatypes.JSON(`[{"pattern":"github__*_issue*"}]`)})
+		if len(tools) != 3 || tools["github__close_issue"] {
+			t.Errorf("Expected the 3 enabled issue tools, got %v", tools)
+		}
+	})
+
+	t.Run("inclusion rules match by annotations", func(t *testing.T) {
+		tools := resolve(t, &ToolGroup{ToolRules: datatypes.JSON(`[{"annotations":{"read_only":true}}]`)})
+		if len(tools) != 2 || !tools["github__list_issues"] || !tools["time__now"] {
+			t.Errorf("Expected the read-only tools, got %v", tools)
+		}
+	})
+
+	t.Run("exclusion rules also remove explicitly included tools", func(t *testing.T) {
+		tools := resolve(t, &ToolGroup{
+			IncludedTools:   datatypes.JSON(`["github__delete_issue"]`),
+			IncludedServers: datatypes.JSON(`["time"]`),
+			ToolRules: datatypes.JSON(`[
+				{"pattern":"/^github__/"},
+				{"annotations":{"destructive":true},"exclude":true}
+			]`),
+		})
+		if len(tools) != 3 || tools["github__delete_issue"] {
+			t.Errorf("Expected the non-destructive tools, got %v", tools)
+		}
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		group := &ToolGroup{ToolRules: datatypes.JSON(`[{"pattern":"/(/"}]`)}
+		if _, err := group.ResolveEffectiveTools(resolver); err == nil {
+			t.Error("Expected an error for an invalid pattern")
+		}
+	})
+}
+
+func TestToolGroup_IncludesTool(t *testing.T) {
+	resolver := &mockToolResolver{
+		serverTools: map[string][]Tool{
+			"github": {
+				{Name: "github__list_issues", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+				{Name: "github__create_issue", Enabled: true, Annotations: datatypes.JSON(`{"destructiveHint":false}`)},
+				{Name: "github__delete_issue", Enabled: true},
+				{Name: "github__close_issue", Enabled: false},
+			},
+			"time": {
+				{Name: "time__now", Enabled: true, Annotations: datatypes.JSON(`{"readOnlyHint":true}`)},
+			},
+		},
+	}
+	groups := map[string]*ToolGroup{
+		"included tools": {
+			IncludedTools: datatypes.JSON(`["github__list_issues", "time__now"]`),
+			ExcludedTools: datatypes.JSON(`["time__now"]`),
+		},
+		"included servers filter": {
+			IncludedServers:       datatypes.JSON(`["github"]`),
+			IncludedServersFilter: datatypes.JSON(`{"read_only":true}`),
+		},
+		"inclusion rules": {ToolRules: datatypes.JSON(`[{"pattern":"github__*_issue*"}]`)},
+		"exclusion rules": {
+			IncludedTools:   datatypes.JSON(`["github__delete_issue"]`),
+			IncludedServers: datatypes.JSON(`["time"]`),
+			ToolRules: datatypes.JSON(`[
+				{"pattern":"/^github__/"},
+				{"annotations":{"destructive":true},"exclude":true}
+			]`),
+		},
+	}
+
+	// a group includes a tool exactly if the tool is one of the group's effective tools
+	for name, group := range groups {
+		t.Run(name, func(t *testing.T) {
+			effective, err := group.ResolveEffectiveTools(resolver)
+			if err != nil {
+				t.Fatalf("ResolveEffectiveTools() failed: %v", err)
+			}
+			for serverName, tools := range resolver.serverTools {
+				for i := range tools {
+					included, err := group.IncludesTool(serverName, &tools[i])
+					if err != nil {
+						t.Fatalf("IncludesTool() failed: %v", err)
+					}
+					if want := slices.Contains(effective, tools[i].Name); included != want {
+						t.Errorf("IncludesTool(%s) = %v, expected %v", tools[i].Name, included, want)
+					}
+				}
+			}
+		})
+	}
+}
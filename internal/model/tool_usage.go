@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ToolUsage counts the calls of a tool made by an MCP client with a given outcome in an hour.
+// There is at most one row per (hour, server, tool, client, outcome) bucket.
+type ToolUsage struct {
+	gorm.Model
+
+	// Hour is the start of the hour (UTC) the calls were made in.
+	Hour time.Time `json:"hour" gorm:"not null;uniqueIndex:idx_tool_usage_bucket"`
+
+	ServerName string `json:"server_name" gorm:"not null;uniqueIndex:idx_tool_usage_bucket"`
+
+	// ToolName is the canonical name of the tool, eg- "github__search_code".
+	ToolName string `json:"tool_name" gorm:"not null;uniqueIndex:idx_tool_usage_bucket"`
+
+	// ClientName is the name of the MCP client that made the calls,
+	// or "anonymous" for calls made without a client, eg- in development mode.
+	ClientName string `json:"client_name" gorm:"not null;uniqueIndex:idx_tool_usage_bucket"`
+
+	// Outcome is the outcome of the calls, eg- "success", "error", "timeout" or "rate_limited".
+	Outcome string `json:"outcome" gorm:"type:varchar(20);not null;uniqueIndex:idx_tool_usage_bucket"`
+
+	Calls int64 `json:"calls" gorm:"not null;default:0"`
+
+	// DurationMs is the total duration of the calls in milliseconds.
+	DurationMs int64 `json:"duration_ms" gorm:"not null;default:0"`
+}
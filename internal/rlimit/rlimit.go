@@ -0,0 +1,64 @@
+// Package rlimit applies resource limits (rlimits) to the processes of stdio MCP servers.
+//
+// Go can't set the resource limits of a child process between fork and exec, and limiting the process
+// once it runs leaves a window in which it isn't limited. Instead, mcpjungle re-executes itself with the
+// hidden HelperCommand, which sets the limits on its own process and then executes the server's program,
+// so the program is limited from its very first instruction.
+package rlimit
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// HelperCommand is the hidden mcpjungle sub-command that sets the resource limits and then executes the program.
+const HelperCommand = "rlimit-exec"
+
+// ErrUnsupported is returned when resource limits are not supported on the current platform.
+var ErrUnsupported = errors.New("resource limits for stdio servers are only supported on linux")
+
+// Args returns the command line flags that pass the given limits to a helper command.
+func Args(limits *types.StdioResourceLimits) []string {
+	if limits.IsZero() {
+		return nil
+	}
+	var args []string
+	add := func(name string, value uint64) {
+		if value > 0 {
+			args = append(args, "--"+name, strconv.FormatUint(value, 10))
+		}
+	}
+	add("cpu-seconds", limits.CPUSeconds)
+	add("memory-mb", limits.MemoryMB)
+	add("open-files", limits.OpenFiles)
+	return args
+}
+
+// AddFlags defines the flags created by Args on the given flag set.
+// It returns the limits that the flags are parsed into.
+func AddFlags(fs *flag.FlagSet) *types.StdioResourceLimits {
+	limits := &types.StdioResourceLimits{}
+	fs.Uint64Var(&limits.CPUSeconds, "cpu-seconds", 0, "")
+	fs.Uint64Var(&limits.MemoryMB, "memory-mb", 0, "")
+	fs.Uint64Var(&limits.OpenFiles, "open-files", 0, "")
+	return limits
+}
+
+// parseHelperArgs parses the arguments of the helper command into the limits and the command line
+// of the program to run.
+func parseHelperArgs(args []string) (*types.StdioResourceLimits, []string, error) {
+	fs := flag.NewFlagSet(HelperCommand, flag.ContinueOnError)
+	limits := AddFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	argv := fs.Args()
+	if len(argv) == 0 {
+		return nil, nil, fmt.Errorf("%s: no program given", HelperCommand)
+	}
+	return limits, argv, nil
+}
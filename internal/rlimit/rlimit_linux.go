@@ -0,0 +1,73 @@
+package rlimit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"golang.org/x/sys/unix"
+)
+
+// Wrap rewrites cmd so that its program runs with the given resource limits.
+// cmd is started as the helper command of the mcpjungle executable, which sets the limits and executes
+// the original program. cmd is left unchanged if no limit is set.
+func Wrap(cmd *exec.Cmd, limits *types.StdioResourceLimits) error {
+	if limits.IsZero() {
+		return nil
+	}
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the mcpjungle executable: %w", err)
+	}
+
+	args := append(append([]string{exe, HelperCommand}, Args(limits)...), "--", cmd.Path)
+	cmd.Args = append(args, cmd.Args[1:]...)
+	cmd.Path = exe
+	return nil
+}
+
+// Set sets the resource limits of the current process. The limits are inherited by the programs it executes.
+func Set(limits *types.StdioResourceLimits) error {
+	if limits.IsZero() {
+		return nil
+	}
+	set := func(resource int, name string, value uint64) error {
+		if value == 0 {
+			return nil
+		}
+		// syscall.Setrlimit is used so that syscall.Exec doesn't restore the open files limit that Go raised at startup
+		if err := syscall.Setrlimit(resource, &syscall.Rlimit{Cur: value, Max: value}); err != nil {
+			return fmt.Errorf("failed to set %s limit: %w", name, err)
+		}
+		return nil
+	}
+	if err := set(unix.RLIMIT_CPU, "cpu time", limits.CPUSeconds); err != nil {
+		return err
+	}
+	if err := set(unix.RLIMIT_AS, "memory", limits.MemoryMB*1024*1024); err != nil {
+		return err
+	}
+	return set(unix.RLIMIT_NOFILE, "open files", limits.OpenFiles)
+}
+
+// Exec runs the helper command with the given arguments.
+// It sets the resource limits and replaces the current process with the program.
+// It only returns if setting the limits or executing the program fails.
+func Exec(args []string) error {
+	limits, argv, err := parseHelperArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := Set(limits); err != nil {
+		return err
+	}
+	if err := unix.Exec(argv[0], argv, os.Environ()); err != nil {
+		return fmt.Errorf("failed to execute %s: %w", argv[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package rlimit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// TestMain lets the test binary act as the helper command, since Wrap re-executes the current executable.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == HelperCommand {
+		if err := Exec(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestWrapLimitsProgramFromTheStart(t *testing.T) {
+	// the program reads its own limits as its very first action
+	cmd := exec.Command("cat", "/proc/self/limits")
+	testhelpers.AssertNoError(t, Wrap(cmd, &types.StdioResourceLimits{CPUSeconds: 60, OpenFiles: 64}))
+
+	out, err := cmd.CombinedOutput()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, regexp.MustCompile(`Max cpu time\s+60\s+60`).Match(out), string(out))
+	testhelpers.AssertTrue(t, regexp.MustCompile(`Max open files\s+64\s+64`).Match(out), string(out))
+}
+
+func TestWrapWithoutLimits(t *testing.T) {
+	cmd := exec.Command("cat", "/proc/self/limits")
+	path := cmd.Path
+	testhelpers.AssertNoError(t, Wrap(cmd, nil))
+	testhelpers.AssertEqual(t, path, cmd.Path)
+}
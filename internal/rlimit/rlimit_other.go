@@ -0,0 +1,30 @@
+//go:build !linux
+
+package rlimit
+
+import (
+	"os/exec"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// Wrap fails if any resource limit is set, since resource limits are only supported on linux.
+func Wrap(cmd *exec.Cmd, limits *types.StdioResourceLimits) error {
+	if limits.IsZero() {
+		return nil
+	}
+	return ErrUnsupported
+}
+
+// Set fails if any resource limit is set, since resource limits are only supported on linux.
+func Set(limits *types.StdioResourceLimits) error {
+	if limits.IsZero() {
+		return nil
+	}
+	return ErrUnsupported
+}
+
+// Exec fails since resource limits are only supported on linux.
+func Exec(args []string) error {
+	return ErrUnsupported
+}
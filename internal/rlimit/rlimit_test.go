@@ -0,0 +1,24 @@
+package rlimit
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestHelperArgs(t *testing.T) {
+	testhelpers.AssertEqual(t, 0, len(Args(nil)))
+
+	args := append(Args(&types.StdioResourceLimits{CPUSeconds: 60, OpenFiles: 64}), "--", "/usr/bin/npx", "-y")
+	limits, argv, err := parseHelperArgs(args)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, uint64(60), limits.CPUSeconds)
+	testhelpers.AssertEqual(t, uint64(0), limits.MemoryMB)
+	testhelpers.AssertEqual(t, uint64(64), limits.OpenFiles)
+	testhelpers.AssertEqual(t, 2, len(argv))
+	testhelpers.AssertEqual(t, "-y", argv[1])
+
+	_, _, err = parseHelperArgs([]string{"--cpu-seconds", "1", "--"})
+	testhelpers.AssertError(t, err)
+}
@@ -0,0 +1,137 @@
+// Package sandbox runs the processes of stdio MCP servers in an isolated sandbox.
+//
+// A sandboxed process is started in new Linux namespaces (user, mount, PID, IPC, UTS and, unless network
+// access is allowed, network). Before the server's program is executed, mcpjungle re-executes itself with the
+// hidden HelperCommand inside these namespaces to make the root filesystem read-only, hide mcpjungle's own
+// files, mount a private /tmp and /proc and install a seccomp filter.
+package sandbox
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// HelperCommand is the hidden mcpjungle sub-command that sets up the sandbox from inside its namespaces
+// and then executes the sandboxed program.
+const HelperCommand = "sandbox-exec"
+
+// ErrUnsupported is returned when sandboxing is not supported on the current platform.
+var ErrUnsupported = errors.New("sandboxing stdio servers is only supported on Linux")
+
+var (
+	hiddenPathsMu sync.RWMutex
+	hiddenPaths   []string
+)
+
+// SetHiddenPaths sets the files and directories that sandboxed processes can't read, eg- mcpjungle's database.
+// The sandboxed process runs as the same user as mcpjungle unless it is configured to run as a different user,
+// so it could otherwise read all of mcpjungle's files.
+// Relative paths are resolved against the current working directory.
+func SetHiddenPaths(paths ...string) error {
+	abs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		a, err := filepath.Abs(p)
+		if err != nil {
+			return fmt.Errorf("failed to resolve path %s: %w", p, err)
+		}
+		abs = append(abs, a)
+	}
+	hiddenPathsMu.Lock()
+	defer hiddenPathsMu.Unlock()
+	hiddenPaths = abs
+	return nil
+}
+
+// existingHiddenPaths returns the hidden paths that currently exist.
+func existingHiddenPaths() []string {
+	hiddenPathsMu.RLock()
+	defer hiddenPathsMu.RUnlock()
+	var existing []string
+	for _, p := range hiddenPaths {
+		if _, err := os.Stat(p); err == nil {
+			existing = append(existing, p)
+		}
+	}
+	return existing
+}
+
+// helperOptions are the options passed from mcpjungle to the helper command.
+type helperOptions struct {
+	allowNetwork  bool
+	writablePaths []string
+	// hiddenPaths are the files and directories hidden from the program
+	hiddenPaths []string
+
+	// uid and gid are the user and group the program is switched to, -1 to keep the current ones
+	uid int
+	gid int
+
+	// limits are the resource limits set right before the program is executed
+	limits *types.StdioResourceLimits
+}
+
+// args returns the command line arguments of the helper command.
+func (o *helperOptions) args() []string {
+	args := []string{HelperCommand}
+	if o.allowNetwork {
+		args = append(args, "--allow-network")
+	}
+	for _, p := range o.writablePaths {
+		args = append(args, "--writable", p)
+	}
+	for _, p := range o.hiddenPaths {
+		args = append(args, "--hidden", p)
+	}
+	if o.uid >= 0 {
+		args = append(args, "--uid", strconv.Itoa(o.uid))
+	}
+	if o.gid >= 0 {
+		args = append(args, "--gid", strconv.Itoa(o.gid))
+	}
+	args = append(args, rlimit.Args(o.limits)...)
+	return append(args, "--")
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *stringsFlag) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
+
+// parseHelperArgs parses the arguments of the helper command into its options and the command line
+// of the program to run in the sandbox.
+func parseHelperArgs(args []string) (*helperOptions, []string, error) {
+	opts := &helperOptions{}
+	var writable, hidden stringsFlag
+
+	fs := flag.NewFlagSet(HelperCommand, flag.ContinueOnError)
+	fs.BoolVar(&opts.allowNetwork, "allow-network", false, "")
+	fs.Var(&writable, "writable", "")
+	fs.Var(&hidden, "hidden", "")
+	fs.IntVar(&opts.uid, "uid", -1, "")
+	fs.IntVar(&opts.gid, "gid", -1, "")
+	opts.limits = rlimit.AddFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	opts.writablePaths = writable
+	opts.hiddenPaths = hidden
+
+	argv := fs.Args()
+	if len(argv) == 0 {
+		return nil, nil, fmt.Errorf("%s: no program given", HelperCommand)
+	}
+	return opts, argv, nil
+}
@@ -0,0 +1,342 @@
+package sandbox
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"golang.org/x/sys/unix"
+)
+
+// Wrap rewrites cmd so that its program is run in a sandbox.
+// cmd is started as the helper command of the mcpjungle executable in new namespaces,
+// the helper then sets up the sandbox and executes the original program.
+// If cmd is configured to run as a different user, the helper switches to that user once the sandbox is set up.
+// The given resource limits are set by the helper right before it executes the program.
+func Wrap(cmd *exec.Cmd, conf *types.StdioSandbox, limits *types.StdioResourceLimits) error {
+	if cmd.Err != nil {
+		return cmd.Err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the mcpjungle executable: %w", err)
+	}
+
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	attr := cmd.SysProcAttr
+
+	opts := &helperOptions{
+		allowNetwork:  conf.AllowNetwork,
+		writablePaths: conf.WritablePaths,
+		hiddenPaths:   existingHiddenPaths(),
+		uid:           -1,
+		gid:           -1,
+		limits:        limits,
+	}
+
+	// The helper runs as root of a new user namespace, so that it may set up the mounts.
+	// Even if mcpjungle runs as root, the sandboxed process has no privileges outside of its namespaces.
+	// The new PID namespace hides the host's processes, the helper mounts a /proc that only shows its own.
+	euid, egid := os.Geteuid(), os.Getegid()
+	attr.Cloneflags |= syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS | syscall.CLONE_NEWPID | syscall.CLONE_NEWIPC |
+		syscall.CLONE_NEWUTS
+	if !conf.AllowNetwork {
+		attr.Cloneflags |= syscall.CLONE_NEWNET
+	}
+	attr.UidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: euid, Size: 1}}
+	attr.GidMappings = []syscall.SysProcIDMap{{ContainerID: 0, HostID: egid, Size: 1}}
+	attr.GidMappingsEnableSetgroups = euid == 0
+
+	if cred := attr.Credential; cred != nil {
+		if euid != 0 {
+			return fmt.Errorf("mcpjungle must run as root to run a sandboxed process as a different user")
+		}
+		// the user and group must be mapped into the user namespace for the helper to switch to them
+		if int(cred.Uid) != euid {
+			attr.UidMappings = append(attr.UidMappings, syscall.SysProcIDMap{
+				ContainerID: int(cred.Uid), HostID: int(cred.Uid), Size: 1,
+			})
+		}
+		if int(cred.Gid) != egid {
+			attr.GidMappings = append(attr.GidMappings, syscall.SysProcIDMap{
+				ContainerID: int(cred.Gid), HostID: int(cred.Gid), Size: 1,
+			})
+		}
+		opts.uid, opts.gid = int(cred.Uid), int(cred.Gid)
+		attr.Credential = nil
+	}
+
+	cmd.Args = append(append([]string{exe}, opts.args()...), append([]string{cmd.Path}, cmd.Args[1:]...)...)
+	cmd.Path = exe
+	return nil
+}
+
+// Exec runs the helper command with the given arguments.
+// It sets up the sandbox and replaces the current process with the sandboxed program.
+// It only returns if setting up the sandbox or executing the program fails.
+func Exec(args []string) error {
+	opts, argv, err := parseHelperArgs(args)
+	if err != nil {
+		return err
+	}
+	// setting up the mounts outside of the sandbox's namespaces would make the host's filesystems read-only
+	if ok, err := inSandboxUserNamespace(); err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("%s must only be run by mcpjungle to start a sandboxed stdio server", HelperCommand)
+	}
+
+	// no_new_privs and the seccomp filter only apply to the calling thread,
+	// so the program must be executed from the same thread that installs them.
+	runtime.LockOSThread()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	if err := setupMounts(opts.writablePaths, opts.hiddenPaths); err != nil {
+		return err
+	}
+	// the working directory may have been re-mounted
+	if err := os.Chdir(cwd); err != nil {
+		return fmt.Errorf("failed to change to working directory %s: %w", cwd, err)
+	}
+
+	if !opts.allowNetwork {
+		if err := setLoopbackUp(); err != nil {
+			return fmt.Errorf("failed to bring up the loopback interface: %w", err)
+		}
+	}
+
+	if opts.gid >= 0 || opts.uid >= 0 {
+		if err := switchUser(opts.uid, opts.gid); err != nil {
+			return err
+		}
+	}
+
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set no_new_privs: %w", err)
+	}
+	if err := installSeccompFilter(); err != nil {
+		return fmt.Errorf("failed to install seccomp filter: %w", err)
+	}
+	// the limits are set last, so that setting up the sandbox isn't subject to them
+	if err := rlimit.Set(opts.limits); err != nil {
+		return err
+	}
+
+	if err := unix.Exec(argv[0], argv, os.Environ()); err != nil {
+		return fmt.Errorf("failed to execute %s: %w", argv[0], err)
+	}
+	return nil
+}
+
+// inSandboxUserNamespace returns true if the process runs in a user namespace created by Wrap,
+// which only maps single user IDs, unlike the initial user namespace.
+func inSandboxUserNamespace() (bool, error) {
+	b, err := os.ReadFile("/proc/self/uid_map")
+	if err != nil {
+		return false, fmt.Errorf("failed to read uid map: %w", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	for _, line := range lines {
+		// each line is "<id inside the namespace> <id outside> <number of ids>"
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[2] != "1" {
+			return false, nil
+		}
+	}
+	return len(lines) > 0, nil
+}
+
+// setupMounts makes the root filesystem read-only, except for the writable paths, hides the hidden paths
+// and mounts a private /tmp and a /proc of the sandbox's PID namespace.
+func setupMounts(writablePaths, hiddenPaths []string) error {
+	// don't propagate any changes to the mounts of the host
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+	if err := unix.Mount("tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID|unix.MS_NODEV, "mode=1777"); err != nil {
+		return fmt.Errorf("failed to mount private /tmp: %w", err)
+	}
+	if err := unix.Mount("proc", "/proc", "proc", unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("failed to mount /proc: %w", err)
+	}
+	// bind mount the writable paths onto themselves, so that they are separate mounts that stay writable
+	for _, p := range writablePaths {
+		if err := unix.Mount(p, p, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return fmt.Errorf("failed to bind mount writable path %s: %w", p, err)
+		}
+	}
+	// hidden paths are hidden last, so that they are hidden inside writable paths too
+	for _, p := range hiddenPaths {
+		if err := hidePath(p); err != nil {
+			return fmt.Errorf("failed to hide %s: %w", p, err)
+		}
+	}
+
+	mountPoints, err := readMountPoints()
+	if err != nil {
+		return err
+	}
+	for _, mp := range mountPoints {
+		if !shouldRemountReadOnly(mp, writablePaths) {
+			continue
+		}
+		if err := remountReadOnly(mp); err != nil {
+			return fmt.Errorf("failed to make %s read-only: %w", mp, err)
+		}
+	}
+	return nil
+}
+
+// hidePath mounts an empty, read-only directory over a directory, or /dev/null over a file,
+// so that the sandboxed process can't read its contents.
+// Paths that don't exist are skipped.
+func hidePath(p string) error {
+	fi, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return unix.Mount("tmpfs", p, "tmpfs", unix.MS_RDONLY|unix.MS_NOSUID|unix.MS_NODEV|unix.MS_NOEXEC, "mode=000")
+	}
+	return unix.Mount("/dev/null", p, "", unix.MS_BIND, "")
+}
+
+// shouldRemountReadOnly returns true if the given mount point must be made read-only.
+// Pseudo filesystems like /proc and /dev are left alone.
+func shouldRemountReadOnly(mountPoint string, writablePaths []string) bool {
+	for _, p := range append([]string{"/tmp", "/proc", "/dev"}, writablePaths...) {
+		if mountPoint == p || strings.HasPrefix(mountPoint, strings.TrimSuffix(p, "/")+"/") {
+			return false
+		}
+	}
+	return true
+}
+
+// remountReadOnly makes a mount point read-only.
+// The existing flags of the mount are kept, since the kernel doesn't allow clearing them in a user namespace.
+func remountReadOnly(mountPoint string) error {
+	var st unix.Statfs_t
+	if err := unix.Statfs(mountPoint, &st); err != nil {
+		return err
+	}
+	flags := uintptr(unix.MS_BIND | unix.MS_REMOUNT | unix.MS_RDONLY)
+	for stFlag, msFlag := range map[int64]uintptr{
+		unix.ST_NOSUID:     unix.MS_NOSUID,
+		unix.ST_NODEV:      unix.MS_NODEV,
+		unix.ST_NOEXEC:     unix.MS_NOEXEC,
+		unix.ST_NOATIME:    unix.MS_NOATIME,
+		unix.ST_NODIRATIME: unix.MS_NODIRATIME,
+		unix.ST_RELATIME:   unix.MS_RELATIME,
+	} {
+		if int64(st.Flags)&stFlag != 0 {
+			flags |= msFlag
+		}
+	}
+	return unix.Mount("", mountPoint, "", flags, "")
+}
+
+// readMountPoints returns the mount points of the current mount namespace.
+func readMountPoints() ([]string, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	defer f.Close()
+
+	var mountPoints []string
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// the 5th field is the mount point, see proc(5)
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mp := filepath.Clean(unescapeMountPoint(fields[4]))
+		if !seen[mp] {
+			seen[mp] = true
+			mountPoints = append(mountPoints, mp)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	return mountPoints, nil
+}
+
+// unescapeMountPoint decodes the octal escapes (eg- "\040" for a space) used in /proc/self/mountinfo.
+func unescapeMountPoint(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// setLoopbackUp brings up the loopback interface of a new network namespace,
+// so that the sandboxed process can still talk to itself.
+func setLoopbackUp() error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifr, err := unix.NewIfreq("lo")
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlIfreq(fd, unix.SIOCGIFFLAGS, ifr); err != nil {
+		return err
+	}
+	ifr.SetUint16(ifr.Uint16() | unix.IFF_UP)
+	return unix.IoctlIfreq(fd, unix.SIOCSIFFLAGS, ifr)
+}
+
+// switchUser switches the process to the given user and group and drops all supplementary groups.
+func switchUser(uid, gid int) error {
+	if err := unix.Setgroups(nil); err != nil {
+		return fmt.Errorf("failed to drop supplementary groups: %w", err)
+	}
+	if gid >= 0 {
+		if err := unix.Setresgid(gid, gid, gid); err != nil {
+			return fmt.Errorf("failed to switch to group %d: %w", gid, err)
+		}
+	}
+	if uid >= 0 {
+		if err := unix.Setresuid(uid, uid, uid); err != nil {
+			return fmt.Errorf("failed to switch to user %d: %w", uid, err)
+		}
+	}
+	// the parent death signal is reset when the credentials change
+	if err := unix.Prctl(unix.PR_SET_PDEATHSIG, uintptr(unix.SIGKILL), 0, 0, 0); err != nil {
+		return fmt.Errorf("failed to set parent death signal: %w", err)
+	}
+	return nil
+}
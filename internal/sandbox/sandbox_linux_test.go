@@ -0,0 +1,125 @@
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// TestMain lets the test binary act as the helper command, since Wrap re-executes the current executable.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == HelperCommand {
+		if err := Exec(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+// runSandboxed runs a shell script in a sandbox and returns its combined output.
+// It skips the test if the kernel doesn't allow creating the sandbox's namespaces.
+func runSandboxed(t *testing.T, conf *types.StdioSandbox, script string) (string, error) {
+	t.Helper()
+	cmd := exec.Command("sh", "-c", script)
+	testhelpers.AssertNoError(t, Wrap(cmd, conf, nil))
+
+	out, err := cmd.CombinedOutput()
+	if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EINVAL) || errors.Is(err, syscall.ENOSPC) {
+		t.Skipf("user namespaces are not available: %v", err)
+	}
+	return string(out), err
+}
+
+func TestSandboxFilesystem(t *testing.T) {
+	// the writable directory must not be inside /tmp, which is private to the sandbox
+	wd, err := os.Getwd()
+	testhelpers.AssertNoError(t, err)
+	writable, err := os.MkdirTemp(wd, "writable-")
+	testhelpers.AssertNoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(writable) })
+
+	hostTmpFile, err := os.CreateTemp("", "host-")
+	testhelpers.AssertNoError(t, err)
+	_ = hostTmpFile.Close()
+	t.Cleanup(func() { _ = os.Remove(hostTmpFile.Name()) })
+
+	script := fmt.Sprintf(`
+if touch %[1]s/not-allowed 2>/dev/null; then echo "root is writable"; fi
+touch %[2]s/allowed || echo "writable path is read-only"
+touch /tmp/private || echo "tmp is read-only"
+if [ -e %[3]s ]; then echo "host tmp is visible"; fi
+`, wd, writable, hostTmpFile.Name())
+
+	out, err := runSandboxed(t, &types.StdioSandbox{Enabled: true, WritablePaths: []string{writable}}, script)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "", strings.TrimSpace(out))
+
+	_, err = os.Stat(filepath.Join(writable, "allowed"))
+	testhelpers.AssertNoError(t, err)
+	_, err = os.Stat(filepath.Join(wd, "not-allowed"))
+	testhelpers.AssertTrue(t, os.IsNotExist(err), "expected no file to be created outside of the writable paths")
+	_, err = os.Stat("/tmp/private")
+	testhelpers.AssertTrue(t, os.IsNotExist(err), "expected the sandbox's /tmp to be private")
+}
+
+func TestSandboxHiddenPaths(t *testing.T) {
+	// the hidden paths must not be inside /tmp, which is private to the sandbox
+	wd, err := os.Getwd()
+	testhelpers.AssertNoError(t, err)
+	dir, err := os.MkdirTemp(wd, "hidden-")
+	testhelpers.AssertNoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	secretFile := filepath.Join(dir, "mcpjungle.db")
+	secretDir := filepath.Join(dir, "data")
+	testhelpers.AssertNoError(t, os.WriteFile(secretFile, []byte("secret"), 0o600))
+	testhelpers.AssertNoError(t, os.Mkdir(secretDir, 0o700))
+	testhelpers.AssertNoError(t, os.WriteFile(filepath.Join(secretDir, "token"), []byte("secret"), 0o600))
+
+	testhelpers.AssertNoError(t, SetHiddenPaths(secretFile, secretDir, filepath.Join(dir, "missing")))
+	t.Cleanup(func() { _ = SetHiddenPaths() })
+
+	script := fmt.Sprintf(`
+cat %[1]s
+cat %[2]s/token 2>/dev/null
+echo "visible: $(cat %[3]s)"
+`, secretFile, secretDir, filepath.Join(dir, "visible"))
+	testhelpers.AssertNoError(t, os.WriteFile(filepath.Join(dir, "visible"), []byte("yes"), 0o600))
+
+	out, err := runSandboxed(t, &types.StdioSandbox{Enabled: true, WritablePaths: []string{dir}}, script)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "visible: yes", strings.TrimSpace(out))
+}
+
+func TestSandboxProcesses(t *testing.T) {
+	// the sandboxed process is the init process of its own PID namespace and can't see the host's processes
+	script := fmt.Sprintf(`echo $$; if [ -e /proc/%d ]; then echo "host process is visible"; fi`, os.Getpid())
+	out, err := runSandboxed(t, &types.StdioSandbox{Enabled: true}, script)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "1", strings.TrimSpace(out))
+}
+
+func TestSandboxNetwork(t *testing.T) {
+	// /proc/net/dev lists the interfaces of the process's network namespace
+	out, err := runSandboxed(t, &types.StdioSandbox{Enabled: true}, "tail -n +3 /proc/net/dev | cut -d: -f1")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "lo", strings.TrimSpace(out))
+}
+
+func TestSandboxSeccomp(t *testing.T) {
+	if _, err := exec.LookPath("mount"); err != nil {
+		t.Skip("mount is not installed")
+	}
+	out, err := runSandboxed(t, &types.StdioSandbox{Enabled: true}, "mount -t tmpfs tmpfs /mnt")
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, strings.ToLower(out), "permission denied")
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package sandbox
+
+import (
+	"os/exec"
+
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// Wrap fails since sandboxing is only supported on Linux.
+func Wrap(cmd *exec.Cmd, conf *types.StdioSandbox, limits *types.StdioResourceLimits) error {
+	return ErrUnsupported
+}
+
+// Exec fails since sandboxing is only supported on Linux.
+func Exec(args []string) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,38 @@
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestHelperArgs(t *testing.T) {
+	opts := &helperOptions{
+		allowNetwork:  true,
+		writablePaths: []string{"/srv/a", "/srv/b"},
+		hiddenPaths:   []string{"/srv/mcpjungle.db"},
+		uid:           1000,
+		gid:           -1,
+		limits:        &types.StdioResourceLimits{MemoryMB: 512},
+	}
+	args := append(opts.args(), "/usr/bin/npx", "-y", "server")
+	testhelpers.AssertEqual(t, HelperCommand, args[0])
+
+	parsed, argv, err := parseHelperArgs(args[1:])
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, parsed.allowNetwork, "expected network access to be allowed")
+	testhelpers.AssertEqual(t, 2, len(parsed.writablePaths))
+	testhelpers.AssertEqual(t, "/srv/b", parsed.writablePaths[1])
+	testhelpers.AssertEqual(t, 1, len(parsed.hiddenPaths))
+	testhelpers.AssertEqual(t, "/srv/mcpjungle.db", parsed.hiddenPaths[0])
+	testhelpers.AssertEqual(t, 1000, parsed.uid)
+	testhelpers.AssertEqual(t, -1, parsed.gid)
+	testhelpers.AssertEqual(t, uint64(512), parsed.limits.MemoryMB)
+	testhelpers.AssertEqual(t, uint64(0), parsed.limits.CPUSeconds)
+	testhelpers.AssertEqual(t, 3, len(argv))
+	testhelpers.AssertEqual(t, "-y", argv[1])
+
+	_, _, err = parseHelperArgs([]string{"--"})
+	testhelpers.AssertError(t, err)
+}
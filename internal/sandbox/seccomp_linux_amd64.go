@@ -0,0 +1,18 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_X86_64
+
+// x32SyscallBit is set in the numbers of the system calls of the x32 ABI,
+// which shares the architecture with x86-64 but uses different numbers.
+const x32SyscallBit = 0x40000000
+
+// archFilter kills processes using the x32 ABI, which would bypass the filter.
+// It expects the system call number to be loaded.
+func archFilter() []unix.SockFilter {
+	return []unix.SockFilter{
+		{Code: unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K, Jt: 0, Jf: 1, K: x32SyscallBit},
+		{Code: unix.BPF_RET | unix.BPF_K, K: unix.SECCOMP_RET_KILL_PROCESS},
+	}
+}
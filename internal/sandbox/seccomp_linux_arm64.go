@@ -0,0 +1,9 @@
+package sandbox
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_AARCH64
+
+func archFilter() []unix.SockFilter {
+	return nil
+}
@@ -4,21 +4,22 @@ package audit
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/util"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"gorm.io/gorm"
 )
 
 // AuditService manages audit trail logging for MCPJungle operations.
 type AuditService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	logger logger.Logger
 }
 
 // NewAuditService creates a new audit service instance.
-func NewAuditService(db *gorm.DB) *AuditService {
-	return &AuditService{db: db}
+func NewAuditService(db *gorm.DB, l logger.Logger) *AuditService {
+	return &AuditService{db: db, logger: l}
 }
 
 // LogCreate logs a CREATE operation on an entity.
@@ -167,20 +168,24 @@ func (s *AuditService) logAsync(ctx context.Context, log *model.AuditLog) {
 		log.ActorID = "system"
 	}
 
+	l := logger.FromContext(ctx, s.logger).WithFields(
+		logger.String("entity_type", log.EntityType),
+		logger.String("entity_name", log.EntityName),
+		logger.String("operation", log.Operation),
+	)
+
 	// Write audit log asynchronously to avoid blocking
 	go func() {
 		defer func() {
 			// Recover from any panics to ensure audit logging never crashes the application
 			if r := recover(); r != nil {
-				// In production, this would be logged to a monitoring system
-				fmt.Printf("[WARN] Audit logging panic recovered: %v\n", r)
+				l.Warn("audit logging panic recovered", logger.Any("panic", r))
 			}
 		}()
 
 		if err := s.db.Create(log).Error; err != nil {
 			// Log error but don't fail the operation
-			// In production, this would be sent to a monitoring system
-			fmt.Printf("[WARN] Failed to write audit log: %v\n", err)
+			l.Warn("failed to write audit log", logger.ErrorField(err))
 		}
 	}()
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/util"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -16,7 +17,7 @@ func TestNewAuditService(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 	testhelpers.AssertNotNil(t, svc)
 	testhelpers.AssertEqual(t, setup.DB, svc.db)
 }
@@ -25,7 +26,7 @@ func TestLogCreate(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Create context with audit information
 	ctx := util.SetAuditContext(context.Background(), &util.AuditContext{
@@ -68,7 +69,7 @@ func TestLogCreateWithoutContext(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Log a create operation without audit context (should default to system)
 	data := map[string]interface{}{
@@ -92,7 +93,7 @@ func TestLogUpdate(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	ctx := util.SetAuditContext(context.Background(), &util.AuditContext{
 		ActorType: model.AuditActorUser,
@@ -127,7 +128,7 @@ func TestLogDelete(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	ctx := util.SetAuditContext(context.Background(), &util.AuditContext{
 		ActorType: model.AuditActorUser,
@@ -153,7 +154,7 @@ func TestLogEnable(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	details := map[string]interface{}{
 		"tools_count":   5,
@@ -177,7 +178,7 @@ func TestLogDisable(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	details := map[string]interface{}{
 		"tools_count": 5,
@@ -200,7 +201,7 @@ func TestLogError(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	ctx := util.SetAuditContext(context.Background(), &util.AuditContext{
 		ActorType: model.AuditActorUser,
@@ -228,7 +229,7 @@ func TestListByEntity(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Create multiple audit logs for the same entity
 	ctx := context.Background()
@@ -253,7 +254,7 @@ func TestListAll(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Create logs for different entities
 	ctx := context.Background()
@@ -287,7 +288,7 @@ func TestFilterSensitiveData(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Test that sensitive fields are filtered
 	data := map[string]interface{}{
@@ -314,7 +315,7 @@ func TestFilterSensitiveDataNested(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewAuditService(setup.DB)
+	svc := NewAuditService(setup.DB, logger.NewNop())
 
 	// Test nested sensitive data filtering
 	data := map[string]interface{}{
@@ -0,0 +1,273 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// authorizeToolAccess returns an error if the MCP client making the request is not allowed to access the tool.
+// Authorization only applies in enterprise mode. In development mode, all tools are accessible.
+// Tool-level ACL (via tool groups) is checked first, with server-level ACL as fallback.
+func authorizeToolAccess(ctx context.Context, name string) error {
+	serverMode := ctx.Value("mode").(model.ServerMode)
+	if !model.IsEnterpriseMode(serverMode) {
+		return nil
+	}
+
+	c := ctx.Value("client").(*model.McpClient)
+
+	// Get the tool access checker if available from context
+	var checker model.ToolAccessChecker
+	if tgChecker := ctx.Value("toolGroupChecker"); tgChecker != nil {
+		checker, _ = tgChecker.(model.ToolAccessChecker)
+	}
+
+	if checker == nil {
+		// Fallback to server-level check if tool group service is not available
+		serverName, _, _ := splitServerToolName(name)
+		if !c.CheckHasServerAccess(serverName) {
+			return fmt.Errorf("client %s is not authorized to access MCP server %s", c.Name, serverName)
+		}
+		return nil
+	}
+
+	hasAccess, err := checker.CheckClientToolAccess(c, name)
+	if err != nil {
+		return fmt.Errorf("failed to check tool access for client %s: %w", c.Name, err)
+	}
+	if !hasAccess {
+		return fmt.Errorf("client %s is not authorized to access tool %s", c.Name, name)
+	}
+	return nil
+}
+
+// toolGroupMetaKey is the _meta field of a tool definition that holds the name of the tool group it is scoped to.
+const toolGroupMetaKey = "mcpjungle/tool_group"
+
+// ScopeToolToGroup returns a copy of a tool definition served by the MCP proxy server of the given tool group
+// that MCP clients can only call through that server.
+// Access to it is granted by the group under the name it is served as, not by access to the underlying tool.
+func ScopeToolToGroup(tool mcp.Tool, group string) mcp.Tool {
+	fields := map[string]any{toolGroupMetaKey: group}
+	if tool.Meta != nil {
+		fields = maps.Clone(tool.Meta.AdditionalFields)
+		fields[toolGroupMetaKey] = group
+	}
+	scoped := tool
+	scoped.Meta = &mcp.Meta{AdditionalFields: fields}
+	return scoped
+}
+
+// toolGroupScope returns the tool group that a tool definition is scoped to by ScopeToolToGroup,
+// or an empty string if it isn't scoped to a group.
+func toolGroupScope(tool mcp.Tool) string {
+	if tool.Meta == nil {
+		return ""
+	}
+	group, _ := tool.Meta.AdditionalFields[toolGroupMetaKey].(string)
+	return group
+}
+
+// authorizeServedToolAccess returns an error if the MCP client making the request is not allowed to access
+// a tool definition served by an MCP proxy server.
+// Access to tools scoped to a tool group is decided by the group, access to all others by their canonical name.
+func authorizeServedToolAccess(ctx context.Context, tool mcp.Tool) error {
+	group := toolGroupScope(tool)
+	if group == "" {
+		return authorizeToolAccess(ctx, CanonicalToolName(tool))
+	}
+
+	serverMode := ctx.Value("mode").(model.ServerMode)
+	if !model.IsEnterpriseMode(serverMode) {
+		return nil
+	}
+
+	c := ctx.Value("client").(*model.McpClient)
+
+	var checker model.GroupToolAccessChecker
+	if tgChecker := ctx.Value("toolGroupChecker"); tgChecker != nil {
+		checker, _ = tgChecker.(model.GroupToolAccessChecker)
+	}
+
+	if checker == nil {
+		return authorizeToolAccess(ctx, CanonicalToolName(tool))
+	}
+
+	hasAccess, err := checker.CheckClientGroupToolAccess(c, group, tool.Name, CanonicalToolName(tool))
+	if err != nil {
+		return fmt.Errorf("failed to check tool access for client %s: %w", c.Name, err)
+	}
+	if !hasAccess {
+		return fmt.Errorf("client %s is not authorized to access tool %s of tool group %s", c.Name, tool.Name, group)
+	}
+	return nil
+}
+
+// authorizeToolCall returns an error if the MCP client making the request is not allowed to call the tool
+// that the MCP proxy server handling the request serves under the given name.
+// canonical is the canonical name of the tool, which is checked if the request didn't pass through a proxy server.
+func authorizeToolCall(ctx context.Context, name, canonical string) error {
+	if srv := server.ServerFromContext(ctx); srv != nil {
+		if served := srv.GetTool(name); served != nil {
+			return authorizeServedToolAccess(ctx, served.Tool)
+		}
+	}
+	return authorizeToolAccess(ctx, canonical)
+}
+
+// authorizePromptAccess returns an error if the MCP client making the request is not allowed to access the prompt.
+// Just like tools, authorization only applies in enterprise mode and uses tool groups if the client has any.
+func authorizePromptAccess(ctx context.Context, name string) error {
+	serverMode := ctx.Value("mode").(model.ServerMode)
+	if !model.IsEnterpriseMode(serverMode) {
+		return nil
+	}
+
+	c := ctx.Value("client").(*model.McpClient)
+
+	var checker model.PromptAccessChecker
+	if tgChecker := ctx.Value("toolGroupChecker"); tgChecker != nil {
+		checker, _ = tgChecker.(model.PromptAccessChecker)
+	}
+
+	if checker == nil {
+		serverName, _, _ := splitServerPromptName(name)
+		if !c.CheckHasServerAccess(serverName) {
+			return fmt.Errorf("client %s is not authorized to access MCP server %s", c.Name, serverName)
+		}
+		return nil
+	}
+
+	hasAccess, err := checker.CheckClientPromptAccess(c, name)
+	if err != nil {
+		return fmt.Errorf("failed to check prompt access for client %s: %w", c.Name, err)
+	}
+	if !hasAccess {
+		return fmt.Errorf("client %s is not authorized to access prompt %s", c.Name, name)
+	}
+	return nil
+}
+
+// isMetaTool returns true if the given tool is one of mcpjungle's own meta-tools.
+// Meta-tools are not provided by any upstream MCP server, so they're always visible to all clients.
+func isMetaTool(name string) bool {
+	switch name {
+	case SearchMetaToolName, SearchPromptsMetaToolName, DescribeToolMetaToolName, CallToolMetaToolName,
+		ActivateToolsMetaToolName:
+		return true
+	}
+	return false
+}
+
+// toolSearchScope returns a filter for the results of the search meta-tool that only keeps the tools
+// which the caller can call: the tools served by the MCP proxy server handling the request, which excludes
+// disabled tools and, for a tool group's proxy server, limits the results to the group's effective tools,
+// and the tools that the authenticated MCP client is authorized to access.
+// It returns nil if the request did not pass through an MCP proxy server or mcpjungle's auth middleware.
+func toolSearchScope(ctx context.Context) func(string) bool {
+	srv := server.ServerFromContext(ctx)
+	_, authenticated := ctx.Value("mode").(model.ServerMode)
+	if srv == nil && !authenticated {
+		return nil
+	}
+	return func(name string) bool {
+		if srv != nil {
+			served := srv.GetTool(name)
+			if served == nil {
+				return false
+			}
+			return !authenticated || authorizeServedToolAccess(ctx, served.Tool) == nil
+		}
+		return !authenticated || authorizeToolAccess(ctx, name) == nil
+	}
+}
+
+// promptSearchScope returns a filter for the results of the prompt search meta-tool that only keeps the prompts
+// which the authenticated MCP client is authorized to access.
+// It returns nil if the request did not pass through mcpjungle's auth middleware.
+func promptSearchScope(ctx context.Context) func(types.SearchResult) bool {
+	if _, ok := ctx.Value("mode").(model.ServerMode); !ok {
+		return nil
+	}
+	return func(r types.SearchResult) bool {
+		return authorizePromptAccess(ctx, r.Name) == nil
+	}
+}
+
+// filterToolsForClient is a tools/list filter that only keeps the tools which the
+// MCP client making the request is authorized to access.
+// This ensures that clients are never shown tools they can't call.
+func filterToolsForClient(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	if _, ok := ctx.Value("mode").(model.ServerMode); !ok {
+		// the request did not pass through mcpjungle's auth middleware, nothing to filter on
+		return tools
+	}
+	filtered := make([]mcp.Tool, 0, len(tools))
+	for _, t := range tools {
+		if isMetaTool(t.Name) {
+			filtered = append(filtered, t)
+			continue
+		}
+		if err := authorizeServedToolAccess(ctx, t); err != nil {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+	return filtered
+}
+
+// filterPromptsForClient is an after-list hook for prompts/list that removes the prompts which the
+// MCP client making the request is not authorized to access.
+func filterPromptsForClient(ctx context.Context, _ any, _ *mcp.ListPromptsRequest, result *mcp.ListPromptsResult) {
+	if _, ok := ctx.Value("mode").(model.ServerMode); !ok || result == nil {
+		return
+	}
+	filtered := make([]mcp.Prompt, 0, len(result.Prompts))
+	for _, p := range result.Prompts {
+		if err := authorizePromptAccess(ctx, p.Name); err != nil {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+	result.Prompts = filtered
+}
+
+// ProxyEndpointGlobal is the metrics label of the global MCP proxy endpoint, which serves all tools.
+const ProxyEndpointGlobal = "global"
+
+// ToolGroupProxyEndpoint returns the metrics label of the MCP proxy endpoint of a tool group.
+func ToolGroupProxyEndpoint(group string) string {
+	return "group:" + group
+}
+
+// ProxyServerOptions returns the options that every MCP proxy server in mcpjungle must be created with.
+// They make sure that tools/list and prompts/list responses only contain the tools and prompts
+// which the requesting MCP client is authorized to access.
+// They also keep track of the number of MCP client sessions connected to the proxy server, which
+// serves the given endpoint using the given transport.
+// lazy reports whether the proxy server currently runs in lazy tool loading mode, see SetLazyToolLoading.
+func (m *MCPService) ProxyServerOptions(
+	endpoint string, transport types.McpServerTransport, lazy func() bool,
+) []server.ServerOption {
+	hooks := &server.Hooks{}
+	hooks.AddAfterListPrompts(filterPromptsForClient)
+	hooks.AddOnRegisterSession(func(ctx context.Context, _ server.ClientSession) {
+		m.metrics.RecordActiveSessionsChange(ctx, endpoint, string(transport), 1)
+	})
+	hooks.AddOnUnregisterSession(func(ctx context.Context, session server.ClientSession) {
+		m.metrics.RecordActiveSessionsChange(ctx, endpoint, string(transport), -1)
+		m.activatedTools.drop(session.SessionID())
+	})
+
+	return []server.ServerOption{
+		server.WithToolFilter(m.lazyToolFilter(lazy)),
+		server.WithToolFilter(filterToolsForClient),
+		server.WithHooks(hooks),
+	}
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// fakeAccessChecker grants access to a fixed set of tools and prompts.
+// groupTools holds the tools scoped to a tool group as "group/name".
+type fakeAccessChecker struct {
+	tools      map[string]bool
+	groupTools map[string]bool
+	prompts    map[string]bool
+}
+
+func (f *fakeAccessChecker) CheckClientToolAccess(_ *model.McpClient, toolName string) (bool, error) {
+	return f.tools[toolName], nil
+}
+
+func (f *fakeAccessChecker) CheckClientGroupToolAccess(_ *model.McpClient, groupName, toolName, _ string) (bool, error) {
+	return f.groupTools[groupName+"/"+toolName], nil
+}
+
+func (f *fakeAccessChecker) CheckClientPromptAccess(_ *model.McpClient, promptName string) (bool, error) {
+	return f.prompts[promptName], nil
+}
+
+func enterpriseContext(c *model.McpClient, checker any) context.Context {
+	ctx := context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+	ctx = context.WithValue(ctx, "client", c)
+	if checker != nil {
+		ctx = context.WithValue(ctx, "toolGroupChecker", checker)
+	}
+	return ctx
+}
+
+func TestFilterToolsForClient(t *testing.T) {
+	tools := []mcp.Tool{
+		{Name: SearchMetaToolName},
+		{Name: "github__create_issue"},
+		{Name: "github__delete_repo"},
+		{Name: "slack__post_message"},
+	}
+
+	t.Run("development mode shows all tools", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+		testhelpers.AssertEqual(t, 4, len(filterToolsForClient(ctx, tools)))
+	})
+
+	t.Run("tool group ACL", func(t *testing.T) {
+		checker := &fakeAccessChecker{tools: map[string]bool{"github__create_issue": true}}
+		ctx := enterpriseContext(&model.McpClient{Name: "agent"}, checker)
+
+		filtered := filterToolsForClient(ctx, tools)
+		testhelpers.AssertEqual(t, 2, len(filtered))
+		testhelpers.AssertEqual(t, SearchMetaToolName, filtered[0].Name)
+		testhelpers.AssertEqual(t, "github__create_issue", filtered[1].Name)
+	})
+
+	t.Run("server-level ACL fallback", func(t *testing.T) {
+		c := &model.McpClient{Name: "agent", AllowList: []byte(`["slack"]`)}
+		ctx := enterpriseContext(c, nil)
+
+		filtered := filterToolsForClient(ctx, tools)
+		testhelpers.AssertEqual(t, 2, len(filtered))
+		testhelpers.AssertEqual(t, "slack__post_message", filtered[1].Name)
+	})
+}
+
+func TestFilterPromptsForClient(t *testing.T) {
+	checker := &fakeAccessChecker{prompts: map[string]bool{"github__summarize_pr": true}}
+	ctx := enterpriseContext(&model.McpClient{Name: "agent"}, checker)
+
+	result := &mcp.ListPromptsResult{
+		Prompts: []mcp.Prompt{
+			{Name: "github__summarize_pr"},
+			{Name: "github__review_code"},
+		},
+	}
+	filterPromptsForClient(ctx, 1, &mcp.ListPromptsRequest{}, result)
+
+	testhelpers.AssertEqual(t, 1, len(result.Prompts))
+	testhelpers.AssertEqual(t, "github__summarize_pr", result.Prompts[0].Name)
+}
+
+func TestAuthorizePromptAccess(t *testing.T) {
+	checker := &fakeAccessChecker{prompts: map[string]bool{"github__summarize_pr": true}}
+	ctx := enterpriseContext(&model.McpClient{Name: "agent"}, checker)
+
+	testhelpers.AssertNoError(t, authorizePromptAccess(ctx, "github__summarize_pr"))
+	testhelpers.AssertError(t, authorizePromptAccess(ctx, "github__review_code"))
+}
+
+// sessionMetrics is a telemetry.CustomMetrics that keeps track of the active sessions per endpoint
+type sessionMetrics struct {
+	telemetry.NoopCustomMetrics
+	active map[string]int64
+}
+
+func (s *sessionMetrics) RecordActiveSessionsChange(_ context.Context, endpoint, transport string, delta int64) {
+	s.active[endpoint+"/"+transport] += delta
+}
+
+func TestProxyServerOptionsCountSessions(t *testing.T) {
+	metrics := &sessionMetrics{active: make(map[string]int64)}
+	m := &MCPService{metrics: metrics, activatedTools: newActivatedTools()}
+
+	proxy := server.NewMCPServer("test", "0.0.1",
+		m.ProxyServerOptions(ToolGroupProxyEndpoint("dev"), types.TransportSSE, m.LazyToolLoading)...)
+	ctx := context.Background()
+	testhelpers.AssertNoError(t, proxy.RegisterSession(ctx, server.NewInProcessSession("s1", nil)))
+	testhelpers.AssertNoError(t, proxy.RegisterSession(ctx, server.NewInProcessSession("s2", nil)))
+	testhelpers.AssertEqual(t, int64(2), metrics.active["group:dev/sse"])
+
+	proxy.UnregisterSession(ctx, "s1")
+	testhelpers.AssertEqual(t, int64(1), metrics.active["group:dev/sse"])
+}
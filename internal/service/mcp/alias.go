@@ -0,0 +1,116 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// The _meta fields of a tool exposed under an alias.
+// mcp-go never sends the _meta field of a tool definition to clients, so they stay internal to mcpjungle.
+const (
+	// canonicalNameMetaKey holds the canonical name of the underlying tool.
+	canonicalNameMetaKey = "mcpjungle/canonical_name"
+	// hiddenParametersMetaKey holds the parameters of the underlying tool that the alias leaves out.
+	hiddenParametersMetaKey = "mcpjungle/hidden_parameters"
+)
+
+// AliasTool returns the definition of the tool exposed under the given alias.
+// Calls to the returned tool must be handled by MCPProxyToolCallHandler, which forwards them to the underlying tool.
+func (m *MCPService) AliasTool(alias types.ToolAlias) (mcp.Tool, error) {
+	if isMetaTool(alias.Tool) {
+		return mcp.Tool{}, fmt.Errorf("%s is a meta-tool and can't be aliased", alias.Tool)
+	}
+	tool, ok := m.GetToolInstance(alias.Tool)
+	if !ok {
+		return mcp.Tool{}, fmt.Errorf("tool %s does not exist or is disabled", alias.Tool)
+	}
+
+	aliased := tool
+	aliased.Name = alias.Name
+	if alias.Description != "" {
+		aliased.Description = alias.Description
+	}
+	aliased.Meta = &mcp.Meta{AdditionalFields: map[string]any{canonicalNameMetaKey: alias.Tool}}
+	if len(alias.Parameters) > 0 {
+		schema, err := narrowInputSchema(alias.Tool, tool.InputSchema, alias.Parameters)
+		if err != nil {
+			return mcp.Tool{}, err
+		}
+		aliased.InputSchema = schema
+
+		var hidden []string
+		for p := range tool.InputSchema.Properties {
+			if !slices.Contains(alias.Parameters, p) {
+				hidden = append(hidden, p)
+			}
+		}
+		aliased.Meta.AdditionalFields[hiddenParametersMetaKey] = hidden
+	}
+	return aliased, nil
+}
+
+// CanonicalToolName returns the canonical name of the tool that a proxy server's tool definition exposes.
+// It is the name of the definition itself, unless the definition was created by AliasTool.
+func CanonicalToolName(tool mcp.Tool) string {
+	if tool.Meta != nil {
+		if name, ok := tool.Meta.AdditionalFields[canonicalNameMetaKey].(string); ok {
+			return name
+		}
+	}
+	return tool.Name
+}
+
+// narrowInputSchema returns a copy of a tool's input schema that only contains the given parameters.
+// All required parameters of the tool must be kept, otherwise the tool could never be called successfully.
+func narrowInputSchema(toolName string, schema mcp.ToolInputSchema, parameters []string) (mcp.ToolInputSchema, error) {
+	properties := make(map[string]any, len(parameters))
+	for _, p := range parameters {
+		prop, ok := schema.Properties[p]
+		if !ok {
+			return mcp.ToolInputSchema{}, fmt.Errorf("tool %s has no parameter %s", toolName, p)
+		}
+		properties[p] = prop
+	}
+	for _, r := range schema.Required {
+		if _, ok := properties[r]; !ok {
+			return mcp.ToolInputSchema{}, fmt.Errorf("parameter %s of tool %s is required and can't be left out", r, toolName)
+		}
+	}
+
+	narrowed := schema
+	narrowed.Properties = properties
+	narrowed.Required = slices.Clone(schema.Required)
+	return narrowed, nil
+}
+
+// resolveToolAlias returns the canonical name of the tool called by the request.
+// If the request calls a tool exposed under an alias by the MCP proxy server handling it, the call must not pass
+// any of the underlying tool's parameters that the alias leaves out.
+func resolveToolAlias(ctx context.Context, request mcp.CallToolRequest) (string, error) {
+	name := request.Params.Name
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return name, nil
+	}
+	served := srv.GetTool(name)
+	if served == nil {
+		return name, nil
+	}
+	canonical := CanonicalToolName(served.Tool)
+	if canonical == name {
+		return name, nil
+	}
+
+	hidden, _ := served.Tool.Meta.AdditionalFields[hiddenParametersMetaKey].([]string)
+	for arg := range request.GetArguments() {
+		if slices.Contains(hidden, arg) {
+			return "", fmt.Errorf("tool %s does not accept the parameter %s", name, arg)
+		}
+	}
+	return canonical, nil
+}
@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliasTool(t *testing.T) {
+	mcpService, _ := setupLazyTestServer(t, false)
+	mcpService.addToolInstance(mcp.NewTool("github__create_pull_request",
+		mcp.WithDescription("Create a pull request"),
+		mcp.WithString("title", mcp.Required()),
+		mcp.WithString("body"),
+		mcp.WithBoolean("draft"),
+	))
+
+	t.Run("renames the tool and narrows its input schema", func(t *testing.T) {
+		tool, err := mcpService.AliasTool(types.ToolAlias{
+			Name:        "create_pr",
+			Tool:        "github__create_pull_request",
+			Description: "Open a PR",
+			Parameters:  []string{"title", "body"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "create_pr", tool.Name)
+		assert.Equal(t, "Open a PR", tool.Description)
+		assert.Len(t, tool.InputSchema.Properties, 2)
+		assert.NotContains(t, tool.InputSchema.Properties, "draft")
+		assert.Equal(t, []string{"title"}, tool.InputSchema.Required)
+		assert.Equal(t, "github__create_pull_request", CanonicalToolName(tool))
+
+		// the registered tool must not be modified
+		original, _ := mcpService.GetToolInstance("github__create_pull_request")
+		assert.Len(t, original.InputSchema.Properties, 3)
+		assert.Equal(t, "github__create_pull_request", CanonicalToolName(original))
+	})
+
+	t.Run("keeps the description and schema by default", func(t *testing.T) {
+		tool, err := mcpService.AliasTool(types.ToolAlias{Name: "create_pr", Tool: "github__create_pull_request"})
+		require.NoError(t, err)
+		assert.Equal(t, "Create a pull request", tool.Description)
+		assert.Len(t, tool.InputSchema.Properties, 3)
+	})
+
+	t.Run("rejects invalid aliases", func(t *testing.T) {
+		invalid := []types.ToolAlias{
+			{Name: "create_pr", Tool: "github__unknown"},
+			{Name: "search", Tool: SearchMetaToolName},
+			{Name: "create_pr", Tool: "github__create_pull_request", Parameters: []string{"unknown"}},
+			{Name: "create_pr", Tool: "github__create_pull_request", Parameters: []string{"body"}},
+		}
+		for _, alias := range invalid {
+			_, err := mcpService.AliasTool(alias)
+			assert.Error(t, err, "expected an error for alias %+v", alias)
+		}
+	})
+}
+
+func TestToolAliasInProxyServer(t *testing.T) {
+	mcpService, srv := setupLazyTestServer(t, false)
+	mcpService.addToolInstance(srv.GetTool("github__create_issue").Tool)
+
+	tool, err := mcpService.AliasTool(types.ToolAlias{Name: "issue", Tool: "github__create_issue"})
+	require.NoError(t, err)
+	srv.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, err := resolveToolAlias(ctx, request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(name), nil
+	})
+
+	t.Run("calls are resolved to the canonical tool", func(t *testing.T) {
+		result := callMetaTool(t, devSessionContext(srv, "s1"), srv, "issue", map[string]any{"title": "bug"})
+		assert.False(t, result.IsError)
+		assert.Equal(t, "github__create_issue", resultText(t, result))
+
+		// calling the alias through the call meta-tool resolves it the same way
+		result = callMetaTool(t, devSessionContext(srv, "s1"), srv, CallToolMetaToolName, map[string]any{"name": "issue"})
+		assert.Equal(t, "github__create_issue", resultText(t, result))
+	})
+
+	t.Run("access to the alias is decided by access to the canonical tool", func(t *testing.T) {
+		allowed := &model.McpClient{Name: "agent", AllowList: []byte(`["github"]`)}
+		ctx := srv.WithContext(enterpriseContext(allowed, nil), server.NewInProcessSession("s2", nil))
+		assert.Contains(t, listToolNames(t, ctx, srv), "issue")
+
+		denied := &model.McpClient{Name: "agent", AllowList: []byte(`["slack"]`)}
+		ctx = srv.WithContext(enterpriseContext(denied, nil), server.NewInProcessSession("s3", nil))
+		assert.NotContains(t, listToolNames(t, ctx, srv), "issue")
+	})
+}
+
+func TestResolveToolAliasRejectsHiddenParameters(t *testing.T) {
+	mcpService, srv := setupLazyTestServer(t, false)
+	mcpService.addToolInstance(mcp.NewTool("github__create_pull_request",
+		mcp.WithString("title", mcp.Required()),
+		mcp.WithBoolean("draft"),
+	))
+	tool, err := mcpService.AliasTool(types.ToolAlias{
+		Name: "create_pr", Tool: "github__create_pull_request", Parameters: []string{"title"},
+	})
+	require.NoError(t, err)
+	srv.AddTool(tool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if _, err := resolveToolAlias(ctx, request); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	ctx := devSessionContext(srv, "s1")
+	result := callMetaTool(t, ctx, srv, "create_pr", map[string]any{"title": "fix", "draft": true})
+	assert.True(t, result.IsError)
+	assert.Contains(t, resultText(t, result), "does not accept the parameter draft")
+
+	result = callMetaTool(t, ctx, srv, "create_pr", map[string]any{"title": "fix"})
+	assert.False(t, result.IsError)
+}
+
+func TestGroupScopedAliasAccess(t *testing.T) {
+	mcpService, srv := setupLazyTestServer(t, false)
+	mcpService.addToolInstance(srv.GetTool("github__create_issue").Tool)
+
+	// both tools authorize calls like MCPProxyToolCallHandler and return the name of the called tool
+	handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, err := resolveToolAlias(ctx, request)
+		if err == nil {
+			err = authorizeToolCall(ctx, request.Params.Name, name)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(name), nil
+	}
+	tool, err := mcpService.AliasTool(types.ToolAlias{Name: "issue", Tool: "github__create_issue"})
+	require.NoError(t, err)
+	srv.AddTool(ScopeToolToGroup(tool, "issues"), handler)
+	srv.AddTool(srv.GetTool("github__create_issue").Tool, handler)
+
+	// the client's group only exposes the tool under its alias
+	checker := &fakeAccessChecker{groupTools: map[string]bool{"issues/issue": true}}
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["issues"]`)}
+	ctx := srv.WithContext(enterpriseContext(c, checker), server.NewInProcessSession("s1", nil))
+
+	t.Run("the alias is listed and callable", func(t *testing.T) {
+		names := listToolNames(t, ctx, srv)
+		assert.Contains(t, names, "issue")
+		assert.NotContains(t, names, "github__create_issue")
+
+		result := callMetaTool(t, ctx, srv, "issue", map[string]any{"title": "bug"})
+		assert.False(t, result.IsError)
+		assert.Equal(t, "github__create_issue", resultText(t, result))
+	})
+
+	t.Run("the underlying tool can't be called under its canonical name", func(t *testing.T) {
+		result := callMetaTool(t, ctx, srv, "github__create_issue", map[string]any{"title": "bug"})
+		assert.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "not authorized")
+
+		result = callMetaTool(t, ctx, srv, CallToolMetaToolName, map[string]any{"name": "github__create_issue"})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("the alias is only accessible through its group", func(t *testing.T) {
+		other := &fakeAccessChecker{groupTools: map[string]bool{"other/issue": true}}
+		ctx := srv.WithContext(enterpriseContext(c, other), server.NewInProcessSession("s2", nil))
+		assert.NotContains(t, listToolNames(t, ctx, srv), "issue")
+		result := callMetaTool(t, ctx, srv, "issue", map[string]any{"title": "bug"})
+		assert.True(t, result.IsError)
+	})
+}
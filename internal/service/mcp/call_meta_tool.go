@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DescribeToolMetaToolName is the canonical name of the meta-tool that returns the full definition of a tool
+	DescribeToolMetaToolName = "mcpjungle__describe_tool"
+
+	// CallToolMetaToolName is the canonical name of the meta-tool that calls any tool by its canonical name
+	CallToolMetaToolName = "mcpjungle__call_tool"
+)
+
+// initCallToolMetaTools registers the describe and call meta-tools in the global MCP proxy servers.
+// Together with the search meta-tool, they let agents use every tool in the registry without listing all of them.
+func (m *MCPService) initCallToolMetaTools() {
+	tools := []server.ServerTool{
+		{Tool: describeToolMetaTool(), Handler: m.describeToolMetaToolHandler},
+		{Tool: callToolMetaTool(), Handler: m.callToolMetaToolHandler},
+	}
+	for _, t := range tools {
+		m.mcpProxyServer.AddTool(t.Tool, t.Handler)
+		m.sseMcpProxyServer.AddTool(t.Tool, t.Handler)
+		m.addToolInstance(t.Tool)
+	}
+}
+
+func describeToolMetaTool() mcp.Tool {
+	return mcp.Tool{
+		Name: DescribeToolMetaToolName,
+		Description: "Get the full definition of a tool, including its input schema and its annotations, " +
+			"eg- whether it only reads data or is destructive. " +
+			"Use it to find out how to call a tool found with " + SearchMetaToolName + ".",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Canonical name of the tool, eg- github__create_issue",
+				},
+			},
+			Required: []string{"name"},
+		},
+	}
+}
+
+func callToolMetaTool() mcp.Tool {
+	return mcp.Tool{
+		Name: CallToolMetaToolName,
+		Description: "Call any tool that you are allowed to use by its canonical name, even if it is not in your tool list. " +
+			"Use " + DescribeToolMetaToolName + " to find out which arguments it takes.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"name": map[string]interface{}{
+					"type":        "string",
+					"description": "Canonical name of the tool to call, eg- github__create_issue",
+				},
+				"arguments": map[string]interface{}{
+					"type":        "object",
+					"description": "Arguments to call the tool with, matching its input schema",
+				},
+			},
+			Required: []string{"name"},
+		},
+	}
+}
+
+// lookupCallableTool returns the tool with the given canonical name if the caller can call it
+// through the MCP proxy server handling the request.
+// Meta-tools are never returned, they are already available to every caller.
+func (m *MCPService) lookupCallableTool(ctx context.Context, name string) (server.ServerTool, error) {
+	if isMetaTool(name) {
+		return server.ServerTool{}, fmt.Errorf("%s is a meta-tool, call it directly", name)
+	}
+	if allow := toolSearchScope(ctx); allow != nil && !allow(name) {
+		return server.ServerTool{}, fmt.Errorf("tool %s does not exist or you are not allowed to use it", name)
+	}
+	if srv := server.ServerFromContext(ctx); srv != nil {
+		// the scope check above guarantees that the server handling the request serves the tool
+		return *srv.GetTool(name), nil
+	}
+	tool, ok := m.GetToolInstance(name)
+	if !ok {
+		return server.ServerTool{}, fmt.Errorf("tool %s does not exist or is disabled", name)
+	}
+	return server.ServerTool{Tool: tool, Handler: m.MCPProxyToolCallHandler}, nil
+}
+
+// describeToolMetaToolHandler handles calls to the describe meta-tool
+func (m *MCPService) describeToolMetaToolHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, fmt.Errorf("'name' parameter is required: %w", err)
+	}
+	tool, err := m.lookupCallableTool(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	definition, err := json.MarshalIndent(tool.Tool, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to format the definition of %s: %v", name, err)), nil
+	}
+	return mcp.NewToolResultText(string(definition)), nil
+}
+
+// callToolMetaToolHandler handles calls to the call meta-tool.
+// It calls the tool with the handler of the MCP proxy server handling the request,
+// so the call is subject to the same checks as if the tool had been called directly.
+func (m *MCPService) callToolMetaToolHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return nil, fmt.Errorf("'name' parameter is required: %w", err)
+	}
+	tool, err := m.lookupCallableTool(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var call mcp.CallToolRequest
+	call.Params.Name = name
+	call.Params.Arguments = request.GetArguments()["arguments"]
+	call.Params.Meta = request.Params.Meta
+	return tool.Handler(ctx, call)
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeAndCallToolMetaTools(t *testing.T) {
+	_, srv := setupLazyTestServer(t, false)
+	ctx := devSessionContext(srv, "s1")
+
+	t.Run("describe returns the tool definition", func(t *testing.T) {
+		result := callMetaTool(t, ctx, srv, DescribeToolMetaToolName, map[string]any{"name": "github__create_issue"})
+		require.False(t, result.IsError)
+
+		var tool mcp.Tool
+		require.NoError(t, json.Unmarshal([]byte(resultText(t, result)), &tool))
+		assert.Equal(t, "github__create_issue", tool.Name)
+		assert.Equal(t, []string{"title"}, tool.InputSchema.Required)
+		require.NotNil(t, tool.Annotations.DestructiveHint)
+		assert.False(t, *tool.Annotations.DestructiveHint)
+	})
+
+	t.Run("call forwards the arguments to the tool", func(t *testing.T) {
+		result := callMetaTool(t, ctx, srv, CallToolMetaToolName, map[string]any{
+			"name":      "github__create_issue",
+			"arguments": map[string]any{"title": "bug"},
+		})
+		require.False(t, result.IsError)
+		assert.Equal(t, `github__create_issue {"title":"bug"}`, resultText(t, result))
+	})
+
+	t.Run("tools the client cannot access are neither described nor called", func(t *testing.T) {
+		c := &model.McpClient{Name: "agent", AllowList: []byte(`["slack"]`)}
+		ctx := enterpriseContext(c, nil)
+		assert.True(t, callMetaTool(t, ctx, srv, DescribeToolMetaToolName, map[string]any{"name": "github__create_issue"}).IsError)
+		assert.True(t, callMetaTool(t, ctx, srv, CallToolMetaToolName, map[string]any{"name": "github__create_issue"}).IsError)
+		assert.False(t, callMetaTool(t, ctx, srv, CallToolMetaToolName, map[string]any{"name": "slack__post_message"}).IsError)
+	})
+}
+
+func TestCallToolMetaToolsInGlobalProxy(t *testing.T) {
+	db := setupTestDBForSearch(t)
+	mcpProxyServer := server.NewMCPServer("test-server", "1.0.0")
+	mcpService, err := NewMCPService(
+		db, mcpProxyServer, server.NewMCPServer("test-sse-server", "1.0.0"), telemetry.NewNoopCustomMetrics(), logger.NewNop(),
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{DescribeToolMetaToolName, CallToolMetaToolName} {
+		_, exists := mcpService.GetToolInstance(name)
+		assert.True(t, exists, "expected %s to be registered", name)
+	}
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	result := callMetaTool(t, ctx, mcpProxyServer, CallToolMetaToolName, map[string]any{"name": SearchMetaToolName})
+	assert.True(t, result.IsError, "expected meta-tools not to be callable through the call meta-tool")
+	result = callMetaTool(t, ctx, mcpProxyServer, DescribeToolMetaToolName, map[string]any{"name": "github__unknown"})
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+const (
+	// defaultMaxQueueDepth is the number of calls that can wait for a server whose
+	// max_queue_depth is not configured.
+	defaultMaxQueueDepth = 100
+	// defaultQueueTimeout is how long a call waits for a server whose queue_timeout_seconds is not configured.
+	defaultQueueTimeout = 30 * time.Second
+)
+
+var (
+	// ErrServerQueueFull is returned when a call cannot be queued because the server's queue is full.
+	ErrServerQueueFull = errors.New("too many calls waiting for the MCP server")
+	// ErrServerQueueTimeout is returned when a call waited too long in the server's queue.
+	ErrServerQueueTimeout = errors.New("timed out waiting for the MCP server to become available")
+)
+
+// serverLimiter limits the number of concurrent calls to a single upstream MCP server.
+// Calls above the limit wait in a FIFO queue, so they are admitted in the order they arrived.
+type serverLimiter struct {
+	mu     sync.Mutex
+	active int
+	// waiters holds a channel per queued call. Closing the channel hands a slot over to the call.
+	waiters *list.List
+}
+
+func newServerLimiter() *serverLimiter {
+	return &serverLimiter{waiters: list.New()}
+}
+
+// acquire waits until the call can be forwarded to the server.
+// It returns the time spent in the queue. If the call could not be admitted, an error is returned
+// and the caller must not call release.
+func (l *serverLimiter) acquire(
+	ctx context.Context, maxConcurrency, maxQueueDepth int, timeout time.Duration, onQueueChange func(delta int64),
+) (time.Duration, error) {
+	l.mu.Lock()
+	if l.active < maxConcurrency && l.waiters.Len() == 0 {
+		l.active++
+		l.mu.Unlock()
+		return 0, nil
+	}
+	if l.waiters.Len() >= maxQueueDepth {
+		l.mu.Unlock()
+		return 0, ErrServerQueueFull
+	}
+	ready := make(chan struct{})
+	e := l.waiters.PushBack(ready)
+	l.mu.Unlock()
+
+	onQueueChange(1)
+	defer onQueueChange(-1)
+
+	started := time.Now()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-ready:
+		return time.Since(started), nil
+	case <-timer.C:
+		err = ErrServerQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-ready:
+		// the slot was handed over right as we gave up, so pass it on
+		l.releaseLocked()
+	default:
+		l.waiters.Remove(e)
+	}
+	return time.Since(started), err
+}
+
+// release frees the slot held by a call, handing it over to the next queued call if there is one.
+func (l *serverLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseLocked()
+}
+
+func (l *serverLimiter) releaseLocked() {
+	if front := l.waiters.Front(); front != nil {
+		l.waiters.Remove(front)
+		close(front.Value.(chan struct{}))
+		return
+	}
+	l.active--
+}
+
+// acquireServerSlot waits for a free slot to forward a call to the given MCP server,
+// respecting the server's max_concurrency setting.
+// It returns a function that must be called to release the slot once the call is complete.
+// Queue depth and wait times are recorded in the metrics.
+func (m *MCPService) acquireServerSlot(ctx context.Context, s *model.McpServer) (func(), error) {
+	if s.MaxConcurrency <= 0 {
+		return func() {}, nil
+	}
+
+	m.limitersMu.Lock()
+	l, ok := m.serverLimiters[s.Name]
+	if !ok {
+		l = newServerLimiter()
+		m.serverLimiters[s.Name] = l
+	}
+	m.limitersMu.Unlock()
+
+	maxQueueDepth := s.MaxQueueDepth
+	if maxQueueDepth <= 0 {
+		maxQueueDepth = defaultMaxQueueDepth
+	}
+	timeout := time.Duration(s.QueueTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultQueueTimeout
+	}
+
+	waited, err := l.acquire(ctx, s.MaxConcurrency, maxQueueDepth, timeout, func(delta int64) {
+		m.metrics.RecordServerQueueDepthChange(ctx, s.Name, delta)
+	})
+	m.metrics.RecordServerQueueWait(ctx, s.Name, waited, err == nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward call to MCP server %s: %w", s.Name, err)
+	}
+	return l.release, nil
+}
+
+// removeServerLimiter drops the concurrency limiter of a server, eg- when the server is deregistered.
+func (m *MCPService) removeServerLimiter(name string) {
+	m.limitersMu.Lock()
+	defer m.limitersMu.Unlock()
+	delete(m.serverLimiters, name)
+}
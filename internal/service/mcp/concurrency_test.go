@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+func noopQueueChange(int64) {}
+
+func TestServerLimiterFIFO(t *testing.T) {
+	l := newServerLimiter()
+	ctx := context.Background()
+
+	_, err := l.acquire(ctx, 1, 10, time.Second, noopQueueChange)
+	testhelpers.AssertNoError(t, err)
+
+	admitted := make(chan int, 3)
+	for i := 0; i < 3; i++ {
+		go func(i int) {
+			if _, err := l.acquire(ctx, 1, 10, 5*time.Second, noopQueueChange); err == nil {
+				admitted <- i
+			}
+		}(i)
+		// make sure the calls are queued in order
+		for {
+			l.mu.Lock()
+			n := l.waiters.Len()
+			l.mu.Unlock()
+			if n == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	for want := 0; want < 3; want++ {
+		l.release()
+		got := <-admitted
+		testhelpers.AssertEqual(t, want, got)
+	}
+	l.release()
+	testhelpers.AssertEqual(t, 0, l.active)
+}
+
+func TestServerLimiterQueueFull(t *testing.T) {
+	l := newServerLimiter()
+	ctx := context.Background()
+
+	_, err := l.acquire(ctx, 1, 0, time.Second, noopQueueChange)
+	testhelpers.AssertNoError(t, err)
+
+	_, err = l.acquire(ctx, 1, 0, time.Second, noopQueueChange)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrServerQueueFull), "expected queue full error")
+}
+
+func TestServerLimiterTimeout(t *testing.T) {
+	l := newServerLimiter()
+	ctx := context.Background()
+
+	_, err := l.acquire(ctx, 1, 1, time.Second, noopQueueChange)
+	testhelpers.AssertNoError(t, err)
+
+	var depth int64
+	waited, err := l.acquire(ctx, 1, 1, 20*time.Millisecond, func(delta int64) { depth += delta })
+	testhelpers.AssertTrue(t, errors.Is(err, ErrServerQueueTimeout), "expected queue timeout error")
+	testhelpers.AssertTrue(t, waited >= 20*time.Millisecond, "expected the call to wait for the timeout")
+	testhelpers.AssertEqual(t, int64(0), depth)
+	testhelpers.AssertEqual(t, 0, l.waiters.Len())
+
+	// the slot is still held by the first call, releasing it frees the server
+	l.release()
+	testhelpers.AssertEqual(t, 0, l.active)
+}
+
+func TestServerLimiterContextCancelled(t *testing.T) {
+	l := newServerLimiter()
+
+	_, err := l.acquire(context.Background(), 1, 1, time.Second, noopQueueChange)
+	testhelpers.AssertNoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = l.acquire(ctx, 1, 1, time.Second, noopQueueChange)
+	testhelpers.AssertTrue(t, errors.Is(err, context.Canceled), "expected context cancelled error")
+	testhelpers.AssertEqual(t, 0, l.waiters.Len())
+}
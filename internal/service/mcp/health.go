@@ -0,0 +1,276 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+const (
+	// DefaultHealthCheckInterval is the time between two health checks of the upstream MCP servers
+	// if health checks are enabled without specifying an interval.
+	DefaultHealthCheckInterval = 30 * time.Second
+	// DefaultStdioHealthCheckInterval is the default minimum time between two health checks of a STDIO server.
+	// Every check of a STDIO server starts a new process, so they are checked less often than other servers.
+	DefaultStdioHealthCheckInterval = 5 * time.Minute
+
+	// defaultHealthCheckTimeout is the maximum time a single health check of a server may take.
+	defaultHealthCheckTimeout = 10 * time.Second
+	// defaultUnhealthyThreshold is the number of consecutive failed health checks after which
+	// the tools of a server are hidden, if hiding is enabled.
+	defaultUnhealthyThreshold = 3
+)
+
+// HealthCheckOptions configures the background health checker of upstream MCP servers.
+type HealthCheckOptions struct {
+	// Interval is the time between two rounds of health checks.
+	Interval time.Duration
+	// Timeout is the maximum time a single health check may take.
+	Timeout time.Duration
+	// StdioInterval is the minimum time between two health checks of a STDIO server.
+	// It defaults to DefaultStdioHealthCheckInterval, or Interval if that is longer.
+	StdioInterval time.Duration
+
+	// HideUnhealthyTools removes the tools of a server from the MCP proxy once the server failed
+	// UnhealthyThreshold health checks in a row. The tools are added back as soon as the server is healthy again.
+	HideUnhealthyTools bool
+	// UnhealthyThreshold is the number of consecutive failed health checks after which a server's tools are hidden.
+	UnhealthyThreshold int
+}
+
+// StartHealthChecker periodically checks the health of all registered MCP servers in the background
+// until the given context is cancelled.
+// A health check initializes a new session with the server and pings it.
+// Like tool calls, health checks respect the server's max_concurrency setting and circuit breaker.
+func (m *MCPService) StartHealthChecker(ctx context.Context, opts HealthCheckOptions) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultHealthCheckInterval
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultHealthCheckTimeout
+	}
+	if opts.StdioInterval <= 0 {
+		opts.StdioInterval = max(DefaultStdioHealthCheckInterval, opts.Interval)
+	}
+	if opts.UnhealthyThreshold <= 0 {
+		opts.UnhealthyThreshold = defaultUnhealthyThreshold
+	}
+
+	go func() {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			m.checkAllServersHealth(ctx, opts)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// checkAllServersHealth runs a health check against every registered MCP server concurrently
+// and waits for all of them to complete.
+func (m *MCPService) checkAllServersHealth(ctx context.Context, opts HealthCheckOptions) {
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		m.contextLogger(ctx).Error("health checker failed to list MCP servers", logger.ErrorField(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := range servers {
+		if servers[i].Transport == types.TransportStdio && m.checkedWithin(servers[i].Name, opts.StdioInterval) {
+			continue
+		}
+		wg.Add(1)
+		go func(s *model.McpServer) {
+			defer wg.Done()
+			m.checkServerHealth(ctx, s, opts)
+		}(&servers[i])
+	}
+	wg.Wait()
+}
+
+// checkedWithin returns true if the given server's latest health check is more recent than the given duration.
+func (m *MCPService) checkedWithin(name string, d time.Duration) bool {
+	h, ok := m.GetServerHealth(name)
+	return ok && h.LastCheckedAt != nil && time.Since(*h.LastCheckedAt) < d
+}
+
+// checkServerHealth runs a single health check against the given MCP server and records the result.
+// If hiding unhealthy tools is enabled, it also hides or restores the server's tools.
+// If the server is too busy to take the check within the timeout, the check is skipped.
+func (m *MCPService) checkServerHealth(ctx context.Context, s *model.McpServer, opts HealthCheckOptions) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	ctx = m.withLogFields(ctx, logger.String("server", s.Name))
+	l := m.contextLogger(ctx)
+
+	release, err := m.acquireServerSlot(ctx, s)
+	if err != nil {
+		// a busy server says nothing about its health
+		l.Debug("skipping health check of busy MCP server", logger.ErrorField(err))
+		return
+	}
+	defer release()
+
+	started := time.Now()
+	err = m.pingMcpServer(ctx, s)
+	latency := time.Since(started)
+
+	h := m.recordServerHealth(s.Name, started, latency, err)
+
+	if !opts.HideUnhealthyTools {
+		return
+	}
+	if h.Status == types.HealthStatusUnhealthy && h.ConsecutiveFailures >= opts.UnhealthyThreshold && !h.ToolsHidden {
+		if err := m.setServerToolsHidden(s, true); err != nil {
+			l.Error("failed to hide tools of unhealthy MCP server", logger.ErrorField(err))
+			return
+		}
+		l.Warn("hiding tools of unhealthy MCP server", logger.Int("failed_checks", h.ConsecutiveFailures))
+	} else if h.Status == types.HealthStatusHealthy && h.ToolsHidden {
+		if err := m.setServerToolsHidden(s, false); err != nil {
+			l.Error("failed to restore tools of MCP server", logger.ErrorField(err))
+			return
+		}
+		l.Info("MCP server is healthy again, restored its tools")
+	}
+}
+
+// pingMcpServer initializes a new session with the MCP server and pings it.
+// The ping goes through the server's circuit breaker, so it fails fast while the breaker is open
+// and serves as the trial call once the cooldown is over.
+func (m *MCPService) pingMcpServer(ctx context.Context, s *model.McpServer) error {
+	if s.Transport == types.TransportStdio {
+		// fail with a clear message if the command was removed since the server was registered
+		conf, err := s.GetStdioConfig()
+		if err != nil {
+			return fmt.Errorf("failed to get stdio config: %w", err)
+		}
+		if _, err := exec.LookPath(conf.Command); err != nil {
+			return fmt.Errorf("command %s not found: %w", conf.Command, err)
+		}
+	}
+
+	return m.withUpstreamSession(ctx, s, true, func(ctx context.Context, c *client.Client) error {
+		if err := c.Ping(ctx); err != nil {
+			return fmt.Errorf("ping failed: %w", err)
+		}
+		return nil
+	})
+}
+
+// recordServerHealth updates the health of the given server with the result of a health check.
+// It returns a copy of the updated health.
+func (m *MCPService) recordServerHealth(name string, checkedAt time.Time, latency time.Duration, err error) types.ServerHealth {
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+
+	h, ok := m.health[name]
+	if !ok {
+		h = &types.ServerHealth{}
+		m.health[name] = h
+	}
+	h.LastCheckedAt = &checkedAt
+	h.LatencyMs = latency.Milliseconds()
+	if err != nil {
+		h.Status = types.HealthStatusUnhealthy
+		h.LastError = err.Error()
+		h.ConsecutiveFailures++
+	} else {
+		h.Status = types.HealthStatusHealthy
+		h.LastError = ""
+		h.ConsecutiveFailures = 0
+		h.LastSeen = &checkedAt
+	}
+	return *h
+}
+
+// GetServerHealth returns the result of the latest health checks of the given MCP server.
+// It returns false if the server has not been checked yet.
+func (m *MCPService) GetServerHealth(name string) (*types.ServerHealth, bool) {
+	m.healthMu.RLock()
+	defer m.healthMu.RUnlock()
+
+	h, ok := m.health[name]
+	if !ok {
+		return nil, false
+	}
+	c := *h
+	return &c, true
+}
+
+// removeServerHealth drops the health of a server, eg- when the server is deregistered.
+func (m *MCPService) removeServerHealth(name string) {
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+	delete(m.health, name)
+}
+
+// setServerToolsHidden removes all enabled tools of the server from the MCP proxy, or adds them back.
+// Unlike disabling the tools, this does not change the tools in the DB.
+func (m *MCPService) setServerToolsHidden(s *model.McpServer, hidden bool) error {
+	tools, err := m.ListToolsByServer(s.Name)
+	if err != nil {
+		return err
+	}
+
+	var toolNames []string
+	for i := range tools {
+		if !tools[i].Enabled {
+			continue
+		}
+		toolNames = append(toolNames, tools[i].Name)
+
+		if hidden {
+			continue
+		}
+		mcpTool, err := convertToolModelToMcpObject(&tools[i])
+		if err != nil {
+			return fmt.Errorf("failed to convert tool model to MCP object for tool %s: %w", tools[i].Name, err)
+		}
+		m.addProxyTool(s, mcpTool)
+	}
+
+	if hidden && len(toolNames) > 0 {
+		if s.Transport == types.TransportSSE {
+			m.sseMcpProxyServer.DeleteTools(toolNames...)
+		} else {
+			m.mcpProxyServer.DeleteTools(toolNames...)
+		}
+		m.deleteToolInstances(toolNames...)
+		m.notifyToolDeletion(toolNames...)
+	}
+
+	m.healthMu.Lock()
+	if h, ok := m.health[s.Name]; ok {
+		h.ToolsHidden = hidden
+	}
+	m.healthMu.Unlock()
+
+	return nil
+}
+
+// addProxyTool adds a tool of the given server back to the appropriate MCP proxy server.
+func (m *MCPService) addProxyTool(s *model.McpServer, tool mcp.Tool) {
+	if s.Transport == types.TransportSSE {
+		m.sseMcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
+	} else {
+		m.mcpProxyServer.AddTool(tool, m.MCPProxyToolCallHandler)
+	}
+	m.addToolInstance(tool)
+	m.notifyToolAddition(tool.Name)
+}
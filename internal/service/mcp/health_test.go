@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// newFlakyUpstream starts a streamable http MCP server with a single "echo" tool.
+// The server responds with 503 while down is true.
+func newFlakyUpstream(t *testing.T, down *atomic.Bool) *httptest.Server {
+	upstream := server.NewMCPServer("upstream", "0.1")
+	upstream.AddTool(mcp.NewTool("echo"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	h := server.NewStreamableHTTPServer(upstream)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestCheckServerHealth(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	var down atomic.Bool
+	ts := newFlakyUpstream(t, &down)
+
+	s, err := model.NewStreamableHTTPServer("flaky", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, svc.RegisterMcpServer(context.Background(), s))
+
+	_, ok := svc.GetServerHealth("flaky")
+	testhelpers.AssertTrue(t, !ok, "expected no health before the first check")
+
+	opts := HealthCheckOptions{Timeout: 5 * time.Second, HideUnhealthyTools: true, UnhealthyThreshold: 2}
+
+	svc.checkServerHealth(context.Background(), s, opts)
+	h, ok := svc.GetServerHealth("flaky")
+	testhelpers.AssertTrue(t, ok, "expected health after the first check")
+	testhelpers.AssertEqual(t, types.HealthStatusHealthy, h.Status)
+	testhelpers.AssertNotNil(t, h.LastSeen)
+	testhelpers.AssertEqual(t, "", h.LastError)
+
+	down.Store(true)
+	svc.checkServerHealth(context.Background(), s, opts)
+	h, _ = svc.GetServerHealth("flaky")
+	testhelpers.AssertEqual(t, types.HealthStatusUnhealthy, h.Status)
+	testhelpers.AssertEqual(t, 1, h.ConsecutiveFailures)
+	testhelpers.AssertTrue(t, h.LastError != "", "expected the error of the failed check to be recorded")
+	testhelpers.AssertTrue(t, !h.ToolsHidden, "expected tools to stay visible below the threshold")
+	_, ok = svc.GetToolInstance("flaky__echo")
+	testhelpers.AssertTrue(t, ok, "expected the tool to stay visible below the threshold")
+
+	svc.checkServerHealth(context.Background(), s, opts)
+	h, _ = svc.GetServerHealth("flaky")
+	testhelpers.AssertTrue(t, h.ToolsHidden, "expected tools to be hidden once the threshold is reached")
+	_, ok = svc.GetToolInstance("flaky__echo")
+	testhelpers.AssertTrue(t, !ok, "expected the tool to be hidden")
+
+	// the tools are only hidden, not disabled
+	tool, err := svc.GetTool("flaky__echo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, tool.Enabled, "expected the tool to remain enabled in the DB")
+
+	down.Store(false)
+	svc.checkServerHealth(context.Background(), s, opts)
+	h, _ = svc.GetServerHealth("flaky")
+	testhelpers.AssertEqual(t, types.HealthStatusHealthy, h.Status)
+	testhelpers.AssertTrue(t, !h.ToolsHidden, "expected tools to be restored once the server is healthy")
+	_, ok = svc.GetToolInstance("flaky__echo")
+	testhelpers.AssertTrue(t, ok, "expected the tool to be restored")
+
+	testhelpers.AssertNoError(t, svc.DeregisterMcpServer("flaky"))
+	_, ok = svc.GetServerHealth("flaky")
+	testhelpers.AssertTrue(t, !ok, "expected health to be dropped on deregistration")
+}
+
+func TestPingMcpServerMissingCommand(t *testing.T) {
+	s, err := model.NewStdioServer("gone", "", "mcpjungle-command-that-does-not-exist", nil, nil)
+	testhelpers.AssertNoError(t, err)
+
+	m := &MCPService{metrics: telemetry.NewNoopCustomMetrics()}
+	err = m.pingMcpServer(context.Background(), s)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertTrue(t, testhelpers.Contains(err.Error(), "not found"), "expected a command not found error")
+}
+
+func TestHealthChecksThrottleStdioServers(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	s, err := model.NewStdioServer("gone", "", "mcpjungle-command-that-does-not-exist", nil, nil)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, setup.DB.Create(s).Error)
+
+	opts := HealthCheckOptions{Timeout: 5 * time.Second, StdioInterval: time.Hour}
+	for i := 0; i < 2; i++ {
+		svc.checkAllServersHealth(context.Background(), opts)
+	}
+	h, ok := svc.GetServerHealth("gone")
+	testhelpers.AssertTrue(t, ok, "expected the stdio server to be checked")
+	testhelpers.AssertEqual(t, 1, h.ConsecutiveFailures)
+}
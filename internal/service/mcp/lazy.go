@@ -0,0 +1,256 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+)
+
+// ActivateToolsMetaToolName is the canonical name of the meta-tool that adds tools to the session's tool list.
+// Unlike the other meta-tools, it is only listed in lazy tool loading mode.
+const ActivateToolsMetaToolName = "mcpjungle__activate_tools"
+
+// SetLazyToolLoading turns lazy tool loading on or off for the global MCP proxy endpoints.
+// In lazy mode, MCP client sessions initially only list mcpjungle's meta-tools, which let them search,
+// describe and call the other tools, and the activate meta-tool, which adds tools to the session's tool list.
+// This keeps the tool list small when mcpjungle proxies a large number of tools.
+func (m *MCPService) SetLazyToolLoading(enabled bool) {
+	m.lazyToolLoading.Store(enabled)
+}
+
+// LazyToolLoading returns true if lazy tool loading is turned on for the global MCP proxy endpoints.
+func (m *MCPService) LazyToolLoading() bool {
+	return m.lazyToolLoading.Load()
+}
+
+const (
+	// activatedToolsTTL is how long the tools activated in a session are remembered after the session last used them.
+	activatedToolsTTL = 24 * time.Hour
+	// maxActivatedToolSessions is the maximum number of sessions whose activated tools are remembered.
+	maxActivatedToolSessions = 10000
+)
+
+// activatedTools keeps track of the tools that MCP client sessions activated in lazy tool loading mode.
+// Activations are forgotten when a session is unregistered from the proxy server.
+// Note that mcp-go only registers streamable HTTP sessions while they hold a GET stream open,
+// so sessions of clients that only POST are never unregistered.
+// To bound memory, activations also expire once a session stops using them for the TTL, and the least
+// recently used session is forgotten when the maximum number of sessions is reached.
+type activatedTools struct {
+	mu sync.Mutex
+	// sessions maps session IDs to the tools activated in the session
+	sessions map[string]*sessionTools
+
+	ttl         time.Duration
+	maxSessions int
+	now         func() time.Time
+}
+
+// sessionTools holds the canonical names of the tools activated in a session.
+type sessionTools struct {
+	names    map[string]struct{}
+	lastUsed time.Time
+}
+
+func newActivatedTools() *activatedTools {
+	return &activatedTools{
+		sessions:    make(map[string]*sessionTools),
+		ttl:         activatedToolsTTL,
+		maxSessions: maxActivatedToolSessions,
+		now:         time.Now,
+	}
+}
+
+// activate adds tools to the tools activated in a session.
+func (a *activatedTools) activate(sessionID string, names ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	tools := a.lookup(sessionID, now)
+	if tools == nil {
+		a.makeRoom(now)
+		tools = &sessionTools{names: make(map[string]struct{})}
+		a.sessions[sessionID] = tools
+	}
+	tools.lastUsed = now
+	for _, name := range names {
+		tools.names[name] = struct{}{}
+	}
+}
+
+// active returns a copy of the canonical names of the tools activated in the session.
+func (a *activatedTools) active(sessionID string) map[string]struct{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	tools := a.lookup(sessionID, now)
+	if tools == nil {
+		return nil
+	}
+	tools.lastUsed = now
+	return maps.Clone(tools.names)
+}
+
+// isActive returns true if the tool was activated in the session.
+func (a *activatedTools) isActive(sessionID, name string) bool {
+	_, ok := a.active(sessionID)[name]
+	return ok
+}
+
+// drop forgets the tools activated in a session, eg- because the session ended.
+func (a *activatedTools) drop(sessionID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.sessions, sessionID)
+}
+
+// lookup returns the tools activated in a session, or nil if there are none or they expired.
+// The caller must hold the lock.
+func (a *activatedTools) lookup(sessionID string, now time.Time) *sessionTools {
+	tools, ok := a.sessions[sessionID]
+	if !ok {
+		return nil
+	}
+	if now.Sub(tools.lastUsed) >= a.ttl {
+		delete(a.sessions, sessionID)
+		return nil
+	}
+	return tools
+}
+
+// makeRoom forgets expired sessions and, if the maximum number of sessions is still reached,
+// the least recently used session, so that a new session can be added.
+// The caller must hold the lock.
+func (a *activatedTools) makeRoom(now time.Time) {
+	var lruID string
+	var lru *sessionTools
+	for id, tools := range a.sessions {
+		if now.Sub(tools.lastUsed) >= a.ttl {
+			delete(a.sessions, id)
+			continue
+		}
+		if lru == nil || tools.lastUsed.Before(lru.lastUsed) {
+			lruID, lru = id, tools
+		}
+	}
+	if lru != nil && len(a.sessions) >= a.maxSessions {
+		delete(a.sessions, lruID)
+	}
+}
+
+// lazyToolFilter returns a tools/list filter for an MCP proxy server that supports lazy tool loading.
+// lazy reports whether the server currently runs in lazy mode.
+// In lazy mode, sessions only see the meta-tools and the tools they activated.
+// Otherwise, the activate meta-tool, which is only useful in lazy mode, is hidden.
+func (m *MCPService) lazyToolFilter(lazy func() bool) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		filtered := make([]mcp.Tool, 0, len(tools))
+		if !lazy() {
+			for _, t := range tools {
+				if t.Name != ActivateToolsMetaToolName {
+					filtered = append(filtered, t)
+				}
+			}
+			return filtered
+		}
+
+		activated := m.activatedTools.active(sessionIDFromContext(ctx))
+		for _, t := range tools {
+			if _, ok := activated[t.Name]; ok || isMetaTool(t.Name) {
+				filtered = append(filtered, t)
+			}
+		}
+		return filtered
+	}
+}
+
+// sessionIDFromContext returns the ID of the MCP client session making the request, or an empty string if
+// the request is not part of a session.
+func sessionIDFromContext(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
+
+// initActivateToolsMetaTool registers the activate meta-tool in the global MCP proxy servers.
+func (m *MCPService) initActivateToolsMetaTool() {
+	tool := activateToolsMetaTool()
+	m.mcpProxyServer.AddTool(tool, m.activateToolsMetaToolHandler)
+	m.sseMcpProxyServer.AddTool(tool, m.activateToolsMetaToolHandler)
+	m.addToolInstance(tool)
+}
+
+func activateToolsMetaTool() mcp.Tool {
+	return mcp.Tool{
+		Name: ActivateToolsMetaToolName,
+		Description: "Add tools to your tool list for the rest of the session, so that you can call them directly. " +
+			"Use it for tools you expect to call repeatedly.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"names": map[string]interface{}{
+					"type":        "array",
+					"description": "Canonical names of the tools to activate",
+					"items": map[string]interface{}{
+						"type": "string",
+					},
+				},
+			},
+			Required: []string{"names"},
+		},
+	}
+}
+
+// activateToolsMetaToolHandler handles calls to the activate meta-tool.
+// Activated tools are added to the session's tool list, and the client is notified that its tool list changed.
+func (m *MCPService) activateToolsMetaToolHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	names, err := request.RequireStringSlice("names")
+	if err != nil {
+		return nil, fmt.Errorf("'names' parameter is required: %w", err)
+	}
+	sessionID := sessionIDFromContext(ctx)
+	if sessionID == "" {
+		return mcp.NewToolResultError("Tools can only be activated in an MCP session"), nil
+	}
+
+	var activated, rejected []string
+	for _, name := range names {
+		if _, err := m.lookupCallableTool(ctx, name); err != nil {
+			rejected = append(rejected, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		activated = append(activated, name)
+	}
+	if len(activated) > 0 {
+		m.activatedTools.activate(sessionID, activated...)
+		if srv := server.ServerFromContext(ctx); srv != nil {
+			err := srv.SendNotificationToClient(ctx, mcp.MethodNotificationToolsListChanged, nil)
+			if err != nil {
+				// the client still sees the activated tools the next time it lists tools
+				m.contextLogger(ctx).Warn("failed to notify client of its changed tool list", logger.ErrorField(err))
+			}
+		}
+	}
+
+	var text strings.Builder
+	if len(activated) > 0 {
+		slices.Sort(activated)
+		text.WriteString("Activated tools: " + strings.Join(activated, ", ") + "\n")
+	}
+	if len(rejected) > 0 {
+		text.WriteString("Could not activate:\n- " + strings.Join(rejected, "\n- ") + "\n")
+	}
+	return &mcp.CallToolResult{
+		IsError: len(activated) == 0,
+		Content: []mcp.Content{mcp.NewTextContent(text.String())},
+	}, nil
+}
@@ -0,0 +1,177 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupLazyTestServer returns a proxy server with the meta-tools and two tools that echo their arguments.
+func setupLazyTestServer(t *testing.T, lazy bool) (*MCPService, *server.MCPServer) {
+	db := setupTestDBForSearch(t)
+	mcpService, err := NewMCPService(
+		db, server.NewMCPServer("test-server", "1.0.0"), server.NewMCPServer("test-sse-server", "1.0.0"),
+		telemetry.NewNoopCustomMetrics(), logger.NewNop(),
+	)
+	require.NoError(t, err)
+
+	srv := server.NewMCPServer("group-server", "1.0.0",
+		mcpService.ProxyServerOptions(ToolGroupProxyEndpoint("dev"), types.TransportStreamableHTTP, func() bool { return lazy })...,
+	)
+	mcpService.AddMetaTools(srv)
+
+	echo := func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, _ := json.Marshal(request.GetArguments())
+		return mcp.NewToolResultText(request.Params.Name + " " + string(args)), nil
+	}
+	srv.AddTool(mcp.NewTool("github__create_issue",
+		mcp.WithString("title", mcp.Required()),
+		mcp.WithDestructiveHintAnnotation(false),
+	), echo)
+	srv.AddTool(mcp.NewTool("slack__post_message"), echo)
+	return mcpService, srv
+}
+
+func devSessionContext(srv *server.MCPServer, sessionID string) context.Context {
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	return srv.WithContext(ctx, server.NewInProcessSession(sessionID, nil))
+}
+
+func listToolNames(t *testing.T, ctx context.Context, srv *server.MCPServer) []string {
+	msg, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": "tools/list"})
+	resp, ok := srv.HandleMessage(ctx, msg).(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response")
+	result, ok := resp.Result.(mcp.ListToolsResult)
+	require.True(t, ok, "Expected a ListToolsResult")
+
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func callMetaTool(t *testing.T, ctx context.Context, srv *server.MCPServer, name string, args map[string]any) mcp.CallToolResult {
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	resp, ok := srv.HandleMessage(ctx, msg).(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response")
+	result, ok := resp.Result.(mcp.CallToolResult)
+	require.True(t, ok, "Expected a CallToolResult")
+	return result
+}
+
+func resultText(t *testing.T, result mcp.CallToolResult) string {
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "Expected TextContent")
+	return text.Text
+}
+
+func TestLazyToolFilter(t *testing.T) {
+	t.Run("eager mode lists all tools except the activate meta-tool", func(t *testing.T) {
+		_, srv := setupLazyTestServer(t, false)
+		names := listToolNames(t, devSessionContext(srv, "s1"), srv)
+		assert.ElementsMatch(t, []string{
+			SearchMetaToolName, DescribeToolMetaToolName, CallToolMetaToolName, "github__create_issue", "slack__post_message",
+		}, names)
+	})
+
+	t.Run("lazy mode only lists the meta-tools", func(t *testing.T) {
+		_, srv := setupLazyTestServer(t, true)
+		names := listToolNames(t, devSessionContext(srv, "s1"), srv)
+		assert.ElementsMatch(t, []string{
+			SearchMetaToolName, DescribeToolMetaToolName, CallToolMetaToolName, ActivateToolsMetaToolName,
+		}, names)
+	})
+}
+
+func TestActivateToolsMetaTool(t *testing.T) {
+	mcpService, srv := setupLazyTestServer(t, true)
+	ctx := devSessionContext(srv, "s1")
+
+	result := callMetaTool(t, ctx, srv, ActivateToolsMetaToolName, map[string]any{
+		"names": []string{"github__create_issue", "github__unknown", CallToolMetaToolName},
+	})
+	assert.False(t, result.IsError)
+	text := resultText(t, result)
+	assert.Contains(t, text, "Activated tools: github__create_issue")
+	assert.Contains(t, text, "github__unknown: tool github__unknown does not exist")
+	assert.Contains(t, text, CallToolMetaToolName+": "+CallToolMetaToolName+" is a meta-tool")
+
+	t.Run("activated tools are only listed in the session that activated them", func(t *testing.T) {
+		assert.Contains(t, listToolNames(t, ctx, srv), "github__create_issue")
+		assert.NotContains(t, listToolNames(t, ctx, srv), "slack__post_message")
+		assert.NotContains(t, listToolNames(t, devSessionContext(srv, "s2"), srv), "github__create_issue")
+	})
+
+	t.Run("activations are forgotten when the session ends", func(t *testing.T) {
+		require.NoError(t, srv.RegisterSession(ctx, server.NewInProcessSession("s1", nil)))
+		srv.UnregisterSession(ctx, "s1")
+		assert.False(t, mcpService.activatedTools.isActive("s1", "github__create_issue"))
+	})
+
+	t.Run("tools cannot be activated outside a session", func(t *testing.T) {
+		noSession := context.WithValue(context.Background(), "mode", model.ModeDev)
+		result := callMetaTool(t, noSession, srv, ActivateToolsMetaToolName, map[string]any{
+			"names": []string{"slack__post_message"},
+		})
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("enterprise mode only activates tools the client can access", func(t *testing.T) {
+		c := &model.McpClient{Name: "agent", AllowList: []byte(`["slack"]`)}
+		ctx := srv.WithContext(enterpriseContext(c, nil), server.NewInProcessSession("s3", nil))
+		result := callMetaTool(t, ctx, srv, ActivateToolsMetaToolName, map[string]any{
+			"names": []string{"github__create_issue"},
+		})
+		assert.True(t, result.IsError)
+		assert.False(t, mcpService.activatedTools.isActive("s3", "github__create_issue"))
+	})
+}
+
+func TestActivatedToolsAreBounded(t *testing.T) {
+	now := time.Now()
+	a := newActivatedTools()
+	a.now = func() time.Time { return now }
+	a.maxSessions = 2
+
+	t.Run("activations expire when the session stops using them", func(t *testing.T) {
+		a.activate("s1", "github__create_issue")
+		now = now.Add(a.ttl / 2)
+		assert.True(t, a.isActive("s1", "github__create_issue"))
+		now = now.Add(a.ttl / 2)
+		assert.True(t, a.isActive("s1", "github__create_issue"), "expected using the activation to extend it")
+		now = now.Add(a.ttl)
+		assert.False(t, a.isActive("s1", "github__create_issue"))
+		assert.Empty(t, a.sessions)
+	})
+
+	t.Run("the least recently used session is forgotten when the maximum is reached", func(t *testing.T) {
+		a.activate("s1", "github__create_issue")
+		now = now.Add(time.Minute)
+		a.activate("s2", "github__create_issue")
+		now = now.Add(time.Minute)
+		assert.True(t, a.isActive("s1", "github__create_issue"))
+
+		a.activate("s3", "github__create_issue")
+		assert.Len(t, a.sessions, 2)
+		assert.True(t, a.isActive("s1", "github__create_issue"))
+		assert.False(t, a.isActive("s2", "github__create_issue"))
+		assert.True(t, a.isActive("s3", "github__create_issue"))
+	})
+}
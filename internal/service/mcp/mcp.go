@@ -2,14 +2,21 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/service/audit"
+	"github.com/mcpjungle/mcpjungle/internal/service/ratelimit"
 	"github.com/mcpjungle/mcpjungle/internal/service/search"
+	"github.com/mcpjungle/mcpjungle/internal/service/usage"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
 
@@ -45,7 +52,41 @@ type MCPService struct {
 	// searchService provides tool search functionality
 	searchService *search.SearchService
 
+	// rateLimitService enforces rate limits and quotas on tool calls
+	rateLimitService *ratelimit.RateLimitService
+
+	// usageService aggregates tool calls for usage analytics
+	usageService *usage.UsageService
+	// usageEvents queues the tool calls to be recorded in the usage analytics by a single writer goroutine
+	usageEvents      chan usageEvent
+	startUsageWriter sync.Once
+
+	// serverLimiters holds the concurrency limiters of upstream MCP servers, keyed by server name.
+	serverLimiters map[string]*serverLimiter
+	limitersMu     sync.Mutex
+
+	// breakers holds the circuit breakers of upstream MCP servers, keyed by server name.
+	breakers   map[string]*circuitBreaker
+	breakersMu sync.Mutex
+
+	// health holds the results of the latest health checks of upstream MCP servers, keyed by server name.
+	health   map[string]*types.ServerHealth
+	healthMu sync.RWMutex
+
+	// defaultToolCallTimeout applies to tool calls whose server or tool doesn't configure a timeout
+	defaultToolCallTimeout time.Duration
+
+	// requireStdioSandbox rejects the registration of stdio servers that don't run in a sandbox
+	requireStdioSandbox bool
+
+	// lazyToolLoading makes sessions of the global MCP proxy endpoints initially list only the meta-tools
+	lazyToolLoading atomic.Bool
+	// activatedTools holds the tools that sessions activated in lazy tool loading mode
+	activatedTools *activatedTools
+
 	metrics telemetry.CustomMetrics
+
+	logger logger.Logger
 }
 
 // NewMCPService creates a new instance of MCPService.
@@ -55,15 +96,12 @@ func NewMCPService(
 	mcpProxyServer *server.MCPServer,
 	sseMcpProxyServer *server.MCPServer,
 	metrics telemetry.CustomMetrics,
+	l logger.Logger,
 ) (*MCPService, error) {
     // Validate inputs early to avoid nil dereferences during initialization
     if mcpProxyServer == nil || sseMcpProxyServer == nil {
         return nil, fmt.Errorf("mcp proxy servers must not be nil")
     }
-    // Ensure provided server pointers reference initialized instances
-    // Reinitialize in place to preserve pointer identity expected by tests
-    *mcpProxyServer = *server.NewMCPServer("mcpjungle-proxy", "MCPJungle proxy server")
-    *sseMcpProxyServer = *server.NewMCPServer("mcpjungle-proxy-sse", "MCPJungle SSE proxy server")
 	s := &MCPService{
 		db: db,
 
@@ -80,19 +118,80 @@ func NewMCPService(
 		promptDeletionCallback: func(promptNames ...string) {},
 		promptAdditionCallback: func(promptName string) error { return nil },
 
-		auditService: audit.NewAuditService(db),
+		auditService: audit.NewAuditService(db, l),
 
 		searchService: search.NewSearchService(db),
 
+		rateLimitService: ratelimit.NewRateLimitService(db),
+
+		usageService: usage.NewUsageService(db),
+		usageEvents:  make(chan usageEvent, usageEventBuffer),
+
+		serverLimiters: make(map[string]*serverLimiter),
+		breakers:       make(map[string]*circuitBreaker),
+		health:         make(map[string]*types.ServerHealth),
+
+		activatedTools: newActivatedTools(),
+
+		defaultToolCallTimeout: DefaultToolCallTimeout,
+
 		metrics: metrics,
+
+		logger: l,
 	}
+
+	// Ensure provided server pointers reference initialized instances
+	// Reinitialize in place to preserve pointer identity expected by tests
+	*mcpProxyServer = *server.NewMCPServer(
+		"mcpjungle-proxy", "MCPJungle proxy server",
+		s.ProxyServerOptions(ProxyEndpointGlobal, types.TransportStreamableHTTP, s.LazyToolLoading)...,
+	)
+	*sseMcpProxyServer = *server.NewMCPServer(
+		"mcpjungle-proxy-sse", "MCPJungle SSE proxy server",
+		s.ProxyServerOptions(ProxyEndpointGlobal, types.TransportSSE, s.LazyToolLoading)...,
+	)
+
 	if err := s.initMCPProxyServer(); err != nil {
 		return nil, fmt.Errorf("failed to initialize MCP proxy server: %w", err)
 	}
+	if err := metrics.ObserveInventory(s.countInventory); err != nil {
+		return nil, fmt.Errorf("failed to observe the inventory of MCP servers and tools: %w", err)
+	}
 	return s, nil
 }
 
+// discardLogger is used by upstream sessions whose context doesn't carry a logger.
+// MCPService attaches a logger to the context of every call to an upstream server,
+// so this only applies to sessions created outside the service, eg- in tests.
+var discardLogger = logger.NewNop()
+
+// contextLogger returns the logger carried by ctx, eg- the logger of the API request being served,
+// falling back to the service's logger.
+func (m *MCPService) contextLogger(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, m.logger)
+}
+
+// withLogFields returns a copy of ctx whose logger carries the given fields in addition to its existing ones.
+func (m *MCPService) withLogFields(ctx context.Context, fields ...logger.Field) context.Context {
+	return logger.NewContext(ctx, m.contextLogger(ctx).WithFields(fields...))
+}
+
+// sessionLogger returns the logger to use for a session with an upstream server.
+func sessionLogger(ctx context.Context) logger.Logger {
+	return logger.FromContext(ctx, discardLogger)
+}
+
 // GetSearchService returns the search service instance
 func (m *MCPService) GetSearchService() *search.SearchService {
 	return m.searchService
 }
+
+// GetRateLimitService returns the rate limit service instance
+func (m *MCPService) GetRateLimitService() *ratelimit.RateLimitService {
+	return m.rateLimitService
+}
+
+// GetUsageService returns the usage analytics service instance
+func (m *MCPService) GetUsageService() *usage.UsageService {
+	return m.usageService
+}
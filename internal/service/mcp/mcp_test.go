@@ -3,9 +3,11 @@ package mcp
 import (
 	"testing"
 
+	"context"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"gorm.io/gorm"
 )
@@ -37,7 +39,7 @@ func TestNewMCPService(t *testing.T) {
 				db = tt.db
 			}
 
-			mcpService, err := NewMCPService(db, tt.mcpProxyServer, tt.mcpProxyServer, telemetry.NewNoopCustomMetrics())
+			mcpService, err := NewMCPService(db, tt.mcpProxyServer, tt.mcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 
 			if tt.expectError {
 				testhelpers.AssertError(t, err)
@@ -67,7 +69,7 @@ func TestMCPServiceInitialization(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(setup.DB, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(setup.DB, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, mcpService)
 
@@ -99,7 +101,7 @@ func TestMCPServiceCallbacks(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
 	// Test that callbacks are initialized to NOOP functions
@@ -127,7 +129,7 @@ func TestMCPServiceConcurrency(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
 	// Test that the service can handle concurrent access to toolInstances
@@ -163,7 +165,7 @@ func TestMCPServiceToolInstances(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 
     // Test that toolInstances map is properly initialized
@@ -199,7 +201,7 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 
 	proxyServer := &server.MCPServer{}
 
-	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, proxyServer, proxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, mcpService)
 
@@ -210,3 +212,32 @@ func TestMCPServiceErrorHandling(t *testing.T) {
 		t.Error("Expected toolInstances to be initialized")
 	}
 }
+
+func TestCountInventory(t *testing.T) {
+	db := setupTestDBWithPrompts(t)
+	m := &MCPService{db: db}
+
+	srv := createTestServer(t, db)
+	other, err := model.NewStdioServer("other-server", "", "echo", nil, nil)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, db.Create(other).Error)
+
+	for _, tool := range []*model.Tool{
+		{Name: "a", ServerID: srv.ID},
+		{Name: "b", ServerID: srv.ID},
+		{Name: "c", ServerID: other.ID},
+	} {
+		testhelpers.AssertNoError(t, db.Create(tool).Error)
+	}
+	// the zero value of Enabled is replaced by the column default on create, so disable the tool afterwards
+	testhelpers.AssertNoError(t, db.Model(&model.Tool{}).Where("name = ?", "c").Update("enabled", false).Error)
+
+	counts, err := m.countInventory(context.Background())
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, telemetry.InventoryCounts{
+		RegisteredServers: 2,
+		EnabledServers:    1,
+		RegisteredTools:   3,
+		EnabledTools:      2,
+	}, counts)
+}
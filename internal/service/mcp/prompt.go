@@ -4,11 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -90,6 +90,7 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 	if !ok {
 		return nil, fmt.Errorf("invalid input: prompt name does not contain a %s separator", serverPromptNameSep)
 	}
+	ctx = m.withLogFields(ctx, logger.String("server", serverName), logger.String("prompt", promptName))
 
 	serverModel, err := m.GetMcpServer(serverName)
 	if err != nil {
@@ -100,11 +101,11 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, serverModel)
+	release, err := m.acquireServerSlot(ctx, serverModel)
 	if err != nil {
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer release()
 
 	getPromptReq := mcp.GetPromptRequest{}
 	getPromptReq.Params.Name = promptName
@@ -123,7 +124,11 @@ func (m *MCPService) GetPromptWithArgs(ctx context.Context, name string, args ma
 	}
 	getPromptReq.Params.Arguments = stringArgs
 
-	getPromptResp, err := mcpClient.GetPrompt(ctx, getPromptReq)
+	var getPromptResp *mcp.GetPromptResult
+	err = m.withUpstreamSession(ctx, serverModel, true, func(ctx context.Context, c *client.Client) (err error) {
+		getPromptResp, err = c.GetPrompt(ctx, getPromptReq)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get prompt %s from MCP server %s: %w", promptName, serverName, err)
 	}
@@ -307,7 +312,9 @@ func (m *MCPService) registerServerPrompts(ctx context.Context, s *model.McpServ
 		if err := m.db.Create(p).Error; err != nil {
 			// If registration of a prompt fails, we should not fail the entire server registration.
 			// Instead, continue with the next prompt.
-			log.Printf("[ERROR] failed to register prompt %s in DB: %v", canonicalPromptName, err)
+			m.contextLogger(ctx).Error(
+				"failed to register prompt in DB", logger.String("prompt", canonicalPromptName), logger.ErrorField(err),
+			)
 		} else {
 			// Set prompt name to include the server name prefix to make it recognizable by MCPJungle
 			// then add the prompt to the MCP proxy server
@@ -420,6 +427,6 @@ func (m *MCPService) notifyPromptDeletion(promptNames ...string) {
 func (m *MCPService) notifyPromptAddition(promptName string) {
 	if err := m.promptAdditionCallback(promptName); err != nil {
 		// log the issue, but do not fail the entire operation
-		log.Printf("[ERROR] prompt addition callback failed for prompt %s: %v", promptName, err)
+		m.logger.Error("prompt addition callback failed", logger.String("prompt", promptName), logger.ErrorField(err))
 	}
 }
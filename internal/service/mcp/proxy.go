@@ -5,67 +5,66 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MCPProxyToolCallHandler handles tool calls for the MCP proxy server
 // by forwarding the request to the appropriate upstream MCP server and
 // relaying the response back.
-func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (m *MCPService) MCPProxyToolCallHandler(
+	ctx context.Context, request mcp.CallToolRequest,
+) (_ *mcp.CallToolResult, err error) {
 	started := time.Now()
 	outcome := telemetry.ToolCallOutcomeSuccess
 
-	name := request.Params.Name
+	// tools exposed under an alias are called and recorded under their canonical names
+	name, err := resolveToolAlias(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := startSpan(ctx, string(mcp.MethodToolsCall)+" "+name, trace.SpanKindServer,
+		attrMCPMethod.String(string(mcp.MethodToolsCall)),
+		attrMCPTool.String(name),
+	)
+	defer func() { endSpan(span, err) }()
+
 	serverName, toolName, ok := splitServerToolName(name)
 	if !ok {
 		return nil, fmt.Errorf("invalid input: tool name does not contain a %s separator", serverToolNameSep)
 	}
+	span.SetAttributes(attrMCPServer.String(serverName))
+	ctx = m.withLogFields(ctx, logger.String("server", serverName), logger.String("tool", toolName))
 
-	serverMode := ctx.Value("mode").(model.ServerMode)
-	if model.IsEnterpriseMode(serverMode) {
-		// In enterprise mode, we need to check whether the MCP client is authorized to access the tool.
-		// First check tool-level ACL (via tool groups), then fall back to server-level ACL.
-		c := ctx.Value("client").(*model.McpClient)
-		
-		// Get the tool group checker if available from context
-		var checker model.ToolGroupToolChecker
-		var resolver model.ToolGroupResolver
-		if tgChecker := ctx.Value("toolGroupChecker"); tgChecker != nil {
-			checker = tgChecker.(model.ToolGroupToolChecker)
-			resolver = tgChecker.(model.ToolGroupResolver)
-		}
-		
-		// Check tool access (uses tool groups if available, otherwise server-level ACL)
-		if checker != nil && resolver != nil {
-			hasAccess, err := c.CheckHasToolAccess(name, checker, resolver)
-			if err != nil {
-				return nil, fmt.Errorf(
-					"failed to check tool access for client %s: %w", c.Name, err,
-				)
-			}
-			if !hasAccess {
-				return nil, fmt.Errorf(
-					"client %s is not authorized to access tool %s", c.Name, name,
-				)
-			}
-		} else {
-			// Fallback to server-level check if tool group service is not available
-			if !c.CheckHasServerAccess(serverName) {
-				return nil, fmt.Errorf(
-					"client %s is not authorized to access MCP server %s", c.Name, serverName,
-				)
-			}
-		}
+	// In enterprise mode, we need to check whether the MCP client is authorized to access the tool.
+	_, aclSpan := startSpan(ctx, "authorize tool access", trace.SpanKindInternal, attrMCPTool.String(name))
+	err = authorizeToolCall(ctx, request.Params.Name, name)
+	endSpan(aclSpan, err)
+	if err != nil {
+		m.metrics.RecordAuthFailure(ctx, telemetry.AuthFailureAccessDenied)
+		return nil, err
 	}
 
 	// Record the tool call metrics at the end of the function
 	defer func() {
-		m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
+		m.recordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
 	}()
 
+	d, err := m.applyRateLimits(ctx, serverName, name)
+	if err != nil {
+		outcome = telemetry.ToolCallOutcomeError
+		return nil, err
+	}
+	if d != nil {
+		outcome = telemetry.ToolCallOutcomeRateLimited
+		return rateLimitedToolResult(d), nil
+	}
+
 	// get the MCP server details from the database
 	server, err := m.GetMcpServer(serverName)
 	if err != nil {
@@ -78,19 +77,36 @@ func (m *MCPService) MCPProxyToolCallHandler(ctx context.Context, request mcp.Ca
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, server)
+	release, err := m.acquireServerSlot(ctx, server)
+	if err != nil {
+		outcome = telemetry.ToolCallOutcomeError
+		return nil, err
+	}
+	defer release()
+
+	d, err = m.chargeQuota(ctx, name)
 	if err != nil {
 		outcome = telemetry.ToolCallOutcomeError
 		return nil, err
 	}
-	defer mcpClient.Close()
+	if d != nil {
+		outcome = telemetry.ToolCallOutcomeRateLimited
+		return rateLimitedToolResult(d), nil
+	}
 
 	// Ensure the tool name is set correctly, ie, without the server name prefix
 	request.Params.Name = toolName
 
-	res, err := mcpClient.CallTool(ctx, request)
+	timeout := server.ResolveToolCallTimeout(toolName, m.defaultToolCallTimeout)
+	var res *mcp.CallToolResult
+	err = m.withUpstreamSession(ctx, server, m.isIdempotentTool(server, toolName),
+		func(ctx context.Context, c *client.Client) (err error) {
+			res, err = m.callToolWithTimeout(ctx, c, serverName, request, timeout)
+			return err
+		},
+	)
 	if err != nil {
-		outcome = telemetry.ToolCallOutcomeError
+		outcome = toolCallErrorOutcome(err)
 	}
 
 	// forward the request to the upstream MCP server and relay the response back
@@ -109,17 +125,13 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 	if !ok {
 		return nil, fmt.Errorf("invalid input: prompt name does not contain a %s separator", serverPromptNameSep)
 	}
+	ctx = m.withLogFields(ctx, logger.String("server", serverName), logger.String("prompt", promptName))
 
-	serverMode := ctx.Value("mode").(model.ServerMode)
-	if serverMode == model.ModeProd {
-		// In production mode, we need to check whether the MCP client is authorized to access the MCP server.
-		// If not, return error Unauthorized.
-		c := ctx.Value("client").(*model.McpClient)
-		if !c.CheckHasServerAccess(serverName) {
-			return nil, fmt.Errorf(
-				"client %s is not authorized to access MCP server %s", c.Name, serverName,
-			)
-		}
+	// In enterprise mode, we need to check whether the MCP client is authorized to access the prompt.
+	// Just like tools, this takes the client's tool groups into account.
+	if err := authorizePromptAccess(ctx, name); err != nil {
+		m.metrics.RecordAuthFailure(ctx, telemetry.AuthFailureAccessDenied)
+		return nil, err
 	}
 
 	// Record the prompt call metrics at the end of the function
@@ -139,18 +151,23 @@ func (m *MCPService) mcpProxyPromptHandler(ctx context.Context, request mcp.GetP
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, server)
+	release, err := m.acquireServerSlot(ctx, server)
 	if err != nil {
 		outcome = telemetry.PromptCallOutcomeError
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer release()
 
 	// Ensure the prompt name is set correctly, ie, without the server name prefix
 	request.Params.Name = promptName
 
 	// forward the request to the upstream MCP server and relay the response back
-	res, err := mcpClient.GetPrompt(ctx, request)
+	// getting a prompt has no side effects, so it is always safe to retry
+	var res *mcp.GetPromptResult
+	err = m.withUpstreamSession(ctx, server, true, func(ctx context.Context, c *client.Client) (err error) {
+		res, err = c.GetPrompt(ctx, request)
+		return err
+	})
 	if err != nil {
 		outcome = telemetry.PromptCallOutcomeError
 	}
@@ -165,6 +182,11 @@ func (m *MCPService) initMCPProxyServer() error {
 	if err := m.initSearchMetaTool(); err != nil {
 		return fmt.Errorf("failed to initialize search meta-tool: %w", err)
 	}
+	if err := m.initSearchPromptsMetaTool(); err != nil {
+		return fmt.Errorf("failed to initialize prompt search meta-tool: %w", err)
+	}
+	m.initCallToolMetaTools()
+	m.initActivateToolsMetaTool()
 
 	mcpServerModelsCache := make(map[string]*model.McpServer)
 
@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/ratelimit"
+)
+
+// applyRateLimits checks a tool call against the rate limits and quotas of the calling MCP client,
+// the upstream MCP server and the tool.
+// It returns a nil decision if the call is allowed. Rejections are recorded in the metrics.
+func (m *MCPService) applyRateLimits(ctx context.Context, serverName, name string) (*ratelimit.Decision, error) {
+	d, err := m.rateLimitService.Allow(rateLimitedClientName(ctx), serverName, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rate limits for tool %s: %w", name, err)
+	}
+	if d.Allowed {
+		return nil, nil
+	}
+	m.metrics.RecordRateLimitRejection(ctx, string(d.Scope), d.Target)
+	return d, nil
+}
+
+// chargeQuota counts a tool call against the quotas of the calling MCP client.
+// It must be called right before the call is dispatched to the upstream server,
+// so that calls which fail earlier (eg- because the server doesn't exist) don't use up the client's quota.
+// It returns a nil decision if the call is allowed.
+func (m *MCPService) chargeQuota(ctx context.Context, name string) (*ratelimit.Decision, error) {
+	d, err := m.rateLimitService.ChargeQuota(rateLimitedClientName(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quotas for tool %s: %w", name, err)
+	}
+	if d.Allowed {
+		return nil, nil
+	}
+	m.metrics.RecordRateLimitRejection(ctx, string(d.Scope), d.Target)
+	return d, nil
+}
+
+// rateLimitedClientName returns the name of the MCP client making the call, or an empty string if there is none.
+func rateLimitedClientName(ctx context.Context) string {
+	if c, ok := ctx.Value("client").(*model.McpClient); ok && c != nil {
+		return c.Name
+	}
+	return ""
+}
+
+// rateLimitedToolResult creates the MCP error result returned to a client whose tool call was rate limited.
+// Besides the human-readable message, the retry time is included in the result's metadata
+// so that clients can back off programmatically.
+func rateLimitedToolResult(d *ratelimit.Decision) *mcp.CallToolResult {
+	retryAfter := int64(math.Ceil(d.RetryAfter.Seconds()))
+	retryAt := time.Now().Add(d.RetryAfter).UTC().Format(time.RFC3339)
+
+	res := mcp.NewToolResultErrorf("%s, retry after %d seconds (at %s)", d.Reason, retryAfter, retryAt)
+	res.Meta = &mcp.Meta{
+		AdditionalFields: map[string]any{
+			"retryAfterSeconds": retryAfter,
+			"retryAt":           retryAt,
+		},
+	}
+	return res
+}
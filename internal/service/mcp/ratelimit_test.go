@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestMCPProxyToolCallHandlerRateLimited(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	err = svc.GetRateLimitService().SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeClient, Target: "agent", RequestsPerMinute: 1, Burst: 1,
+	})
+	testhelpers.AssertNoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	ctx = context.WithValue(ctx, "client", &model.McpClient{Name: "agent"})
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "github__search_code"
+
+	// the first call is allowed, but fails because the server isn't registered
+	_, err = svc.MCPProxyToolCallHandler(ctx, req)
+	testhelpers.AssertError(t, err)
+
+	res, err := svc.MCPProxyToolCallHandler(ctx, req)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, res.IsError, "expected an error result for a rate limited call")
+	testhelpers.AssertStringContains(t, res.Content[0].(mcp.TextContent).Text, "retry after")
+	testhelpers.AssertEqual(t, int64(60), res.Meta.AdditionalFields["retryAfterSeconds"])
+}
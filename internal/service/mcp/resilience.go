@@ -0,0 +1,273 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+const (
+	// defaultCircuitBreakerThreshold is the number of consecutive failures after which
+	// the circuit breaker of a server opens, unless configured otherwise.
+	defaultCircuitBreakerThreshold = 5
+	// defaultCircuitBreakerCooldown is how long an open circuit breaker fails calls fast, unless configured otherwise.
+	defaultCircuitBreakerCooldown = 30 * time.Second
+
+	// defaultRetryInitialBackoff is the wait time before the first retry if the retry policy doesn't specify one.
+	defaultRetryInitialBackoff = 200 * time.Millisecond
+	// defaultRetryMaxBackoff caps the wait time between retries if the retry policy doesn't specify it.
+	defaultRetryMaxBackoff = 5 * time.Second
+)
+
+// ErrCircuitOpen is returned when a call is failed fast because the upstream server's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerState is the state of the circuit breaker of an upstream MCP server.
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed means that calls are forwarded to the server as usual.
+	CircuitBreakerClosed CircuitBreakerState = "closed"
+	// CircuitBreakerOpen means that calls to the server fail fast without being forwarded.
+	CircuitBreakerOpen CircuitBreakerState = "open"
+	// CircuitBreakerHalfOpen means that a single trial call is let through to check whether the server recovered.
+	CircuitBreakerHalfOpen CircuitBreakerState = "half_open"
+)
+
+// connectError wraps an error that occurred while opening a session with an upstream MCP server.
+type connectError struct {
+	err error
+}
+
+func (e *connectError) Error() string { return e.err.Error() }
+
+func (e *connectError) Unwrap() error { return e.err }
+
+// isUpstreamFailure returns true if the error means that the upstream server is unavailable, ie,
+// mcpjungle couldn't connect to it, the transport failed or the server didn't respond in time.
+// Only these errors count as failures for the circuit breaker. Errors that the server returned,
+// eg- a JSON-RPC invalid params error, show that it is up and responding.
+func isUpstreamFailure(err error) bool {
+	var connErr *connectError
+	var transportErr *transport.Error
+	return errors.As(err, &connErr) || errors.As(err, &transportErr) || errors.Is(err, ErrToolCallTimeout)
+}
+
+// circuitBreaker tracks consecutive failures of calls to an upstream MCP server.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	state    CircuitBreakerState
+	failures int
+	openedAt time.Time
+	// probing is true while the trial call of a half-open breaker is in flight
+	probing bool
+}
+
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{state: CircuitBreakerClosed}
+}
+
+// allow returns an error if calls to the server must currently fail fast.
+// It returns the new state if the breaker changed state.
+func (b *circuitBreaker) allow(now time.Time, cooldown time.Duration) (CircuitBreakerState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitBreakerOpen:
+		if now.Sub(b.openedAt) < cooldown {
+			return "", ErrCircuitOpen
+		}
+		b.state = CircuitBreakerHalfOpen
+		b.probing = true
+		return CircuitBreakerHalfOpen, nil
+	case CircuitBreakerHalfOpen:
+		if b.probing {
+			return "", ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return "", nil
+}
+
+// record updates the breaker with the result of a call that was allowed.
+// It returns the new state if the breaker changed state.
+func (b *circuitBreaker) record(success bool, now time.Time, threshold int) CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if success {
+		b.failures = 0
+		if b.state != CircuitBreakerClosed {
+			b.state = CircuitBreakerClosed
+			return CircuitBreakerClosed
+		}
+		return ""
+	}
+
+	b.failures++
+	if b.state == CircuitBreakerHalfOpen || (b.state == CircuitBreakerClosed && b.failures >= threshold) {
+		b.state = CircuitBreakerOpen
+		b.openedAt = now
+		return CircuitBreakerOpen
+	}
+	return ""
+}
+
+// abandon releases a call that was allowed without recording a result.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) currentState() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// GetCircuitBreakerState returns the state of the circuit breaker of the given upstream MCP server.
+func (m *MCPService) GetCircuitBreakerState(serverName string) CircuitBreakerState {
+	m.breakersMu.Lock()
+	b, ok := m.breakers[serverName]
+	m.breakersMu.Unlock()
+	if !ok {
+		return CircuitBreakerClosed
+	}
+	return b.currentState()
+}
+
+func (m *MCPService) getCircuitBreaker(serverName string) *circuitBreaker {
+	m.breakersMu.Lock()
+	defer m.breakersMu.Unlock()
+	b, ok := m.breakers[serverName]
+	if !ok {
+		b = newCircuitBreaker()
+		m.breakers[serverName] = b
+	}
+	return b
+}
+
+// removeCircuitBreaker drops the circuit breaker of a server, eg- when the server is deregistered.
+func (m *MCPService) removeCircuitBreaker(serverName string) {
+	m.breakersMu.Lock()
+	defer m.breakersMu.Unlock()
+	delete(m.breakers, serverName)
+}
+
+// withUpstreamSession opens a session with the upstream MCP server and runs the given call with it.
+// It applies the server's circuit breaker and retry policy:
+//   - if the breaker is open, the call fails fast with ErrCircuitOpen
+//   - failures to connect to or initialize the server are retried with exponential backoff
+//   - upstream failures of the call itself, eg- a dropped connection, are only retried if retryCall is true,
+//     ie, the call is idempotent. Errors returned by the server, eg- invalid params, are never retried.
+//
+// Only errors that mean the server is unavailable count as breaker failures, see isUpstreamFailure.
+// Every attempt uses a new session.
+func (m *MCPService) withUpstreamSession(
+	ctx context.Context, s *model.McpServer, retryCall bool, call func(ctx context.Context, c *client.Client) error,
+) error {
+	threshold := s.CircuitBreakerThreshold
+	if threshold <= 0 {
+		threshold = defaultCircuitBreakerThreshold
+	}
+	cooldown := time.Duration(s.CircuitBreakerCooldownSeconds) * time.Second
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+
+	b := m.getCircuitBreaker(s.Name)
+	state, err := b.allow(time.Now(), cooldown)
+	if err != nil {
+		return fmt.Errorf("MCP server %s is unavailable after repeated failures: %w", s.Name, err)
+	}
+	if state != "" {
+		m.metrics.RecordCircuitBreakerStateChange(ctx, s.Name, string(state))
+	}
+
+	policy, err := s.GetRetryPolicy()
+	if err != nil {
+		return fmt.Errorf("invalid retry policy for MCP server %s: %w", s.Name, err)
+	}
+	maxAttempts := 1
+	backoff, maxBackoff := defaultRetryInitialBackoff, defaultRetryMaxBackoff
+	if policy != nil {
+		maxAttempts = policy.MaxAttempts
+		if policy.InitialBackoffMs > 0 {
+			backoff = time.Duration(policy.InitialBackoffMs) * time.Millisecond
+		}
+		if policy.MaxBackoffMs > 0 {
+			maxBackoff = time.Duration(policy.MaxBackoffMs) * time.Millisecond
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		retryable, err = m.runUpstreamAttempt(ctx, s, retryCall, call)
+		if err == nil || !retryable || attempt >= maxAttempts || ctx.Err() != nil {
+			break
+		}
+
+		m.metrics.RecordUpstreamRetry(ctx, s.Name)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+
+	if err != nil && ctx.Err() != nil {
+		// calls abandoned by the caller say nothing about the health of the server
+		b.abandon()
+		return err
+	}
+	if state := b.record(!isUpstreamFailure(err), time.Now(), threshold); state != "" {
+		m.metrics.RecordCircuitBreakerStateChange(ctx, s.Name, string(state))
+	}
+	return err
+}
+
+// runUpstreamAttempt makes a single attempt of a call to an upstream server.
+// If the attempt failed, it also returns whether it can be retried.
+func (m *MCPService) runUpstreamAttempt(
+	ctx context.Context, s *model.McpServer, retryCall bool, call func(ctx context.Context, c *client.Client) error,
+) (bool, error) {
+	c, err := m.openMcpServerSession(ctx, s)
+	if err != nil {
+		// the server was never reached or didn't complete the handshake, so it is always safe to retry
+		return true, &connectError{err: err}
+	}
+	defer c.Close()
+
+	if err := call(ctx, c); err != nil {
+		// an error returned by the server would be returned again, only retry if the server wasn't reachable
+		return retryCall && isUpstreamFailure(err), withStderrOutput(c, err)
+	}
+	return false, nil
+}
+
+// isIdempotentTool returns true if retrying a failed call of the tool is safe.
+// A tool is considered idempotent if the server's retry policy lists it or if the upstream server
+// annotated it as idempotent.
+func (m *MCPService) isIdempotentTool(s *model.McpServer, toolName string) bool {
+	if policy, err := s.GetRetryPolicy(); err == nil && policy != nil {
+		if slices.Contains(policy.IdempotentTools, toolName) {
+			return true
+		}
+	}
+	if t, ok := m.GetToolInstance(mergeServerToolNames(s.Name, toolName)); ok {
+		if hint := t.Annotations.IdempotentHint; hint != nil && *hint {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// retryCountingMetrics counts the upstream retries recorded by the MCP service.
+type retryCountingMetrics struct {
+	telemetry.NoopCustomMetrics
+	retries atomic.Int64
+}
+
+func (r *retryCountingMetrics) RecordUpstreamRetry(context.Context, string) {
+	r.retries.Add(1)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker()
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		_, err := b.allow(now, time.Minute)
+		testhelpers.AssertNoError(t, err)
+		b.record(false, now, 3)
+	}
+	testhelpers.AssertEqual(t, CircuitBreakerClosed, b.currentState())
+
+	_, err := b.allow(now, time.Minute)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, CircuitBreakerOpen, b.record(false, now, 3))
+
+	_, err = b.allow(now.Add(30*time.Second), time.Minute)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrCircuitOpen), "expected open breaker to fail fast")
+
+	// after the cooldown, a single trial call is let through
+	state, err := b.allow(now.Add(time.Minute), time.Minute)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, CircuitBreakerHalfOpen, state)
+	_, err = b.allow(now.Add(time.Minute), time.Minute)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrCircuitOpen), "expected only one trial call")
+
+	testhelpers.AssertEqual(t, CircuitBreakerClosed, b.record(true, now.Add(time.Minute), 3))
+}
+
+func TestWithUpstreamSessionRetriesAndBreaks(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	metrics := &retryCountingMetrics{}
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, metrics, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	// nothing listens on this port, so every connection attempt fails
+	s, err := model.NewStreamableHTTPServer("flaky", "", "http://127.0.0.1:1/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, s.SetRetryPolicy(&types.RetryPolicy{MaxAttempts: 3, InitialBackoffMs: 1}))
+	testhelpers.AssertNoError(t, s.SetCircuitBreaker(2, 60))
+
+	calls := 0
+	call := func(context.Context, *client.Client) error {
+		calls++
+		return nil
+	}
+
+	for i := 0; i < 2; i++ {
+		err = svc.withUpstreamSession(context.Background(), s, false, call)
+		testhelpers.AssertError(t, err)
+	}
+	testhelpers.AssertEqual(t, int64(4), metrics.retries.Load())
+	testhelpers.AssertEqual(t, 0, calls)
+	testhelpers.AssertEqual(t, CircuitBreakerOpen, svc.GetCircuitBreakerState("flaky"))
+
+	err = svc.withUpstreamSession(context.Background(), s, false, call)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrCircuitOpen), "expected the call to fail fast")
+	testhelpers.AssertEqual(t, int64(4), metrics.retries.Load())
+}
+
+func TestWithUpstreamSessionOnlyRetriesUpstreamFailures(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(server.NewMCPServer("upstream", "0.1")))
+	defer ts.Close()
+
+	metrics := &retryCountingMetrics{}
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, metrics, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	s, err := model.NewStreamableHTTPServer("github", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, s.SetRetryPolicy(&types.RetryPolicy{MaxAttempts: 3, InitialBackoffMs: 1}))
+
+	// the upstream server has no tools, so it answers the call with a JSON-RPC error
+	calls := 0
+	err = svc.withUpstreamSession(context.Background(), s, true, func(ctx context.Context, c *client.Client) error {
+		calls++
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "missing"
+		_, err := c.CallTool(ctx, req)
+		return err
+	})
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertEqual(t, 1, calls)
+	testhelpers.AssertEqual(t, int64(0), metrics.retries.Load())
+
+	// transport failures of idempotent calls are retried
+	calls = 0
+	err = svc.withUpstreamSession(context.Background(), s, true, func(context.Context, *client.Client) error {
+		calls++
+		return transport.NewError(errors.New("connection reset"))
+	})
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertEqual(t, 3, calls)
+	testhelpers.AssertEqual(t, int64(2), metrics.retries.Load())
+}
+
+func TestWithUpstreamSessionOnlyBreaksOnUpstreamFailures(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(server.NewMCPServer("upstream", "0.1")))
+	defer ts.Close()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	s, err := model.NewStreamableHTTPServer("github", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, s.SetCircuitBreaker(2, 60))
+
+	// errors returned by a responsive server don't open the breaker
+	for i := 0; i < 3; i++ {
+		err = svc.withUpstreamSession(context.Background(), s, false, func(context.Context, *client.Client) error {
+			return fmt.Errorf("%w: missing argument", mcp.ErrInvalidParams)
+		})
+		testhelpers.AssertTrue(t, errors.Is(err, mcp.ErrInvalidParams), "expected the call error to be returned")
+	}
+	testhelpers.AssertEqual(t, CircuitBreakerClosed, svc.GetCircuitBreakerState("github"))
+
+	// transport failures and timeouts do
+	failures := []error{transport.NewError(errors.New("connection reset")), ErrToolCallTimeout}
+	for _, failure := range failures {
+		err = svc.withUpstreamSession(context.Background(), s, false, func(context.Context, *client.Client) error {
+			return failure
+		})
+		testhelpers.AssertError(t, err)
+	}
+	testhelpers.AssertEqual(t, CircuitBreakerOpen, svc.GetCircuitBreakerState("github"))
+}
@@ -6,16 +6,45 @@ import (
 	"fmt"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/service/search"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 const (
 	// SearchMetaToolName is the canonical name for the search meta-tool
 	SearchMetaToolName = "mcpjungle__search_tools"
+
+	// SearchPromptsMetaToolName is the canonical name for the prompt search meta-tool
+	SearchPromptsMetaToolName = "mcpjungle__search_prompts"
 )
 
 // initSearchMetaTool creates and registers the search meta-tool in the MCP proxy server
 func (m *MCPService) initSearchMetaTool() error {
+	searchTool := searchMetaTool()
+
+	// Register the tool with both proxy servers
+	m.mcpProxyServer.AddTool(searchTool, m.searchMetaToolHandler)
+	m.sseMcpProxyServer.AddTool(searchTool, m.searchMetaToolHandler)
+
+	// Add to tool instances tracker
+	m.addToolInstance(searchTool)
+
+	return nil
+}
+
+// AddMetaTools registers the meta-tools in a tool group's MCP proxy server.
+// When called through a group's proxy server, the meta-tools only find, describe, call and activate
+// the tools of that group.
+func (m *MCPService) AddMetaTools(s *server.MCPServer) {
+	s.AddTool(searchMetaTool(), m.searchMetaToolHandler)
+	s.AddTool(describeToolMetaTool(), m.describeToolMetaToolHandler)
+	s.AddTool(callToolMetaTool(), m.callToolMetaToolHandler)
+	s.AddTool(activateToolsMetaTool(), m.activateToolsMetaToolHandler)
+}
+
+// searchMetaTool returns the definition of the search meta-tool.
+func searchMetaTool() mcp.Tool {
 	// Create the search tool schema
 	inputSchema := mcp.ToolInputSchema{
 		Type: "object",
@@ -41,25 +70,23 @@ func (m *MCPService) initSearchMetaTool() error {
 				"type":        "boolean",
 				"description": "If true, only return enabled tools (default: false)",
 			},
+			"mode": map[string]interface{}{
+				"type": "string",
+				"description": "'keyword' matches the words of the query against tool names and descriptions. " +
+					"'semantic' ranks tools by how similar their meaning is to the query, which helps when you " +
+					"don't know the exact words a tool uses (default: keyword)",
+				"enum": []string{string(search.SearchModeKeyword), string(search.SearchModeSemantic)},
+			},
 		},
 		Required: []string{"query"},
 	}
 
-	// Create the MCP tool object
-	searchTool := mcp.Tool{
-		Name:        SearchMetaToolName,
-		Description: "Search for tools across all registered MCP servers in MCPJungle. Returns matching tools with their descriptions and metadata.",
+	return mcp.Tool{
+		Name: SearchMetaToolName,
+		Description: "Search for tools across all registered MCP servers in MCPJungle. " +
+			"Returns matching tools with their descriptions and metadata. Only tools that you can call are returned.",
 		InputSchema: inputSchema,
 	}
-
-	// Register the tool with both proxy servers
-	m.mcpProxyServer.AddTool(searchTool, m.searchMetaToolHandler)
-	m.sseMcpProxyServer.AddTool(searchTool, m.searchMetaToolHandler)
-
-	// Add to tool instances tracker
-	m.addToolInstance(searchTool)
-
-	return nil
 }
 
 // searchMetaToolHandler handles calls to the search meta-tool
@@ -86,8 +113,18 @@ func (m *MCPService) searchMetaToolHandler(ctx context.Context, request mcp.Call
 	// Extract only_enabled (optional)
 	opts.OnlyEnabled = request.GetBool("only_enabled", false)
 
+	// Extract mode (optional)
+	mode, err := search.ValidateSearchMode(request.GetString("mode", ""))
+	if err != nil {
+		return nil, err
+	}
+	opts.Mode = mode
+
+	// Only return tools that the caller can actually call
+	opts.Allow = toolSearchScope(ctx)
+
 	// Perform the search
-	results, err := m.searchService.SearchTools(opts)
+	results, err := m.searchService.Search(ctx, opts)
 	if err != nil {
 		return &mcp.CallToolResult{
 			IsError: true,
@@ -141,3 +178,114 @@ func (m *MCPService) searchMetaToolHandler(ctx context.Context, request mcp.Call
 		},
 	}, nil
 }
+
+// initSearchPromptsMetaTool creates and registers the prompt search meta-tool in the MCP proxy server.
+// It lets agents discover the prompt templates offered by the registered MCP servers.
+func (m *MCPService) initSearchPromptsMetaTool() error {
+	searchPromptsTool := mcp.Tool{
+		Name: SearchPromptsMetaToolName,
+		Description: "Search for prompt templates across all registered MCP servers in MCPJungle. " +
+			"Returns matching prompts with their descriptions. Use the returned names to get a prompt.",
+		InputSchema: mcp.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]interface{}{
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "Search query to find prompts. Can be keywords from prompt names, descriptions or arguments.",
+				},
+				"max_results": map[string]interface{}{
+					"type":        "integer",
+					"description": "Maximum number of results to return (default: 20)",
+					"minimum":     1,
+					"maximum":     100,
+				},
+				"only_enabled": map[string]interface{}{
+					"type":        "boolean",
+					"description": "If true, only return enabled prompts (default: false)",
+				},
+			},
+			Required: []string{"query"},
+		},
+	}
+
+	m.mcpProxyServer.AddTool(searchPromptsTool, m.searchPromptsMetaToolHandler)
+	m.sseMcpProxyServer.AddTool(searchPromptsTool, m.searchPromptsMetaToolHandler)
+
+	m.addToolInstance(searchPromptsTool)
+
+	return nil
+}
+
+// searchPromptsMetaToolHandler handles calls to the prompt search meta-tool
+func (m *MCPService) searchPromptsMetaToolHandler(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, err := request.RequireString("query")
+	if err != nil {
+		return nil, fmt.Errorf("'query' parameter is required: %w", err)
+	}
+	if query == "" {
+		return nil, fmt.Errorf("'query' parameter must be a non-empty string")
+	}
+
+	opts := search.UnifiedSearchOptions{
+		Query:       query,
+		MaxResults:  request.GetInt("max_results", 20),
+		Kinds:       []types.SearchKind{types.SearchKindPrompt},
+		OnlyEnabled: request.GetBool("only_enabled", false),
+	}
+	if server.ServerFromContext(ctx) != nil {
+		// disabled prompts are not served by the MCP proxy, so there's no point in returning them
+		opts.OnlyEnabled = true
+	}
+	opts.Allow = promptSearchScope(ctx)
+
+	results, err := m.searchService.SearchAll(opts)
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("Search failed: %v", err)),
+			},
+		}, nil
+	}
+
+	if len(results) == 0 {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("No prompts found matching query: %s", query)),
+			},
+		}, nil
+	}
+
+	resultsJSON, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return &mcp.CallToolResult{
+			IsError: true,
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("Failed to format results: %v", err)),
+			},
+		}, nil
+	}
+
+	summaryText := fmt.Sprintf("Found %d prompts matching '%s':\n\n", len(results), query)
+	for i, result := range results {
+		status := "enabled"
+		if !result.Enabled {
+			status = "disabled"
+		}
+		summaryText += fmt.Sprintf("%d. %s (%s) - %s\n   Score: %.2f, Status: %s\n\n",
+			i+1, result.Name, result.ServerName, result.Description, result.Score, status)
+
+		// Limit summary to first 10 results for readability
+		if i >= 9 && len(results) > 10 {
+			summaryText += fmt.Sprintf("... and %d more results\n", len(results)-10)
+			break
+		}
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(summaryText),
+			mcp.NewTextContent(fmt.Sprintf("Full results (JSON):\n%s", string(resultsJSON))),
+		},
+	}, nil
+}
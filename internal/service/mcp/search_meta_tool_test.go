@@ -9,6 +9,7 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,7 +23,7 @@ func setupTestDBForSearch(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 
 	// Migrate the schema
-	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.AuditLog{})
+	err = db.AutoMigrate(&model.McpServer{}, &model.Tool{}, &model.Prompt{}, &model.AuditLog{}, &model.ToolEmbedding{})
 	require.NoError(t, err)
 
 	return db
@@ -36,7 +37,7 @@ func TestSearchMetaTool(t *testing.T) {
 	sseMcpProxyServer := server.NewMCPServer("test-sse-server", "1.0.0")
 	
 	// Create MCP service
-	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	require.NoError(t, err)
 
 	// Create test servers and tools
@@ -159,6 +160,38 @@ func TestSearchMetaTool(t *testing.T) {
 		assert.Contains(t, textContent.Text, "No tools found")
 	})
 
+	t.Run("Semantic search", func(t *testing.T) {
+		// the tools were created directly in the DB, so they haven't been embedded yet
+		require.NoError(t, mcpService.GetSearchService().SyncEmbeddings(ctx))
+
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchMetaToolName
+		request.Params.Arguments = map[string]any{
+			"query": "switch branch",
+			"mode":  "semantic",
+		}
+
+		result, err := mcpService.searchMetaToolHandler(ctx, request)
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		textContent, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok, "Expected TextContent")
+		assert.Contains(t, textContent.Text, "1. git__branch")
+	})
+
+	t.Run("Invalid search mode", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchMetaToolName
+		request.Params.Arguments = map[string]any{
+			"query": "git",
+			"mode":  "fuzzy",
+		}
+
+		_, err := mcpService.searchMetaToolHandler(ctx, request)
+		assert.Error(t, err)
+	})
+
 	t.Run("Missing query parameter", func(t *testing.T) {
 		request := mcp.CallToolRequest{}
 		request.Params.Name = SearchMetaToolName
@@ -206,7 +239,7 @@ func TestInitSearchMetaTool(t *testing.T) {
 	sseMcpProxyServer := server.NewMCPServer("test-sse-server", "1.0.0")
 	
 	// Create MCP service
-	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics())
+	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
 	require.NoError(t, err)
 
 	// Check that the search meta-tool was added
@@ -215,3 +248,153 @@ func TestInitSearchMetaTool(t *testing.T) {
 	assert.Equal(t, SearchMetaToolName, tool.GetName())
 	assert.Contains(t, tool.Description, "Search for tools")
 }
+
+func TestSearchPromptsMetaTool(t *testing.T) {
+	db := setupTestDBForSearch(t)
+
+	mcpProxyServer := server.NewMCPServer("test-server", "1.0.0")
+	sseMcpProxyServer := server.NewMCPServer("test-sse-server", "1.0.0")
+	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	require.NoError(t, err)
+
+	tool, exists := mcpService.GetToolInstance(SearchPromptsMetaToolName)
+	require.True(t, exists)
+	assert.Contains(t, tool.Description, "Search for prompt templates")
+
+	config, _ := json.Marshal(model.StdioConfig{Command: "git-mcp"})
+	gitServer := &model.McpServer{Name: "git", Transport: types.TransportStdio, Config: datatypes.JSON(config)}
+	require.NoError(t, db.Create(gitServer).Error)
+	require.NoError(t, db.Create(&model.Prompt{
+		ServerID: gitServer.ID, Name: "write_commit_message", Description: "Write a commit message for staged changes",
+	}).Error)
+	require.NoError(t, db.Create(&model.Tool{ServerID: gitServer.ID, Name: "commit", Description: "Create a commit"}).Error)
+
+	ctx := context.Background()
+
+	t.Run("Finds prompts only", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchPromptsMetaToolName
+		request.Params.Arguments = map[string]any{"query": "commit"}
+
+		result, err := mcpService.searchPromptsMetaToolHandler(ctx, request)
+		require.NoError(t, err)
+		assert.False(t, result.IsError)
+
+		textContent, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok, "Expected TextContent")
+		assert.Contains(t, textContent.Text, "Found 1 prompts")
+		assert.Contains(t, textContent.Text, "1. git__write_commit_message (git)")
+	})
+
+	t.Run("Only finds prompts the client can access", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchPromptsMetaToolName
+		request.Params.Arguments = map[string]any{"query": "commit"}
+
+		ctx := enterpriseContext(&model.McpClient{Name: "agent"}, &fakeAccessChecker{})
+		result, err := mcpService.searchPromptsMetaToolHandler(ctx, request)
+		require.NoError(t, err)
+
+		textContent, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok, "Expected TextContent")
+		assert.Contains(t, textContent.Text, "No prompts found")
+	})
+
+	t.Run("No matching prompts", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchPromptsMetaToolName
+		request.Params.Arguments = map[string]any{"query": "kubernetes"}
+
+		result, err := mcpService.searchPromptsMetaToolHandler(ctx, request)
+		require.NoError(t, err)
+
+		textContent, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok, "Expected TextContent")
+		assert.Contains(t, textContent.Text, "No prompts found")
+	})
+
+	t.Run("Missing query parameter", func(t *testing.T) {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = SearchPromptsMetaToolName
+		request.Params.Arguments = map[string]any{}
+
+		_, err := mcpService.searchPromptsMetaToolHandler(ctx, request)
+		assert.Error(t, err)
+	})
+}
+
+// callSearchMetaTool calls the search meta-tool through the given MCP server and returns the text of its summary.
+func callSearchMetaTool(t *testing.T, ctx context.Context, s *server.MCPServer, query string) string {
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params": map[string]any{
+			"name":      SearchMetaToolName,
+			"arguments": map[string]any{"query": query},
+		},
+	})
+	resp, ok := s.HandleMessage(ctx, msg).(mcp.JSONRPCResponse)
+	require.True(t, ok, "Expected a JSON-RPC response")
+	result, ok := resp.Result.(mcp.CallToolResult)
+	require.True(t, ok, "Expected a CallToolResult")
+	textContent, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok, "Expected TextContent")
+	return textContent.Text
+}
+
+func TestSearchMetaToolIsScopedToCaller(t *testing.T) {
+	db := setupTestDBForSearch(t)
+	mcpProxyServer := server.NewMCPServer("test-server", "1.0.0")
+	sseMcpProxyServer := server.NewMCPServer("test-sse-server", "1.0.0")
+	mcpService, err := NewMCPService(db, mcpProxyServer, sseMcpProxyServer, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	require.NoError(t, err)
+
+	config, _ := json.Marshal(model.StdioConfig{Command: "mcp"})
+	tools := map[string][]string{
+		"github": {"create_issue", "delete_issue"},
+		"slack":  {"post_issue_update"},
+	}
+	for serverName, toolNames := range tools {
+		s := &model.McpServer{Name: serverName, Transport: types.TransportStdio, Config: datatypes.JSON(config)}
+		require.NoError(t, db.Create(s).Error)
+		for _, name := range toolNames {
+			require.NoError(t, db.Create(&model.Tool{ServerID: s.ID, Name: name, Description: "Manage an issue"}).Error)
+			mcpProxyServer.AddTool(mcp.Tool{Name: serverName + "__" + name}, mcpService.MCPProxyToolCallHandler)
+		}
+	}
+	// a disabled tool is in the database, but not served by the proxy
+	github := &model.McpServer{}
+	require.NoError(t, db.Where("name = ?", "github").First(github).Error)
+	require.NoError(t, db.Create(&model.Tool{ServerID: github.ID, Name: "close_issue", Description: "Close an issue"}).Error)
+
+	t.Run("development mode finds all tools served by the proxy", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+		text := callSearchMetaTool(t, ctx, mcpProxyServer, "issue")
+		assert.Contains(t, text, "Found 3 tools")
+		assert.NotContains(t, text, "github__close_issue")
+	})
+
+	t.Run("enterprise mode only finds tools the client can access", func(t *testing.T) {
+		c := &model.McpClient{Name: "agent", AllowList: []byte(`["slack"]`)}
+		text := callSearchMetaTool(t, enterpriseContext(c, nil), mcpProxyServer, "issue")
+		assert.Contains(t, text, "Found 1 tools")
+		assert.Contains(t, text, "slack__post_issue_update")
+
+		checker := &fakeAccessChecker{tools: map[string]bool{"github__create_issue": true}}
+		text = callSearchMetaTool(t, enterpriseContext(&model.McpClient{Name: "agent"}, checker), mcpProxyServer, "issue")
+		assert.Contains(t, text, "Found 1 tools")
+		assert.Contains(t, text, "github__create_issue")
+	})
+
+	t.Run("a tool group's proxy only finds the tools of the group", func(t *testing.T) {
+		groupServer := server.NewMCPServer("group-server", "1.0.0")
+		mcpService.AddMetaTools(groupServer)
+		groupServer.AddTool(mcp.Tool{Name: "github__delete_issue"}, mcpService.MCPProxyToolCallHandler)
+
+		ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+		text := callSearchMetaTool(t, ctx, groupServer, "issue")
+		assert.Contains(t, text, "Found 1 tools")
+		assert.Contains(t, text, "github__delete_issue")
+	})
+}
@@ -3,9 +3,11 @@ package mcp
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
 // RegisterMcpServer registers a new MCP server in the database.
@@ -16,8 +18,12 @@ func (m *MCPService) RegisterMcpServer(ctx context.Context, s *model.McpServer)
 	if err := validateServerName(s.Name); err != nil {
 		return err
 	}
+	if m.requireStdioSandbox && s.Transport == types.TransportStdio && !s.IsSandboxed() {
+		return ErrStdioSandboxRequired
+	}
+	ctx = m.withLogFields(ctx, logger.String("server", s.Name))
 
-	mcpClient, err := newMcpServerSession(ctx, s)
+	mcpClient, err := m.openMcpServerSession(ctx, s)
 	if err != nil {
 		return err
 	}
@@ -34,7 +40,7 @@ func (m *MCPService) RegisterMcpServer(ctx context.Context, s *model.McpServer)
 
 	// Register prompts (best-effort, don't fail server registration)
 	if err = m.registerServerPrompts(ctx, s, mcpClient); err != nil {
-		log.Printf("[WARN] failed to register prompts for MCP server %s: %v", s.Name, err)
+		m.contextLogger(ctx).Warn("failed to register prompts for MCP server", logger.ErrorField(err))
 	}
 
 	// Log successful server registration
@@ -72,6 +78,14 @@ func (m *MCPService) DeregisterMcpServer(name string) error {
 	if err := m.db.Unscoped().Delete(s).Error; err != nil {
 		return fmt.Errorf("failed to deregister server %s: %w", name, err)
 	}
+	if err := m.rateLimitService.DeleteServerRateLimits(name); err != nil {
+		m.logger.Warn("failed to delete rate limits of deregistered server",
+			logger.String("server", name), logger.ErrorField(err))
+	}
+	m.removeServerLimiter(name)
+	m.removeCircuitBreaker(name)
+	m.removeServerHealth(name)
+	removeStdioLogBuffer(name)
 
 	// Log server deregistration
 	m.auditService.LogDelete(context.Background(), model.AuditEntityMcpServer, name, name)
@@ -146,3 +160,24 @@ func (m *MCPService) DisableMcpServer(name string) ([]string, []string, error) {
 
 	return toolsDisabled, promptsDisabled, nil
 }
+
+// countInventory returns the number of registered and enabled MCP servers and tools.
+// A server is considered enabled if at least one of its tools is enabled.
+func (m *MCPService) countInventory(ctx context.Context) (telemetry.InventoryCounts, error) {
+	var counts telemetry.InventoryCounts
+	db := m.db.WithContext(ctx)
+	if err := db.Model(&model.McpServer{}).Count(&counts.RegisteredServers).Error; err != nil {
+		return counts, fmt.Errorf("failed to count MCP servers: %w", err)
+	}
+	if err := db.Model(&model.Tool{}).Count(&counts.RegisteredTools).Error; err != nil {
+		return counts, fmt.Errorf("failed to count tools: %w", err)
+	}
+	if err := db.Model(&model.Tool{}).Where("enabled = ?", true).Count(&counts.EnabledTools).Error; err != nil {
+		return counts, fmt.Errorf("failed to count enabled tools: %w", err)
+	}
+	err := db.Model(&model.Tool{}).Where("enabled = ?", true).Distinct("server_id").Count(&counts.EnabledServers).Error
+	if err != nil {
+		return counts, fmt.Errorf("failed to count enabled MCP servers: %w", err)
+	}
+	return counts, nil
+}
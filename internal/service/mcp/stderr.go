@@ -0,0 +1,258 @@
+package mcp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+const (
+	// stdioLogBufferLines is the number of recent stderr lines kept in memory per stdio server.
+	stdioLogBufferLines = 1000
+
+	// stdioLogSubscriberBuffer is the number of lines buffered for a follower of a server's logs.
+	// Lines are dropped for followers that can't keep up.
+	stdioLogSubscriberBuffer = 100
+
+	// stderrDrainTimeout is how long mcpjungle waits for a failing stdio server to finish writing to stderr
+	// before appending its output to an error.
+	stderrDrainTimeout = 200 * time.Millisecond
+
+	// maxStderrLineLength is the maximum length of a stderr line kept in logs and errors.
+	// The rest of longer lines is discarded, so that a server can't make mcpjungle buffer unbounded output.
+	maxStderrLineLength = 8 * 1024
+)
+
+// errStdioProcessExited is the cause of cancelling the initialization of a stdio server whose process exited.
+var errStdioProcessExited = errors.New("stdio server process exited")
+
+// ErrServerLogsUnsupported is returned when requesting the logs of a server that doesn't use the stdio transport.
+var ErrServerLogsUnsupported = errors.New("logs are only available for stdio MCP servers")
+
+// stdioLogs holds the stderr log buffers of stdio servers, keyed by server name.
+// The buffers outlive the processes, since a new process is started for every session with a server.
+var stdioLogs = struct {
+	sync.Mutex
+	m map[string]*stdioLogBuffer
+}{m: make(map[string]*stdioLogBuffer)}
+
+// stdioLogBuffer is a ring buffer of the most recent stderr lines of a stdio server.
+// It also delivers new lines to the followers of the server's logs.
+type stdioLogBuffer struct {
+	mu sync.Mutex
+
+	entries []types.ServerLogLine
+	// seq is the number of lines written to the buffer so far
+	seq uint64
+
+	subscribers map[chan types.ServerLogLine]struct{}
+}
+
+// getStdioLogBuffer returns the log buffer of the given server, creating it if it doesn't exist.
+func getStdioLogBuffer(name string) *stdioLogBuffer {
+	stdioLogs.Lock()
+	defer stdioLogs.Unlock()
+
+	b, ok := stdioLogs.m[name]
+	if !ok {
+		b = &stdioLogBuffer{subscribers: make(map[chan types.ServerLogLine]struct{})}
+		stdioLogs.m[name] = b
+	}
+	return b
+}
+
+// removeStdioLogBuffer drops the log buffer of a server, eg- when the server is deregistered.
+// Followers of the server's logs are disconnected.
+func removeStdioLogBuffer(name string) {
+	stdioLogs.Lock()
+	b, ok := stdioLogs.m[name]
+	delete(stdioLogs.m, name)
+	stdioLogs.Unlock()
+
+	if !ok {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		close(ch)
+		delete(b.subscribers, ch)
+	}
+}
+
+// append adds a line to the buffer, evicting the oldest line if the buffer is full.
+func (b *stdioLogBuffer) append(text string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	l := types.ServerLogLine{Time: time.Now(), Line: text}
+	if len(b.entries) < stdioLogBufferLines {
+		b.entries = append(b.entries, l)
+	} else {
+		b.entries[(b.seq-1)%stdioLogBufferLines] = l
+	}
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- l:
+		default:
+		}
+	}
+}
+
+// tail returns up to n of the most recent lines, oldest first. If n is 0, all lines are returned.
+func (b *stdioLogBuffer) tail(n int) []types.ServerLogLine {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tailLocked(n)
+}
+
+func (b *stdioLogBuffer) tailLocked(n int) []types.ServerLogLine {
+	// walk the ring backwards from the newest line
+	var lines []types.ServerLogLine
+	for i := 0; i < len(b.entries) && (n <= 0 || len(lines) < n); i++ {
+		lines = append(lines, b.entries[(b.seq-1-uint64(i))%uint64(len(b.entries))])
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
+
+// subscribe returns the recent lines of the buffer and a channel delivering the lines written from now on.
+// The channel is closed when the buffer is removed. The returned function stops the subscription.
+func (b *stdioLogBuffer) subscribe(n int) ([]types.ServerLogLine, <-chan types.ServerLogLine, func()) {
+	ch := make(chan types.ServerLogLine, stdioLogSubscriberBuffer)
+
+	// no line may be written between taking the recent lines and subscribing
+	b.mu.Lock()
+	recent := b.tailLocked(n)
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[ch]; ok {
+				delete(b.subscribers, ch)
+				close(ch)
+			}
+		})
+	}
+	return recent, ch, cancel
+}
+
+// captureStdioServerStderr captures the stderr output of a stdio MCP server in the background.
+// Every line is written to mcpjungle server logs and to the server's log buffer.
+// This is useful for troubleshooting and visibility into the stdio server's behaviour.
+func captureStdioServerStderr(t *supervisedStdio) {
+	go func() {
+		defer close(t.stderrDone)
+
+		r := bufio.NewReaderSize(t.Stderr(), maxStderrLineLength)
+		for {
+			line, err := readStderrLine(r)
+			if line != "" {
+				t.logger.Info("MCP server stderr", logger.String("line", line))
+				t.logs.append(line)
+				t.recordStderrLine(line)
+			}
+			if err != nil {
+				t.logger.Debug("stopped reading stderr of MCP server", logger.ErrorField(err))
+				return
+			}
+		}
+	}()
+}
+
+// readStderrLine reads the next line written to a server's stderr, without the line ending.
+// Lines longer than the reader's buffer are truncated, the rest of the line is read and discarded.
+func readStderrLine(r *bufio.Reader) (string, error) {
+	line, isPrefix, err := r.ReadLine()
+	if !isPrefix {
+		return string(line), err
+	}
+	// the slice returned by ReadLine is overwritten by the next read
+	truncated := string(line) + " [truncated]"
+	for isPrefix && err == nil {
+		_, isPrefix, err = r.ReadLine()
+	}
+	return truncated, err
+}
+
+// withStderrOutput appends the lines a stdio server wrote to stderr during the session to the given error,
+// if the server is configured to do so.
+func withStderrOutput(c *client.Client, err error) error {
+	t, ok := c.GetTransport().(*supervisedStdio)
+	if !ok || t.errorLogLines <= 0 {
+		return err
+	}
+
+	// give the server a moment to finish writing, eg- if it is crashing
+	select {
+	case <-t.stderrDone:
+	case <-time.After(stderrDrainTimeout):
+	}
+
+	t.stderrTailMu.Lock()
+	lines := strings.Join(t.stderrTail, "\n")
+	t.stderrTailMu.Unlock()
+	if lines == "" {
+		return err
+	}
+	return fmt.Errorf("%w\nlast stderr output of MCP server %s:\n%s", err, t.name, lines)
+}
+
+// recordStderrLine keeps the line if it is among the last lines of the session to append to errors.
+func (t *supervisedStdio) recordStderrLine(line string) {
+	if t.errorLogLines <= 0 {
+		return
+	}
+	t.stderrTailMu.Lock()
+	defer t.stderrTailMu.Unlock()
+	t.stderrTail = append(t.stderrTail, line)
+	if len(t.stderrTail) > t.errorLogLines {
+		t.stderrTail = t.stderrTail[len(t.stderrTail)-t.errorLogLines:]
+	}
+}
+
+// GetServerLogs returns up to n of the most recent lines the given stdio server wrote to stderr, oldest first.
+// If n is 0, all lines kept in memory are returned.
+func (m *MCPService) GetServerLogs(name string, n int) ([]types.ServerLogLine, error) {
+	if err := m.checkServerLogsAvailable(name); err != nil {
+		return nil, err
+	}
+	return getStdioLogBuffer(name).tail(n), nil
+}
+
+// FollowServerLogs returns up to n of the most recent stderr lines of the given stdio server and a channel
+// delivering new lines as the server writes them.
+// The caller must call the returned function once it stops following the logs.
+func (m *MCPService) FollowServerLogs(name string, n int) ([]types.ServerLogLine, <-chan types.ServerLogLine, func(), error) {
+	if err := m.checkServerLogsAvailable(name); err != nil {
+		return nil, nil, nil, err
+	}
+	recent, ch, cancel := getStdioLogBuffer(name).subscribe(n)
+	return recent, ch, cancel, nil
+}
+
+func (m *MCPService) checkServerLogsAvailable(name string) error {
+	s, err := m.GetMcpServer(name)
+	if err != nil {
+		return err
+	}
+	if s.Transport != types.TransportStdio {
+		return ErrServerLogsUnsupported
+	}
+	return nil
+}
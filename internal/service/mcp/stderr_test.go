@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestStdioLogBuffer(t *testing.T) {
+	b := getStdioLogBuffer("test-log-buffer")
+	defer removeStdioLogBuffer("test-log-buffer")
+
+	testhelpers.AssertEqual(t, 0, len(b.tail(10)))
+
+	for i := 1; i <= stdioLogBufferLines+5; i++ {
+		b.append(fmt.Sprintf("line %d", i))
+	}
+
+	all := b.tail(0)
+	testhelpers.AssertEqual(t, stdioLogBufferLines, len(all))
+	testhelpers.AssertEqual(t, "line 6", all[0].Line)
+	testhelpers.AssertEqual(t, fmt.Sprintf("line %d", stdioLogBufferLines+5), all[len(all)-1].Line)
+
+	last := b.tail(2)
+	testhelpers.AssertEqual(t, 2, len(last))
+	testhelpers.AssertEqual(t, fmt.Sprintf("line %d", stdioLogBufferLines+4), last[0].Line)
+}
+
+func TestReadStderrLineTruncatesLongLines(t *testing.T) {
+	long := strings.Repeat("a", 3*maxStderrLineLength)
+	r := bufio.NewReaderSize(strings.NewReader(long+"\nnext\r\nlast"), maxStderrLineLength)
+
+	line, err := readStderrLine(r)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, long[:maxStderrLineLength]+" [truncated]", line)
+
+	line, err = readStderrLine(r)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "next", line)
+
+	line, err = readStderrLine(r)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "last", line)
+
+	_, err = readStderrLine(r)
+	testhelpers.AssertEqual(t, io.EOF, err)
+}
+
+func TestStdioLogBufferSubscribe(t *testing.T) {
+	b := getStdioLogBuffer("test-log-follow")
+	b.append("before")
+
+	recent, ch, cancel := b.subscribe(10)
+	defer cancel()
+	testhelpers.AssertEqual(t, 1, len(recent))
+	testhelpers.AssertEqual(t, "before", recent[0].Line)
+
+	b.append("after")
+	select {
+	case l := <-ch:
+		testhelpers.AssertEqual(t, "after", l.Line)
+	case <-time.After(time.Second):
+		t.Fatal("expected the new line to be delivered")
+	}
+
+	// followers are disconnected when the server is deregistered
+	removeStdioLogBuffer("test-log-follow")
+	_, ok := <-ch
+	testhelpers.AssertFalse(t, ok, "expected the channel to be closed")
+}
+
+func TestStdioInitErrorIncludesStderr(t *testing.T) {
+	s, err := model.NewStdioServer("crashing", "", "sh", []string{"-c", "echo 'starting' >&2; echo 'missing API_KEY' >&2; exit 1"}, nil)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, s.SetStdioSupervision(types.StdioSupervision{ErrorLogLines: 1}))
+	defer removeStdioLogBuffer("crashing")
+
+	_, err = runStdioServer(context.Background(), s)
+	testhelpers.AssertError(t, err)
+	testhelpers.AssertStringContains(t, err.Error(), "missing API_KEY")
+	testhelpers.AssertStringNotContains(t, err.Error(), "starting")
+
+	// both lines are kept in the server's logs
+	lines := getStdioLogBuffer("crashing").tail(0)
+	testhelpers.AssertEqual(t, 2, len(lines))
+}
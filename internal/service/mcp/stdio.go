@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/mcpjungle/mcpjungle/internal/sandbox"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// defaultStdioKillTimeout is how long the process of a stdio server may take to exit after its session
+// is closed, unless the server configures kill_timeout_seconds.
+const defaultStdioKillTimeout = 10 * time.Second
+
+// baseStdioEnv lists the environment variables of mcpjungle that are passed on to every stdio server.
+// Most servers need these to locate executables, home & temp directories and the locale.
+var baseStdioEnv = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "LANG", "LC_ALL", "LC_CTYPE", "TZ", "TMPDIR",
+	// required on windows
+	"SYSTEMROOT", "SYSTEMDRIVE", "COMSPEC", "PATHEXT", "USERPROFILE", "APPDATA", "LOCALAPPDATA", "TEMP", "TMP",
+}
+
+// ErrStdioSandboxRequired is returned when registering a stdio server that doesn't run in a sandbox
+// while mcpjungle requires all stdio servers to be sandboxed.
+var ErrStdioSandboxRequired = errors.New("stdio servers must run in a sandbox, set sandbox.enabled to true")
+
+// stdioProcesses keeps track of the running processes of stdio servers, keyed by PID,
+// so that they can be killed when mcpjungle shuts down.
+var stdioProcesses = struct {
+	sync.Mutex
+	m map[int]*os.Process
+}{m: make(map[int]*os.Process)}
+
+// supervisedStdio is a stdio transport that kills the server process if it doesn't exit in time
+// after the session is closed.
+type supervisedStdio struct {
+	*transport.Stdio
+
+	name        string
+	process     *os.Process
+	killTimeout time.Duration
+	logger      logger.Logger
+
+	// logs is the stderr log buffer of the server, shared by all its sessions
+	logs *stdioLogBuffer
+	// errorLogLines is the number of stderr lines appended to errors of this session,
+	// the most recent of which are kept in stderrTail.
+	errorLogLines int
+	stderrTail    []string
+	stderrTailMu  sync.Mutex
+	// stderrDone is closed once the process's stderr has been read to the end
+	stderrDone chan struct{}
+}
+
+// Close closes the session with the stdio server and waits for its process to exit.
+// If the process is still running after the kill timeout, it is killed along with any child processes it spawned.
+func (t *supervisedStdio) Close() error {
+	timer := time.AfterFunc(t.killTimeout, func() {
+		t.logger.Warn("process of MCP server did not exit in time, killing it",
+			logger.String("kill_timeout", t.killTimeout.String()))
+		killProcessTree(t.process)
+	})
+	defer timer.Stop()
+
+	err := t.Stdio.Close()
+
+	// reap any child processes that outlived the server process
+	killProcessTree(t.process)
+	untrackStdioProcess(t.process)
+
+	return err
+}
+
+// SetRequireStdioSandbox sets whether stdio servers may only be registered if their process runs in a sandbox.
+// Stdio servers that were registered without a sandbox before the policy was turned on keep working,
+// a warning is logged for each of them.
+func (m *MCPService) SetRequireStdioSandbox(required bool) {
+	m.requireStdioSandbox = required
+	if !required {
+		return
+	}
+
+	servers, err := m.ListMcpServers()
+	if err != nil {
+		m.logger.Error("failed to list MCP servers", logger.ErrorField(err))
+		return
+	}
+	for i := range servers {
+		if servers[i].Transport == types.TransportStdio && !servers[i].IsSandboxed() {
+			m.logger.Warn("stdio MCP server was registered without a sandbox", logger.String("server", servers[i].Name))
+		}
+	}
+}
+
+// startStdioServerProcess launches the process of a stdio MCP server under supervision and returns a client
+// connected to it. The session is not initialized yet.
+// The given logger is used to log the output and the supervision of the process.
+func startStdioServerProcess(name string, conf *model.StdioConfig, l logger.Logger) (*client.Client, error) {
+	env := buildStdioEnv(conf, os.Environ())
+
+	var cmd *exec.Cmd
+	cmdFunc := func(ctx context.Context, command string, env []string, args []string) (*exec.Cmd, error) {
+		cmd = exec.Command(command, args...)
+		cmd.Env = env
+		cmd.Dir = conf.WorkDir
+		if err := configureStdioProcess(cmd, conf); err != nil {
+			return nil, err
+		}
+		// the limits are set by a helper process right before it executes the server's command,
+		// since Go can't set them between fork and exec
+		if conf.Sandbox.IsEnabled() {
+			if err := sandbox.Wrap(cmd, conf.Sandbox, conf.ResourceLimits); err != nil {
+				return nil, fmt.Errorf("failed to sandbox the process: %w", err)
+			}
+		} else if err := rlimit.Wrap(cmd, conf.ResourceLimits); err != nil {
+			return nil, fmt.Errorf("failed to apply resource limits: %w", err)
+		}
+		return cmd, nil
+	}
+
+	stdio := transport.NewStdioWithOptions(conf.Command, env, conf.Args, transport.WithCommandFunc(cmdFunc))
+	if err := stdio.Start(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to start stdio transport: %w", err)
+	}
+	trackStdioProcess(cmd.Process)
+
+	killTimeout := time.Duration(conf.KillTimeoutSeconds) * time.Second
+	if killTimeout <= 0 {
+		killTimeout = defaultStdioKillTimeout
+	}
+	t := &supervisedStdio{
+		Stdio:         stdio,
+		name:          name,
+		process:       cmd.Process,
+		killTimeout:   killTimeout,
+		logger:        l,
+		logs:          getStdioLogBuffer(name),
+		errorLogLines: conf.ErrorLogLines,
+		stderrDone:    make(chan struct{}),
+	}
+	captureStdioServerStderr(t)
+
+	return client.NewClient(t), nil
+}
+
+// buildStdioEnv returns the environment of a stdio server's process.
+// It only contains the allowed variables of mcpjungle's own environment, overridden by the server's configured env.
+func buildStdioEnv(conf *model.StdioConfig, environ []string) []string {
+	vars := make(map[string]string)
+	for _, kv := range environ {
+		k, v, ok := strings.Cut(kv, "=")
+		if ok && isStdioEnvAllowed(k, conf.EnvAllowList) {
+			vars[k] = v
+		}
+	}
+	for k, v := range conf.Env {
+		vars[k] = v
+	}
+
+	env := make([]string, 0, len(vars))
+	for k, v := range vars {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func isStdioEnvAllowed(name string, allowList []string) bool {
+	for _, allowed := range baseStdioEnv {
+		if strings.EqualFold(name, allowed) {
+			return true
+		}
+	}
+	for _, allowed := range allowList {
+		if prefix, ok := strings.CutSuffix(allowed, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// setStdioEnv sets a variable in the environment of a process unless it is already set by the server's env.
+func setStdioEnv(cmd *exec.Cmd, conf *model.StdioConfig, name, value string) {
+	if _, ok := conf.Env[name]; ok {
+		return
+	}
+	for i, kv := range cmd.Env {
+		if strings.HasPrefix(kv, name+"=") {
+			cmd.Env[i] = name + "=" + value
+			return
+		}
+	}
+	cmd.Env = append(cmd.Env, name+"="+value)
+}
+
+func trackStdioProcess(p *os.Process) {
+	stdioProcesses.Lock()
+	defer stdioProcesses.Unlock()
+	stdioProcesses.m[p.Pid] = p
+}
+
+func untrackStdioProcess(p *os.Process) {
+	stdioProcesses.Lock()
+	defer stdioProcesses.Unlock()
+	delete(stdioProcesses.m, p.Pid)
+}
+
+// KillStdioProcesses kills the processes of all stdio MCP servers that are still running,
+// along with their child processes. It returns the number of processes killed.
+// It should be called when mcpjungle shuts down so that no orphaned processes are left behind.
+func KillStdioProcesses() int {
+	stdioProcesses.Lock()
+	defer stdioProcesses.Unlock()
+
+	n := len(stdioProcesses.m)
+	for pid, p := range stdioProcesses.m {
+		killProcessTree(p)
+		delete(stdioProcesses.m, pid)
+	}
+	return n
+}
@@ -0,0 +1,11 @@
+package mcp
+
+import (
+	"syscall"
+)
+
+// setParentDeathSignal makes the kernel kill the process of a stdio server if mcpjungle dies
+// without getting a chance to clean up, eg- because it was killed with SIGKILL.
+func setParentDeathSignal(attr *syscall.SysProcAttr) {
+	attr.Pdeathsig = syscall.SIGKILL
+}
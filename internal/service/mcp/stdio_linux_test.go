@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/rlimit"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// TestMain lets the test binary act as the rlimit helper command,
+// since stdio servers with resource limits are started by re-executing the current executable.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == rlimit.HelperCommand {
+		if err := rlimit.Exec(os.Args[2:]); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestStdioResourceLimits(t *testing.T) {
+	conf := &model.StdioConfig{
+		Command: "sleep",
+		Args:    []string{"30"},
+		StdioSupervision: types.StdioSupervision{
+			ResourceLimits:     &types.StdioResourceLimits{CPUSeconds: 60, OpenFiles: 64},
+			KillTimeoutSeconds: 1,
+		},
+	}
+	c, err := startStdioServerProcess("limited", conf, discardLogger)
+	testhelpers.AssertNoError(t, err)
+	defer c.Close()
+
+	// the process starts as the rlimit helper, wait until it has executed the server's command
+	pid := c.GetTransport().(*supervisedStdio).process.Pid
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		cmdline, err := os.ReadFile(fmt.Sprintf("/proc/%d/cmdline", pid))
+		testhelpers.AssertNoError(t, err)
+		program, _, _ := strings.Cut(string(cmdline), "\x00")
+		if strings.HasSuffix(program, "sleep") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("process did not execute the server's command, its command line is %q", cmdline)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	limits, err := os.ReadFile(fmt.Sprintf("/proc/%d/limits", pid))
+	testhelpers.AssertNoError(t, err)
+
+	testhelpers.AssertTrue(t, regexp.MustCompile(`Max cpu time\s+60\s+60`).Match(limits), string(limits))
+	testhelpers.AssertTrue(t, regexp.MustCompile(`Max open files\s+64\s+64`).Match(limits), string(limits))
+}
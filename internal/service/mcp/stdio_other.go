@@ -0,0 +1,10 @@
+//go:build !linux
+
+package mcp
+
+import (
+	"syscall"
+)
+
+// setParentDeathSignal is a no-op because parent death signals are only supported on linux.
+func setParentDeathSignal(attr *syscall.SysProcAttr) {}
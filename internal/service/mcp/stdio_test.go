@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func TestBuildStdioEnv(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"HOME=/home/mcpjungle",
+		"DATABASE_URL=postgres://secret",
+		"AWS_REGION=eu-west-1",
+		"AWS_PROFILE=dev",
+		"GITHUB_TOKEN=ghp_gateway",
+	}
+	conf := &model.StdioConfig{
+		Command:          "npx",
+		Env:              map[string]string{"GITHUB_TOKEN": "ghp_server"},
+		StdioSupervision: types.StdioSupervision{EnvAllowList: []string{"AWS_*"}},
+	}
+
+	env := buildStdioEnv(conf, environ)
+
+	expected := []string{
+		"AWS_PROFILE=dev",
+		"AWS_REGION=eu-west-1",
+		"GITHUB_TOKEN=ghp_server",
+		"HOME=/home/mcpjungle",
+		"PATH=/usr/bin",
+	}
+	testhelpers.AssertTrue(t, slices.Equal(expected, env), "unexpected env: "+testhelpers.FormatSliceError(expected, env))
+}
+
+func TestRequireStdioSandbox(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	svc.SetRequireStdioSandbox(true)
+
+	s, err := model.NewStdioServer("unsandboxed", "", "npx", []string{"-y", "server-everything"}, nil)
+	testhelpers.AssertNoError(t, err)
+
+	err = svc.RegisterMcpServer(context.Background(), s)
+	testhelpers.AssertTrue(t, errors.Is(err, ErrStdioSandboxRequired), "expected the registration to be rejected")
+}
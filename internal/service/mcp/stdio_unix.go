@@ -0,0 +1,87 @@
+//go:build !windows
+
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// configureStdioProcess makes the process of a stdio server the leader of a new process group,
+// so that it can be killed along with its children, and switches it to the configured user and group.
+func configureStdioProcess(cmd *exec.Cmd, conf *model.StdioConfig) error {
+	attr := &syscall.SysProcAttr{Setpgid: true}
+	setParentDeathSignal(attr)
+
+	if conf.RunAsUser != "" || conf.RunAsGroup != "" {
+		cred := &syscall.Credential{Uid: uint32(os.Getuid()), Gid: uint32(os.Getgid())}
+
+		if conf.RunAsUser != "" {
+			u, err := lookupUser(conf.RunAsUser)
+			if err != nil {
+				return err
+			}
+			uid, _ := strconv.ParseUint(u.Uid, 10, 32)
+			gid, _ := strconv.ParseUint(u.Gid, 10, 32)
+			cred.Uid, cred.Gid = uint32(uid), uint32(gid)
+
+			if u.HomeDir != "" {
+				setStdioEnv(cmd, conf, "HOME", u.HomeDir)
+			}
+			if u.Username != "" {
+				setStdioEnv(cmd, conf, "USER", u.Username)
+				setStdioEnv(cmd, conf, "LOGNAME", u.Username)
+			}
+		}
+		if conf.RunAsGroup != "" {
+			gid, err := lookupGroupID(conf.RunAsGroup)
+			if err != nil {
+				return err
+			}
+			cred.Gid = gid
+		}
+		attr.Credential = cred
+	}
+
+	cmd.SysProcAttr = attr
+	return nil
+}
+
+// lookupUser finds a user by name or numeric ID.
+// A numeric ID is accepted even if the user doesn't exist in the user database.
+func lookupUser(nameOrID string) (*user.User, error) {
+	if u, err := user.Lookup(nameOrID); err == nil {
+		return u, nil
+	}
+	if _, err := strconv.ParseUint(nameOrID, 10, 32); err != nil {
+		return nil, fmt.Errorf("user %s not found", nameOrID)
+	}
+	if u, err := user.LookupId(nameOrID); err == nil {
+		return u, nil
+	}
+	return &user.User{Uid: nameOrID, Gid: nameOrID}, nil
+}
+
+// lookupGroupID finds the ID of a group by name or numeric ID.
+func lookupGroupID(nameOrID string) (uint32, error) {
+	if g, err := user.LookupGroup(nameOrID); err == nil {
+		gid, err := strconv.ParseUint(g.Gid, 10, 32)
+		return uint32(gid), err
+	}
+	gid, err := strconv.ParseUint(nameOrID, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("group %s not found", nameOrID)
+	}
+	return uint32(gid), nil
+}
+
+// killProcessTree kills the process group led by the given process.
+func killProcessTree(p *os.Process) {
+	_ = syscall.Kill(-p.Pid, syscall.SIGKILL)
+}
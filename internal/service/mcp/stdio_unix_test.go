@@ -0,0 +1,68 @@
+//go:build !windows
+
+package mcp
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// processExists returns true if a process with the given PID is running.
+func processExists(pid int) bool {
+	return !errors.Is(syscall.Kill(pid, 0), syscall.ESRCH)
+}
+
+func TestSupervisedStdioKillTimeout(t *testing.T) {
+	// the server ignores both its stdin being closed and SIGTERM
+	conf := &model.StdioConfig{
+		Command:          "sh",
+		Args:             []string{"-c", "trap '' TERM; sleep 30"},
+		StdioSupervision: types.StdioSupervision{KillTimeoutSeconds: 1},
+	}
+	c, err := startStdioServerProcess("stubborn", conf, discardLogger)
+	testhelpers.AssertNoError(t, err)
+	pid := c.GetTransport().(*supervisedStdio).process.Pid
+	testhelpers.AssertTrue(t, processExists(pid), "expected the server process to be running")
+
+	started := time.Now()
+	_ = c.Close()
+	testhelpers.AssertTrue(t, time.Since(started) < 5*time.Second, "expected the process to be killed after the kill timeout")
+	testhelpers.AssertFalse(t, processExists(pid), "expected the server process to be gone")
+}
+
+func TestKillStdioProcesses(t *testing.T) {
+	conf := &model.StdioConfig{Command: "sh", Args: []string{"-c", "sleep 30 & wait"}}
+	c, err := startStdioServerProcess("orphan", conf, discardLogger)
+	testhelpers.AssertNoError(t, err)
+	pid := c.GetTransport().(*supervisedStdio).process.Pid
+
+	testhelpers.AssertTrue(t, KillStdioProcesses() >= 1, "expected at least one process to be killed")
+
+	started := time.Now()
+	_ = c.Close()
+	testhelpers.AssertTrue(t, time.Since(started) < 5*time.Second, "expected the session to close right away")
+	testhelpers.AssertFalse(t, processExists(pid), "expected the server process to be gone")
+}
+
+func TestStdioWorkDir(t *testing.T) {
+	dir := t.TempDir()
+	conf := &model.StdioConfig{
+		Command:          "sh",
+		Args:             []string{"-c", "pwd >&2; sleep 30"},
+		StdioSupervision: types.StdioSupervision{WorkDir: dir, KillTimeoutSeconds: 1},
+	}
+	c, err := startStdioServerProcess("workdir", conf, discardLogger)
+	testhelpers.AssertNoError(t, err)
+	defer c.Close()
+
+	buf := make([]byte, 256)
+	n, err := c.GetTransport().(*supervisedStdio).Stderr().Read(buf)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertStringContains(t, string(buf[:n]), dir)
+}
@@ -0,0 +1,24 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// configureStdioProcess fails if the stdio server is configured to run as a different user,
+// which is not supported on windows.
+func configureStdioProcess(cmd *exec.Cmd, conf *model.StdioConfig) error {
+	if conf.RunAsUser != "" || conf.RunAsGroup != "" {
+		return errors.New("run_as_user and run_as_group are not supported on windows")
+	}
+	return nil
+}
+
+// killProcessTree kills the given process.
+// Unlike on unix, processes spawned by it are not killed.
+func killProcessTree(p *os.Process) {
+	_ = p.Kill()
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultToolCallTimeout is the maximum time a tool call may take, unless configured otherwise
+// for mcpjungle, the upstream server or the tool.
+const DefaultToolCallTimeout = 2 * time.Minute
+
+// cancelNotificationTimeout is how long mcpjungle tries to deliver a cancellation to an upstream server.
+const cancelNotificationTimeout = 5 * time.Second
+
+// ErrToolCallTimeout is returned when an upstream MCP server doesn't respond to a tool call in time.
+var ErrToolCallTimeout = errors.New("tool call timed out")
+
+// toolCallRequestID generates the IDs of tool call requests sent to upstream servers.
+// mcpjungle needs to know the ID of a request to be able to cancel it.
+var toolCallRequestID atomic.Int64
+
+// SetDefaultToolCallTimeout sets the call timeout used for tools whose server doesn't configure one.
+// A timeout of 0 means that tool calls never time out.
+func (m *MCPService) SetDefaultToolCallTimeout(timeout time.Duration) {
+	m.defaultToolCallTimeout = timeout
+}
+
+// callToolWithTimeout calls a tool on the upstream MCP server and waits at most for the given timeout.
+// A timeout of 0 means that the call never times out.
+// If the call times out or the caller gives up, the upstream server is sent a notifications/cancelled
+// so that it can stop working on the request.
+// The sizes of the arguments and the result are recorded as metrics of the given server's tool.
+func (m *MCPService) callToolWithTimeout(
+	ctx context.Context, c *client.Client, serverName string, request mcp.CallToolRequest, timeout time.Duration,
+) (_ *mcp.CallToolResult, err error) {
+	ctx, span := startSpan(ctx, string(mcp.MethodToolsCall)+" "+request.Params.Name, trace.SpanKindClient,
+		attrMCPMethod.String(string(mcp.MethodToolsCall)),
+		attrMCPTool.String(request.Params.Name),
+	)
+	defer func() { endSpan(span, err) }()
+
+	callCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		callCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// The params are encoded here to measure their size, the transport sends the encoded JSON as is.
+	params, err := json.Marshal(withTraceContextMeta(callCtx, c, request.Params))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tool call params: %w", err)
+	}
+	m.metrics.RecordToolCallPayloadSize(ctx, serverName, request.Params.Name, telemetry.PayloadDirectionRequest, len(params))
+
+	// The request is sent directly over the transport (instead of using client.CallTool)
+	// because mcpjungle must control the request ID to cancel it.
+	id := mcp.NewRequestId(fmt.Sprintf("mcpjungle-%d", toolCallRequestID.Add(1)))
+	resp, err := c.GetTransport().SendRequest(callCtx, transport.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      id,
+		Method:  string(mcp.MethodToolsCall),
+		Params:  json.RawMessage(params),
+	})
+	if err == nil && resp.Error == nil {
+		m.metrics.RecordToolCallPayloadSize(
+			ctx, serverName, request.Params.Name, telemetry.PayloadDirectionResponse, len(resp.Result),
+		)
+		res, err := mcp.ParseCallToolResult(&resp.Result)
+		if err == nil && res.IsError {
+			span.SetStatus(codes.Error, "tool returned an error")
+		}
+		return res, err
+	}
+
+	if ctx.Err() != nil {
+		sendCancelledNotification(c, id, "request cancelled by the client", sessionLogger(ctx))
+		return nil, ctx.Err()
+	}
+	if errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+		sendCancelledNotification(c, id, fmt.Sprintf("tool call timed out after %s", timeout), sessionLogger(ctx))
+		return nil, fmt.Errorf("%w: no response from MCP server after %s", ErrToolCallTimeout, timeout)
+	}
+	if err != nil {
+		return nil, transport.NewError(err)
+	}
+	return nil, resp.Error.AsError()
+}
+
+// sendCancelledNotification tells the upstream MCP server that mcpjungle is no longer interested
+// in the result of a request. Delivery is best-effort.
+func sendCancelledNotification(c *client.Client, id mcp.RequestId, reason string, l logger.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelNotificationTimeout)
+	defer cancel()
+
+	n := mcp.JSONRPCNotification{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		Notification: mcp.Notification{
+			Method: "notifications/cancelled",
+			Params: mcp.NotificationParams{
+				AdditionalFields: map[string]any{
+					"requestId": id,
+					"reason":    reason,
+				},
+			},
+		},
+	}
+	if err := c.GetTransport().SendNotification(ctx, n); err != nil {
+		l.Warn("failed to send cancellation to MCP server",
+			logger.String("upstream_request_id", id.String()), logger.ErrorField(err))
+	}
+}
+
+// toolCallErrorOutcome returns the metrics outcome of a tool call that failed with the given error.
+func toolCallErrorOutcome(err error) telemetry.ToolCallOutcome {
+	if errors.Is(err, ErrToolCallTimeout) {
+		return telemetry.ToolCallOutcomeTimeout
+	}
+	return telemetry.ToolCallOutcomeError
+}
@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+)
+
+// newSlowUpstream starts an in-process MCP server with a "sleep" tool that only returns
+// once its context is done, and records the IDs of the requests it was asked to cancel.
+func newSlowUpstream(t *testing.T) (*client.Client, func() []string) {
+	t.Helper()
+
+	s := server.NewMCPServer("slow", "0.0.1", server.WithToolCapabilities(true))
+	s.AddTool(mcp.NewTool("sleep"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	s.AddTool(mcp.NewTool("echo"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("hello"), nil
+	})
+
+	var mu sync.Mutex
+	var cancelled []string
+	s.AddNotificationHandler("notifications/cancelled", func(_ context.Context, n mcp.JSONRPCNotification) {
+		mu.Lock()
+		defer mu.Unlock()
+		if id, ok := n.Params.AdditionalFields["requestId"].(string); ok {
+			cancelled = append(cancelled, id)
+		}
+	})
+
+	c, err := client.NewInProcessClient(s)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, c.Start(context.Background()))
+
+	initReq := mcp.InitializeRequest{}
+	initReq.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	_, err = c.Initialize(context.Background(), initReq)
+	testhelpers.AssertNoError(t, err)
+
+	return c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), cancelled...)
+	}
+}
+
+func TestCallToolWithTimeout(t *testing.T) {
+	c, cancelledIDs := newSlowUpstream(t)
+	defer c.Close()
+	m := &MCPService{metrics: telemetry.NewNoopCustomMetrics()}
+
+	t.Run("returns the result of a fast tool", func(t *testing.T) {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "echo"
+		res, err := m.callToolWithTimeout(context.Background(), c, "slow", req, time.Second)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, "hello", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("times out and cancels a slow tool", func(t *testing.T) {
+		req := mcp.CallToolRequest{}
+		req.Params.Name = "sleep"
+		_, err := m.callToolWithTimeout(context.Background(), c, "slow", req, 20*time.Millisecond)
+		testhelpers.AssertTrue(t, errors.Is(err, ErrToolCallTimeout), "expected a tool call timeout error")
+		testhelpers.AssertEqual(t, telemetry.ToolCallOutcomeTimeout, toolCallErrorOutcome(err))
+
+		ids := cancelledIDs()
+		testhelpers.AssertEqual(t, 1, len(ids))
+		testhelpers.AssertStringContains(t, ids[0], "mcpjungle-")
+	})
+}
@@ -4,14 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/mark3labs/mcp-go/client"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // ToolDeletionCallback is a function type that can be registered to be called
@@ -108,20 +109,35 @@ func (m *MCPService) GetToolParentServer(name string) (*model.McpServer, error)
 }
 
 // InvokeTool invokes a tool from a registered MCP server and returns its response.
-func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[string]any) (*types.ToolInvokeResult, error) {
+func (m *MCPService) InvokeTool(
+	ctx context.Context, name string, args map[string]any,
+) (_ *types.ToolInvokeResult, err error) {
 	started := time.Now()
 	outcome := telemetry.ToolCallOutcomeError
 
+	ctx, span := startSpan(ctx, "invoke tool "+name, trace.SpanKindInternal, attrMCPTool.String(name))
+	defer func() { endSpan(span, err) }()
+
 	serverName, toolName, ok := splitServerToolName(name)
 	if !ok {
 		return nil, fmt.Errorf("invalid input: tool name does not contain a %s separator", serverToolNameSep)
 	}
+	ctx = m.withLogFields(ctx, logger.String("server", serverName), logger.String("tool", toolName))
 
 	// record the tool call metrics when the function returns
 	defer func() {
-		m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
+		m.recordToolCall(ctx, serverName, toolName, outcome, time.Since(started))
 	}()
 
+	d, err := m.applyRateLimits(ctx, serverName, name)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		outcome = telemetry.ToolCallOutcomeRateLimited
+		return nil, fmt.Errorf("%s, retry after %s", d.Reason, d.RetryAfter.Round(time.Second))
+	}
+
 	serverModel, err := m.GetMcpServer(serverName)
 	if err != nil {
 		return nil, fmt.Errorf(
@@ -131,18 +147,35 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 		)
 	}
 
-	mcpClient, err := newMcpServerSession(ctx, serverModel)
+	release, err := m.acquireServerSlot(ctx, serverModel)
 	if err != nil {
 		return nil, err
 	}
-	defer mcpClient.Close()
+	defer release()
+
+	d, err = m.chargeQuota(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if d != nil {
+		outcome = telemetry.ToolCallOutcomeRateLimited
+		return nil, fmt.Errorf("%s, retry after %s", d.Reason, d.RetryAfter.Round(time.Second))
+	}
 
 	callToolReq := mcp.CallToolRequest{}
 	callToolReq.Params.Name = toolName
 	callToolReq.Params.Arguments = args
 
-	callToolResp, err := mcpClient.CallTool(ctx, callToolReq)
+	timeout := serverModel.ResolveToolCallTimeout(toolName, m.defaultToolCallTimeout)
+	var callToolResp *mcp.CallToolResult
+	err = m.withUpstreamSession(ctx, serverModel, m.isIdempotentTool(serverModel, toolName),
+		func(ctx context.Context, c *client.Client) (err error) {
+			callToolResp, err = m.callToolWithTimeout(ctx, c, serverName, callToolReq, timeout)
+			return err
+		},
+	)
 	if err != nil {
+		outcome = toolCallErrorOutcome(err)
 		return nil, fmt.Errorf("failed to call tool %s on MCP server %s: %w", toolName, serverName, err)
 	}
 
@@ -162,6 +195,30 @@ func (m *MCPService) InvokeTool(ctx context.Context, name string, args map[strin
 	return result, nil
 }
 
+// anonymousClient is the name that tool calls not made by an authenticated MCP client are recorded under
+const anonymousClient = "anonymous"
+
+// recordToolCall records the metrics of a finished tool call and logs it.
+func (m *MCPService) recordToolCall(
+	ctx context.Context, serverName, toolName string, outcome telemetry.ToolCallOutcome, duration time.Duration,
+) {
+	m.metrics.RecordToolCall(ctx, serverName, toolName, outcome, duration)
+
+	// calls made without an MCP client, eg- in development mode or via the API, are attributed to anonymousClient
+	clientName := anonymousClient
+	if c, ok := ctx.Value("client").(*model.McpClient); ok && c != nil {
+		clientName = c.Name
+	}
+	m.metrics.RecordClientToolCall(ctx, clientName, serverName, outcome)
+	m.recordUsage(ctx, serverName, toolName, clientName, outcome, duration)
+
+	m.contextLogger(ctx).Info(
+		"tool call finished",
+		logger.String("outcome", string(outcome)),
+		logger.Int64("duration_ms", duration.Milliseconds()),
+	)
+}
+
 // SetToolDeletionCallback registers a callback function to be called
 // whenever one or more tools are deleted (deregistered) or disabled.
 // The callback receives the names of the deleted tools as arguments.
@@ -336,10 +393,17 @@ func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer
 			Description: tool.Description,
 			InputSchema: jsonSchema,
 		}
+		// annotations and output schema are optional, only store them if the server provided them
+		if tool.Annotations != (mcp.ToolAnnotation{}) {
+			t.Annotations, _ = json.Marshal(tool.Annotations)
+		}
+		t.OutputSchema = toolOutputSchema(tool)
 		if err := m.db.Create(t).Error; err != nil {
 			// If registration of a tool fails, we should not fail the entire server registration.
 			// Instead, continue with the next tool.
-			log.Printf("[ERROR] failed to register tool %s in DB: %v", canonicalToolName, err)
+			m.contextLogger(ctx).Error(
+				"failed to register tool in DB", logger.String("tool", canonicalToolName), logger.ErrorField(err),
+			)
 			continue
 		}
 
@@ -358,9 +422,30 @@ func (m *MCPService) registerServerTools(ctx context.Context, s *model.McpServer
 		// notify any registered callbacks about the tool addition
 		m.notifyToolAddition(tool.Name)
 	}
+
+	// embedding the tools may involve a slow call to an external embedding API,
+	// so the semantic search index is updated in the background, once for all tools of the server
+	go func() {
+		if err := m.searchService.SyncServerEmbeddings(context.Background(), s.Name); err != nil {
+			m.logger.Warn("failed to update tool embeddings", logger.String("server", s.Name), logger.ErrorField(err))
+		}
+	}()
 	return nil
 }
 
+// toolOutputSchema returns the JSON output schema of a tool fetched from an MCP server,
+// or nil if the tool has no output schema.
+func toolOutputSchema(tool mcp.Tool) []byte {
+	if len(tool.RawOutputSchema) > 0 {
+		return tool.RawOutputSchema
+	}
+	if tool.OutputSchema.Type == "" {
+		return nil
+	}
+	schema, _ := json.Marshal(tool.OutputSchema)
+	return schema
+}
+
 // deregisterServerTools deletes all tools that belong to an MCP server from the DB.
 // It also removes the tools from the MCP proxy server.
 func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
@@ -394,6 +479,9 @@ func (m *MCPService) deregisterServerTools(s *model.McpServer) error {
 	// notify any registered callbacks about the tool deletion
 	m.notifyToolDeletion(toolNames...)
 
+	if err := m.searchService.PruneEmbeddings(); err != nil {
+		m.logger.Warn("failed to delete embeddings of removed tools", logger.ErrorField(err))
+	}
 	return nil
 }
 
@@ -418,6 +506,10 @@ func (m *MCPService) deleteToolInstances(toolNames ...string) {
 // notifyToolDeletion calls all registered tool deletion callbacks with the given tool names.
 func (m *MCPService) notifyToolDeletion(toolNames ...string) {
 	m.toolDeletionCallback(toolNames...)
+
+	for _, name := range toolNames {
+		m.refreshSearchIndex(name)
+	}
 }
 
 // notifyToolAddition calls all registered tool addition callbacks with the given tool names.
@@ -426,7 +518,21 @@ func (m *MCPService) notifyToolAddition(toolName string) {
 	if err := m.toolAdditionCallback(toolName); err != nil {
 		// log the issue, but do not fail the entire operation
 		// as the tool has already been added successfully
-		log.Printf("[ERROR] tool addition callback failed for tool %s: %v", toolName, err)
+		m.logger.Error("tool addition callback failed", logger.String("tool", toolName), logger.ErrorField(err))
+	}
+
+	m.refreshSearchIndex(toolName)
+}
+
+// refreshSearchIndex updates a tool in the keyword search index.
+// Failures are only logged, because the index is rebuilt anyway once it detects that it is outdated.
+func (m *MCPService) refreshSearchIndex(toolName string) {
+	serverName, name, ok := splitServerToolName(toolName)
+	if !ok {
+		return
+	}
+	if err := m.searchService.RefreshTool(serverName, name); err != nil {
+		m.logger.Warn("failed to update tool search index", logger.String("tool", toolName), logger.ErrorField(err))
 	}
 }
 
@@ -504,3 +610,52 @@ func (m *MCPService) convertMCPMetaToMap(meta *mcp.Meta) map[string]any {
 
 	return metaMap
 }
+
+// usageEventBuffer is the number of tool calls that can wait to be recorded in the usage analytics.
+// While the buffer is full, tool calls are left out of the analytics, so that a slow database never delays them.
+const usageEventBuffer = 1024
+
+// usageEvent is a tool call waiting to be recorded in the usage analytics.
+type usageEvent struct {
+	serverName string
+	toolName   string
+	clientName string
+	outcome    telemetry.ToolCallOutcome
+	duration   time.Duration
+}
+
+// recordUsage queues a tool call to be counted in the usage analytics,
+// so that writing to the database doesn't delay the response to the MCP client.
+func (m *MCPService) recordUsage(
+	ctx context.Context, serverName, toolName, clientName string, outcome telemetry.ToolCallOutcome, duration time.Duration,
+) {
+	m.startUsageWriter.Do(func() { go m.writeUsage() })
+
+	e := usageEvent{serverName: serverName, toolName: toolName, clientName: clientName, outcome: outcome, duration: duration}
+	select {
+	case m.usageEvents <- e:
+	default:
+		m.contextLogger(ctx).Warn("usage analytics can't keep up, tool call is not recorded")
+	}
+}
+
+// writeUsage records the queued tool calls in the usage analytics, one at a time.
+func (m *MCPService) writeUsage() {
+	for e := range m.usageEvents {
+		m.writeUsageEvent(e)
+	}
+}
+
+func (m *MCPService) writeUsageEvent(e usageEvent) {
+	name := mergeServerToolNames(e.serverName, e.toolName)
+	defer func() {
+		// Recover from any panics to ensure usage analytics never crash the application
+		if r := recover(); r != nil {
+			m.logger.Warn("usage recording panic recovered", logger.String("tool", name), logger.Any("panic", r))
+		}
+	}()
+
+	if err := m.usageService.Record(e.serverName, name, e.clientName, string(e.outcome), e.duration); err != nil {
+		m.logger.Warn("failed to record tool usage", logger.String("tool", name), logger.ErrorField(err))
+	}
+}
@@ -1,11 +1,19 @@
 package mcp
 
 import (
+	"context"
 	"encoding/json"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
 
@@ -470,3 +478,111 @@ func TestConvertMCPMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyToolCallRecordsUsage(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	err = svc.GetRateLimitService().SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeClient, Target: "agent", RequestsPerMinute: 1, Burst: 1,
+	})
+	testhelpers.AssertNoError(t, err)
+
+	ctx := context.WithValue(context.Background(), "mode", model.ModeDev)
+	ctx = context.WithValue(ctx, "client", &model.McpClient{Name: "agent"})
+
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "github__search_code"
+
+	// the first call fails because the server isn't registered, the second one is rate limited
+	_, _ = svc.MCPProxyToolCallHandler(ctx, req)
+	_, _ = svc.MCPProxyToolCallHandler(ctx, req)
+
+	// usage is recorded asynchronously
+	var rows []types.UsageRow
+	for range 50 {
+		rows, err = svc.GetUsageService().Report(time.Now().Add(-time.Hour), time.Now(), types.UsageGroupByClient, 0)
+		testhelpers.AssertNoError(t, err)
+		if len(rows) == 1 && rows[0].Calls == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	testhelpers.AssertEqual(t, 1, len(rows))
+	testhelpers.AssertEqual(t, "agent", rows[0].Name)
+	testhelpers.AssertEqual(t, int64(2), rows[0].Calls)
+	testhelpers.AssertEqual(t, int64(1), rows[0].Outcomes[string(telemetry.ToolCallOutcomeError)])
+	testhelpers.AssertEqual(t, int64(1), rows[0].Outcomes[string(telemetry.ToolCallOutcomeRateLimited)])
+}
+
+func TestRecordUsageDoesNotBlockWhenQueueIsFull(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	// don't start the writer, so that the queue fills up
+	svc.startUsageWriter.Do(func() {})
+
+	for range usageEventBuffer + 10 {
+		svc.recordUsage(context.Background(), "github", "search_code", "agent", telemetry.ToolCallOutcomeSuccess, time.Millisecond)
+	}
+	testhelpers.AssertEqual(t, usageEventBuffer, len(svc.usageEvents))
+}
+
+func TestRegisterServerToolsStoresAnnotations(t *testing.T) {
+	setup := testhelpers.SetupMCPTest(t)
+	defer setup.Cleanup()
+
+	upstream := server.NewMCPServer("upstream", "0.1")
+	upstream.AddTool(
+		mcp.NewTool("list_repos",
+			mcp.WithTitleAnnotation("List repositories"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithOutputSchema[struct {
+				Repos []string `json:"repos"`
+			}](),
+		),
+		func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText("ok"), nil
+		},
+	)
+	// unlike mcp.NewTool, a literal tool has no annotations
+	echoTool := mcp.Tool{Name: "echo", InputSchema: mcp.ToolInputSchema{Type: "object"}}
+	upstream.AddTool(echoTool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	ts := httptest.NewServer(server.NewStreamableHTTPServer(upstream))
+	defer ts.Close()
+
+	svc, err := NewMCPService(setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+	s, err := model.NewStreamableHTTPServer("github", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, svc.RegisterMcpServer(context.Background(), s))
+
+	tool, err := svc.GetTool("github__list_repos")
+	testhelpers.AssertNoError(t, err)
+	annotations, err := tool.GetAnnotations()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, annotations)
+	testhelpers.AssertEqual(t, "List repositories", annotations.Title)
+	testhelpers.AssertTrue(t, annotations.IsReadOnly(), "expected the tool to be read-only")
+	testhelpers.AssertTrue(t, len(tool.OutputSchema) > 0, "expected the output schema to be stored")
+
+	// the stored tool converts back to the same MCP tool that the upstream server sent
+	mcpTool, err := convertToolModelToMcpObject(tool)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, "List repositories", mcpTool.Annotations.Title)
+	var outputSchema map[string]any
+	testhelpers.AssertNoError(t, json.Unmarshal(mcpTool.RawOutputSchema, &outputSchema))
+	testhelpers.AssertEqual(t, "object", outputSchema["type"])
+
+	echo, err := svc.GetTool("github__echo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(echo.Annotations))
+	testhelpers.AssertEqual(t, 0, len(echo.OutputSchema))
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the tracer that records the spans of the MCP proxy path
+const tracerName = "github.com/mcpjungle/mcpjungle/internal/service/mcp"
+
+// Attributes recorded on the spans of the MCP proxy path
+const (
+	attrMCPMethod    = attribute.Key("mcp.method.name")
+	attrMCPServer    = attribute.Key("mcpjungle.server.name")
+	attrMCPTransport = attribute.Key("mcpjungle.server.transport")
+	attrMCPTool      = attribute.Key("gen_ai.tool.name")
+)
+
+// startSpan starts a span using the global tracer provider.
+// If tracing is disabled, the span is a no-op.
+func startSpan(
+	ctx context.Context, name string, kind trace.SpanKind, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+}
+
+// endSpan ends a span, marking it as failed if err is not nil.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// initializeSession sends the initialize request to an upstream MCP server within a span.
+func initializeSession(ctx context.Context, c *client.Client, request mcp.InitializeRequest) error {
+	ctx, span := startSpan(ctx, string(mcp.MethodInitialize), trace.SpanKindClient,
+		attrMCPMethod.String(string(mcp.MethodInitialize)),
+	)
+	_, err := c.Initialize(ctx, request)
+	endSpan(span, err)
+	return err
+}
+
+// withTraceContextMeta returns the params of a tool call with the W3C trace context of ctx added to their _meta.
+// It is used to propagate traces to stdio MCP servers, which can't receive HTTP headers.
+// The meta of the original request is left untouched because it belongs to the MCP client.
+func withTraceContextMeta(ctx context.Context, c *client.Client, params mcp.CallToolParams) mcp.CallToolParams {
+	if _, ok := c.GetTransport().(*supervisedStdio); !ok {
+		return params
+	}
+	fields := telemetry.InjectTraceContext(ctx)
+	if len(fields) == 0 {
+		return params
+	}
+
+	meta := &mcp.Meta{AdditionalFields: make(map[string]any, len(fields))}
+	if params.Meta != nil {
+		meta.ProgressToken = params.Meta.ProgressToken
+		for k, v := range params.Meta.AdditionalFields {
+			meta.AdditionalFields[k] = v
+		}
+	}
+	for k, v := range fields {
+		meta.AdditionalFields[k] = v
+	}
+	params.Meta = meta
+	return params
+}
@@ -0,0 +1,142 @@
+package mcp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withSpanRecorder installs a global tracer provider that records all spans until the test ends.
+func withSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return rec
+}
+
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, s := range spans {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestCallToolWithTimeoutRecordsSpan(t *testing.T) {
+	rec := withSpanRecorder(t)
+
+	c, _ := newSlowUpstream(t)
+	defer c.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	req := mcp.CallToolRequest{}
+	req.Params.Name = "echo"
+	m := &MCPService{metrics: telemetry.NewNoopCustomMetrics()}
+	_, err := m.callToolWithTimeout(ctx, c, "slow", req, time.Second)
+	testhelpers.AssertNoError(t, err)
+	parent.End()
+
+	span := findSpan(rec.Ended(), "tools/call echo")
+	if span == nil {
+		t.Fatal("expected a span for the tool call")
+	}
+	testhelpers.AssertEqual(t, trace.SpanKindClient, span.SpanKind())
+	testhelpers.AssertEqual(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+}
+
+func TestNewMcpServerSessionPropagatesTraceContext(t *testing.T) {
+	rec := withSpanRecorder(t)
+
+	var mu sync.Mutex
+	var traceparents []string
+	upstream := server.NewStreamableHTTPServer(server.NewMCPServer("upstream", "0.0.1"))
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		mu.Unlock()
+		upstream.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	s, err := model.NewStreamableHTTPServer("upstream", "", ts.URL+"/mcp", "")
+	testhelpers.AssertNoError(t, err)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	c, err := newMcpServerSession(ctx, s)
+	testhelpers.AssertNoError(t, err)
+	_ = c.Close()
+	parent.End()
+
+	spans := rec.Ended()
+	session := findSpan(spans, "create upstream session")
+	if session == nil {
+		t.Fatal("expected a span for the upstream session")
+	}
+	initialize := findSpan(spans, "initialize")
+	if initialize == nil {
+		t.Fatal("expected a span for the initialize request")
+	}
+	testhelpers.AssertEqual(t, session.SpanContext().SpanID(), initialize.Parent().SpanID())
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(traceparents) == 0 || traceparents[0] == "" {
+		t.Fatal("expected the upstream server to receive the trace context")
+	}
+	testhelpers.AssertStringContains(t, traceparents[0], initialize.SpanContext().TraceID().String())
+}
+
+func TestWithTraceContextMeta(t *testing.T) {
+	withSpanRecorder(t)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+
+	params := mcp.CallToolParams{Name: "echo", Meta: &mcp.Meta{ProgressToken: "p1"}}
+
+	t.Run("leaves the params of non-stdio servers untouched", func(t *testing.T) {
+		c, _ := newSlowUpstream(t)
+		defer c.Close()
+
+		got := withTraceContextMeta(ctx, c, params)
+		testhelpers.AssertEqual(t, params.Meta, got.Meta)
+	})
+
+	t.Run("adds the trace context to the meta of stdio calls", func(t *testing.T) {
+		c, err := startStdioServerProcess("cat", &model.StdioConfig{Command: "cat"}, discardLogger)
+		if err != nil {
+			t.Skipf("cat is not available: %v", err)
+		}
+		defer c.Close()
+
+		got := withTraceContextMeta(ctx, c, params)
+		traceparent, _ := got.Meta.AdditionalFields["traceparent"].(string)
+		testhelpers.AssertStringContains(t, traceparent, span.SpanContext().TraceID().String())
+		testhelpers.AssertEqual(t, mcp.ProgressToken("p1"), got.Meta.ProgressToken)
+		if params.Meta.AdditionalFields != nil {
+			t.Error("the meta of the original request must not be modified")
+		}
+	})
+}
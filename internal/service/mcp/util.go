@@ -5,11 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"log"
 	"net"
 	"net/url"
-	"os"
 	"regexp"
 	"strings"
 	"syscall"
@@ -19,7 +16,9 @@ import (
 	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // serverInitRequestTimeout is the timeout (in seconds) for the initialization request to the MCP server
@@ -120,7 +119,18 @@ func convertToolModelToMcpObject(t *model.Tool) (mcp.Tool, error) {
 	}
 	mcpTool.InputSchema = inputSchema
 
-	// TODO: Add other attributes to the tool, such as annotations
+	if len(t.Annotations) > 0 {
+		if err := json.Unmarshal(t.Annotations, &mcpTool.Annotations); err != nil {
+			return mcp.Tool{}, fmt.Errorf(
+				"failed to unmarshal annotations %s for tool %s: %w", t.Annotations, t.Name, err,
+			)
+		}
+	}
+	// the output schema is proxied as-is, so that no JSON schema keyword is lost
+	if len(t.OutputSchema) > 0 {
+		mcpTool.RawOutputSchema = json.RawMessage(t.OutputSchema)
+	}
+
 	// NOTE: if more fields are added to the tool in DB, they should be set here as well
 
 	return mcpTool, nil
@@ -159,6 +169,8 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 		})
 		opts = append(opts, o)
 	}
+	// propagate the trace of the request to the upstream server
+	opts = append(opts, transport.WithHTTPHeaderFunc(telemetry.InjectTraceContext))
 
 	c, err := client.NewStreamableHttpClient(conf.URL, opts...)
 	if err != nil {
@@ -176,7 +188,7 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 	initCtx, cancel := context.WithTimeout(ctx, serverInitRequestTimeout*time.Second)
 	defer cancel()
 
-	_, err = c.Initialize(initCtx, initRequest)
+	err = initializeSession(initCtx, c, initRequest)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			return nil, fmt.Errorf("initialization request to MCP server timed out after %d seconds", serverInitRequestTimeout)
@@ -194,32 +206,6 @@ func createHTTPMcpServerConn(ctx context.Context, s *model.McpServer) (*client.C
 	return c, nil
 }
 
-// captureStdioServerStderr captures the stderr output of a stdio MCP server in the background
-// and writes it to mcpjungle server logs.
-// This is useful for troubleshooting and visibility into the stdio server's behaviour.
-func captureStdioServerStderr(name string, c *client.Client) {
-	stdioTransport := c.GetTransport().(*transport.Stdio)
-
-	go func() {
-		buf := make([]byte, 4096) // 4KB buffer for reading stderr
-		for {
-			n, err := stdioTransport.Stderr().Read(buf)
-			if err != nil {
-				if err == io.EOF || errors.Is(err, os.ErrClosed) {
-					log.Printf("['%s' MCP Server] [DEBUG] server process has exited gracefully", name)
-				} else {
-					log.Printf("['%s' MCP STDERR] Error reading stderr: %v", name, err)
-				}
-				log.Printf("['%s' MCP server] [DEBUG] exiting goroutine", name)
-				break
-			}
-			if n > 0 {
-				log.Printf("['%s' MCP STDERR] %s", name, string(buf[:n]))
-			}
-		}
-	}()
-}
-
 // runStdioServer runs a stdio MCP server and returns the client.
 func runStdioServer(ctx context.Context, s *model.McpServer) (*client.Client, error) {
 	conf, err := s.GetStdioConfig()
@@ -227,23 +213,11 @@ func runStdioServer(ctx context.Context, s *model.McpServer) (*client.Client, er
 		return nil, fmt.Errorf("failed to get stdio config for MCP server %s: %w", s.Name, err)
 	}
 
-	// Convert the environment map to a slice of strings in the format "KEY=VALUE"
-	envVars := make([]string, 0)
-	if conf.Env != nil {
-		for k, v := range conf.Env {
-			envVars = append(envVars, fmt.Sprintf("%s=%s", k, v))
-		}
-	}
-
-	c, err := client.NewStdioMCPClient(conf.Command, envVars, conf.Args...)
+	c, err := startStdioServerProcess(s.Name, conf, sessionLogger(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdio client for MCP server: %w", err)
 	}
 
-	// currently, we only capture the stderr output in the mcpjungle server logs.
-	// TODO: Propagate the stderr output to the client as well to provide them quicker feedback on errors.
-	captureStdioServerStderr(s.Name, c)
-
 	initRequest := mcp.InitializeRequest{}
 	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
 	initRequest.Params.ClientInfo = mcp.Implementation{
@@ -255,16 +229,40 @@ func runStdioServer(ctx context.Context, s *model.McpServer) (*client.Client, er
 	initCtx, cancel := context.WithTimeout(ctx, serverInitRequestTimeout*time.Second)
 	defer cancel()
 
-	_, err = c.Initialize(initCtx, initRequest)
+	// don't wait for the timeout if the server process crashes during initialization
+	initCtx, cancelOnExit := context.WithCancelCause(initCtx)
+	defer cancelOnExit(nil)
+	if t, ok := c.GetTransport().(*supervisedStdio); ok {
+		go func() {
+			select {
+			case <-t.stderrDone:
+				cancelOnExit(errStdioProcessExited)
+			case <-initCtx.Done():
+			}
+		}()
+	}
+
+	err = initializeSession(initCtx, c, initRequest)
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, fmt.Errorf(
+		if errors.Is(context.Cause(initCtx), errStdioProcessExited) {
+			err = errors.New(
+				"MCP server process exited during initialization," +
+					" check mcpungle server logs for any errors from this MCP server",
+			)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf(
 				"initialization request to MCP server timed out after %d seconds,"+
 					" check mcpungle server logs for any errors from this MCP server",
 				serverInitRequestTimeout,
 			)
+		} else {
+			err = fmt.Errorf("failed to initialize connection with MCP server: %w", err)
 		}
-		return nil, fmt.Errorf("failed to initialize connection with MCP server: %w", err)
+		err = withStderrOutput(c, err)
+
+		// don't leave the server process running
+		_ = c.Close()
+		return nil, err
 	}
 
 	return c, nil
@@ -285,6 +283,8 @@ func createSSEMcpServerConn(ctx context.Context, s *model.McpServer) (*client.Cl
 		})
 		opts = append(opts, o)
 	}
+	// propagate the trace of the request to the upstream server
+	opts = append(opts, transport.WithHeaderFunc(telemetry.InjectTraceContext))
 
 	c, err := client.NewSSEMCPClient(conf.URL, opts...)
 	if err != nil {
@@ -302,7 +302,7 @@ func createSSEMcpServerConn(ctx context.Context, s *model.McpServer) (*client.Cl
 			ClientInfo:      mcp.Implementation{Name: "mcpjungle-sse-proxy-client", Version: "0.1.0"},
 		},
 	}
-	_, err = c.Initialize(ctx, initReq)
+	err = initializeSession(ctx, c, initReq)
 	if err != nil {
 		return nil, fmt.Errorf("client failed to initialize connection with SSE MCP server: %w", err)
 	}
@@ -310,7 +310,23 @@ func createSSEMcpServerConn(ctx context.Context, s *model.McpServer) (*client.Cl
 	return c, nil
 }
 
-func newMcpServerSession(ctx context.Context, s *model.McpServer) (*client.Client, error) {
+// openMcpServerSession connects to an upstream MCP server and initializes a session with it,
+// recording how long it took and whether it failed.
+func (m *MCPService) openMcpServerSession(ctx context.Context, s *model.McpServer) (*client.Client, error) {
+	started := time.Now()
+	c, err := newMcpServerSession(ctx, s)
+	m.metrics.RecordUpstreamSession(ctx, s.Name, string(s.Transport), time.Since(started), err == nil)
+	return c, err
+}
+
+// newMcpServerSession connects to an upstream MCP server and initializes a session with it.
+func newMcpServerSession(ctx context.Context, s *model.McpServer) (_ *client.Client, err error) {
+	ctx, span := startSpan(ctx, "create upstream session", trace.SpanKindInternal,
+		attrMCPServer.String(s.Name),
+		attrMCPTransport.String(string(s.Transport)),
+	)
+	defer func() { endSpan(span, err) }()
+
 	if s.Transport == types.TransportStreamableHTTP {
 		mcpClient, err := createHTTPMcpServerConn(ctx, s)
 		if err != nil {
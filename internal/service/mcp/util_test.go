@@ -1,8 +1,12 @@
 package mcp
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/model"
 )
 
 func TestValidateServerName(t *testing.T) {
@@ -128,4 +132,57 @@ func TestIsLoopbackURL(t *testing.T) {
 	}
 }
 
-// todo: add tests for convertToolModelToMcpObject()
+func TestConvertToolModelToMcpObject(t *testing.T) {
+	t.Run("annotations and output schema are proxied", func(t *testing.T) {
+		tool := &model.Tool{
+			Name:         "github__delete_repo",
+			Description:  "Delete a repository",
+			InputSchema:  []byte(`{"type":"object","properties":{"repo":{"type":"string"}}}`),
+			Annotations:  []byte(`{"title":"Delete repository","readOnlyHint":false,"destructiveHint":true}`),
+			OutputSchema: []byte(`{"type":"object","properties":{"deleted":{"type":"boolean"}},"additionalProperties":false}`),
+		}
+		mcpTool, err := convertToolModelToMcpObject(tool)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mcpTool.Annotations.Title != "Delete repository" {
+			t.Errorf("expected the title to be proxied, got %q", mcpTool.Annotations.Title)
+		}
+		if mcpTool.Annotations.DestructiveHint == nil || !*mcpTool.Annotations.DestructiveHint {
+			t.Errorf("expected destructiveHint to be true, got %v", mcpTool.Annotations.DestructiveHint)
+		}
+
+		j, err := json.Marshal(mcpTool)
+		if err != nil {
+			t.Fatalf("failed to marshal tool: %v", err)
+		}
+		var got map[string]any
+		_ = json.Unmarshal(j, &got)
+		outputSchema, _ := got["outputSchema"].(map[string]any)
+		if outputSchema["additionalProperties"] != false {
+			t.Errorf("expected the output schema to be proxied as-is, got %v", got["outputSchema"])
+		}
+	})
+
+	t.Run("tools without annotations have none", func(t *testing.T) {
+		mcpTool, err := convertToolModelToMcpObject(&model.Tool{Name: "time__now", InputSchema: []byte(`{"type":"object"}`)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if mcpTool.Annotations != (mcp.ToolAnnotation{}) {
+			t.Errorf("expected no annotations, got %+v", mcpTool.Annotations)
+		}
+		if mcpTool.RawOutputSchema != nil {
+			t.Errorf("expected no output schema, got %s", mcpTool.RawOutputSchema)
+		}
+	})
+
+	t.Run("invalid annotations are an error", func(t *testing.T) {
+		_, err := convertToolModelToMcpObject(&model.Tool{
+			Name: "time__now", InputSchema: []byte(`{"type":"object"}`), Annotations: []byte(`[]`),
+		})
+		if err == nil {
+			t.Error("expected an error for invalid annotations")
+		}
+	})
+}
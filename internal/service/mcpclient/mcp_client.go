@@ -9,6 +9,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/audit"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"gorm.io/gorm"
 )
 
@@ -18,10 +19,10 @@ type McpClientService struct {
 	auditService *audit.AuditService
 }
 
-func NewMCPClientService(db *gorm.DB) *McpClientService {
+func NewMCPClientService(db *gorm.DB, l logger.Logger) *McpClientService {
 	return &McpClientService{
 		db:           db,
-		auditService: audit.NewAuditService(db),
+		auditService: audit.NewAuditService(db, l),
 	}
 }
 
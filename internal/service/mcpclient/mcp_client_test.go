@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 )
 
@@ -11,7 +12,7 @@ func TestNewMCPClientService(t *testing.T) {
 	db, err := testhelpers.CreateTestDB()
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 	testhelpers.AssertNotNil(t, svc)
 	if svc.db != db {
 		t.Errorf("Expected db to be %v, got %v", db, svc.db)
@@ -22,7 +23,7 @@ func TestListClientsEmpty(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewMCPClientService(setup.DB)
+	svc := NewMCPClientService(setup.DB, logger.NewNop())
 
 	clients, err := svc.ListClients()
 	testhelpers.AssertNoError(t, err)
@@ -35,7 +36,7 @@ func TestCreateClient(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
 
-	svc := NewMCPClientService(setup.DB)
+	svc := NewMCPClientService(setup.DB, logger.NewNop())
 
 	clientInput := model.McpClient{
 		Name:        "test-client",
@@ -72,7 +73,7 @@ func TestCreateClientWithExistingName(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	clientInput := model.McpClient{
 		Name:        "test-client",
@@ -100,7 +101,7 @@ func TestGetClientByToken(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Create a test client
 	clientInput := model.McpClient{
@@ -129,7 +130,7 @@ func TestGetClientByTokenNotFound(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Try to get client with non-existent token
 	client, err := svc.GetClientByToken("non-existent-token")
@@ -147,7 +148,7 @@ func TestDeleteClient(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Create a test client
 	clientInput := model.McpClient{
@@ -176,7 +177,7 @@ func TestDeleteClientNotFound(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Try to delete non-existent client
 	err = svc.DeleteClient("non-existent-client")
@@ -191,7 +192,7 @@ func TestListClientsWithData(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Create multiple test clients
 	clientInputs := []model.McpClient{
@@ -229,7 +230,7 @@ func TestClientTokenUniqueness(t *testing.T) {
 	err = db.AutoMigrate(&model.McpClient{})
 	testhelpers.AssertNoError(t, err)
 
-	svc := NewMCPClientService(db)
+	svc := NewMCPClientService(db, logger.NewNop())
 
 	// Create multiple clients
 	clientInputs := []model.McpClient{
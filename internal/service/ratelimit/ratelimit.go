@@ -0,0 +1,491 @@
+// Package ratelimit provides rate limiting and call quotas for tool calls proxied by MCPJungle.
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	dailyPeriodLayout   = "2006-01-02"
+	monthlyPeriodLayout = "2006-01"
+
+	// toolNameSep separates the server name from the tool name in canonical tool names.
+	toolNameSep = "__"
+)
+
+// Decision is the result of checking a tool call against the configured rate limits and quotas.
+type Decision struct {
+	// Allowed is true if the call can proceed.
+	Allowed bool
+
+	// Scope and Target identify the limit that rejected the call.
+	// They are empty if the call was allowed.
+	Scope  types.RateLimitScope
+	Target string
+
+	// RetryAfter is the duration after which the call can be retried.
+	RetryAfter time.Duration
+
+	// Reason is a human-readable explanation of why the call was rejected.
+	Reason string
+}
+
+// errQuotaExhausted rolls back the transaction that charges a call against a client's quotas.
+var errQuotaExhausted = errors.New("quota exhausted")
+
+type limitKey struct {
+	scope  types.RateLimitScope
+	target string
+}
+
+// tokenBucket is a simple token bucket that is refilled continuously at a fixed rate.
+type tokenBucket struct {
+	mu sync.Mutex
+
+	capacity float64
+	// rate is the number of tokens added per second
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(l *model.RateLimit, now time.Time) *tokenBucket {
+	capacity := float64(l.Burst)
+	if capacity <= 0 {
+		capacity = float64(l.RequestsPerMinute)
+	}
+	return &tokenBucket{
+		capacity: capacity,
+		rate:     float64(l.RequestsPerMinute) / 60,
+		tokens:   capacity,
+		last:     now,
+	}
+}
+
+// refill adds the tokens accumulated since the last refill.
+func (b *tokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+	}
+	b.last = now
+}
+
+// wait returns how long it takes until a token is available. It returns 0 if one is available right away.
+func (b *tokenBucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// RateLimitService manages rate limits and quotas and decides whether tool calls are allowed.
+// Limits are stored in the database and cached in memory, token buckets only live in memory.
+type RateLimitService struct {
+	db *gorm.DB
+
+	// mu only guards the maps below and is never held during database I/O.
+	// Each token bucket has its own lock, so calls subject to different limits don't contend.
+	mu sync.Mutex
+	// limits caches all rate limits from the database. It is nil until loaded and never modified once loaded.
+	limits  map[limitKey]*model.RateLimit
+	buckets map[limitKey]*tokenBucket
+	// generation is incremented whenever the cached limits are dropped,
+	// so that limits loaded concurrently with a change are not cached.
+	generation uint64
+
+	// now returns the current time, it can be overridden in tests.
+	now func() time.Time
+}
+
+// NewRateLimitService creates a new RateLimitService.
+func NewRateLimitService(db *gorm.DB) *RateLimitService {
+	return &RateLimitService{
+		db:      db,
+		buckets: make(map[limitKey]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// ListRateLimits returns all the rate limits configured in mcpjungle.
+func (r *RateLimitService) ListRateLimits() ([]model.RateLimit, error) {
+	var limits []model.RateLimit
+	if err := r.db.Order("scope, target").Find(&limits).Error; err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// SetRateLimit creates the rate limit for the given scope and target, or replaces it if it already exists.
+func (r *RateLimitService) SetRateLimit(l *model.RateLimit) error {
+	if err := validateRateLimit(l); err != nil {
+		return err
+	}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "target"}},
+		DoUpdates: clause.AssignmentColumns(
+			[]string{"requests_per_minute", "burst", "daily_quota", "monthly_quota", "updated_at", "deleted_at"},
+		),
+	}).Create(l).Error
+	if err != nil {
+		return fmt.Errorf("failed to save rate limit: %w", err)
+	}
+
+	r.invalidate(limitKey{scope: l.Scope, target: l.Target})
+	return nil
+}
+
+// DeleteRateLimit removes the rate limit for the given scope and target.
+func (r *RateLimitService) DeleteRateLimit(scope types.RateLimitScope, target string) error {
+	res := r.db.Unscoped().Where("scope = ? AND target = ?", scope, target).Delete(&model.RateLimit{})
+	if res.Error != nil {
+		return fmt.Errorf("failed to delete rate limit: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("rate limit for %s %s not found", scope, target)
+	}
+
+	r.invalidate(limitKey{scope: scope, target: target})
+	return nil
+}
+
+// DeleteClientRateLimits removes the rate limit and quota usage of an MCP client.
+// It must be called when the client is deleted, so that a new client with the same name starts afresh.
+func (r *RateLimitService) DeleteClientRateLimits(clientName string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Where("scope = ? AND target = ?", types.RateLimitScopeClient, clientName).
+			Delete(&model.RateLimit{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("client_name = ?", clientName).Delete(&model.ClientQuotaUsage{}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete rate limits of client %s: %w", clientName, err)
+	}
+
+	r.invalidate(limitKey{scope: types.RateLimitScopeClient, target: clientName})
+	return nil
+}
+
+// DeleteServerRateLimits removes the rate limits of an MCP server and of all its tools.
+// It must be called when the server is deregistered.
+func (r *RateLimitService) DeleteServerRateLimits(serverName string) error {
+	var toolLimits []model.RateLimit
+	if err := r.db.Where("scope = ?", types.RateLimitScopeTool).Find(&toolLimits).Error; err != nil {
+		return fmt.Errorf("failed to list tool rate limits: %w", err)
+	}
+	keys := []limitKey{{scope: types.RateLimitScopeServer, target: serverName}}
+	for _, l := range toolLimits {
+		if strings.HasPrefix(l.Target, serverName+toolNameSep) {
+			keys = append(keys, limitKey{scope: l.Scope, target: l.Target})
+		}
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, k := range keys {
+			err := tx.Unscoped().Where("scope = ? AND target = ?", k.scope, k.target).Delete(&model.RateLimit{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete rate limits of server %s: %w", serverName, err)
+	}
+
+	r.invalidate(keys...)
+	return nil
+}
+
+// Allow checks whether a tool call made by the given client to a tool of an upstream server is allowed.
+// toolName must be the canonical name of the tool (eg- "github__search_code").
+// clientName can be empty if the call was not made by an MCP client (eg- development mode).
+// If the call is allowed, a token is taken from all the applicable token buckets.
+// The call is not counted against the client's quotas until it is dispatched, see ChargeQuota.
+func (r *RateLimitService) Allow(clientName, serverName, toolName string) (*Decision, error) {
+	limits, err := r.loadLimits()
+	if err != nil {
+		return nil, err
+	}
+	now := r.now()
+
+	var clientLimit *model.RateLimit
+	if clientName != "" {
+		clientLimit = limits[limitKey{scope: types.RateLimitScopeClient, target: clientName}]
+	}
+
+	// quotas are checked first because, unlike rate limits, retrying soon doesn't help
+	if clientLimit != nil {
+		d, err := r.checkQuotas(clientLimit, now)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			return d, nil
+		}
+	}
+
+	// the call must be allowed by all the applicable token buckets before a token is taken from any of them.
+	// The buckets are always locked in the same order (client, server, tool), so concurrent calls can't deadlock.
+	keys := []limitKey{
+		{scope: types.RateLimitScopeClient, target: clientName},
+		{scope: types.RateLimitScopeServer, target: serverName},
+		{scope: types.RateLimitScopeTool, target: toolName},
+	}
+	var buckets []*tokenBucket
+	defer func() {
+		for _, b := range buckets {
+			b.mu.Unlock()
+		}
+	}()
+	for _, k := range keys {
+		if k.target == "" {
+			continue
+		}
+		l, ok := limits[k]
+		if !ok || l.RequestsPerMinute <= 0 {
+			continue
+		}
+		b := r.bucket(k, l, now)
+		b.mu.Lock()
+		buckets = append(buckets, b)
+
+		b.refill(now)
+		if wait := b.wait(); wait > 0 {
+			return &Decision{
+				Scope:      k.scope,
+				Target:     k.target,
+				RetryAfter: wait,
+				Reason:     fmt.Sprintf("rate limit of %d calls per minute exceeded for %s %s", l.RequestsPerMinute, k.scope, k.target),
+			}, nil
+		}
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+
+	return &Decision{Allowed: true}, nil
+}
+
+// ChargeQuota counts a tool call that is about to be dispatched to its upstream server against the client's quotas.
+// Allow already rejects calls of clients whose quotas are exhausted, but concurrent calls may have used up the
+// remaining quota since. In that case the call is not counted and a rejection decision is returned.
+func (r *RateLimitService) ChargeQuota(clientName string) (*Decision, error) {
+	if clientName == "" {
+		return &Decision{Allowed: true}, nil
+	}
+	limits, err := r.loadLimits()
+	if err != nil {
+		return nil, err
+	}
+	l, ok := limits[limitKey{scope: types.RateLimitScopeClient, target: clientName}]
+	if !ok || (l.DailyQuota <= 0 && l.MonthlyQuota <= 0) {
+		return &Decision{Allowed: true}, nil
+	}
+
+	now := r.now().UTC()
+	var rejection *Decision
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if l.DailyQuota > 0 {
+			charged, err := chargePeriod(tx, clientName, now.Format(dailyPeriodLayout), l.DailyQuota, now)
+			if err != nil {
+				return err
+			}
+			if !charged {
+				rejection = dailyQuotaExhausted(l, now)
+				return errQuotaExhausted
+			}
+		}
+		if l.MonthlyQuota > 0 {
+			charged, err := chargePeriod(tx, clientName, now.Format(monthlyPeriodLayout), l.MonthlyQuota, now)
+			if err != nil {
+				return err
+			}
+			if !charged {
+				rejection = monthlyQuotaExhausted(l, now)
+				return errQuotaExhausted
+			}
+		}
+		return nil
+	})
+	if rejection != nil {
+		return rejection, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record quota usage for client %s: %w", clientName, err)
+	}
+	return &Decision{Allowed: true}, nil
+}
+
+// GetClientQuotaUsage returns the number of calls made by a client in the current day and month (UTC).
+func (r *RateLimitService) GetClientQuotaUsage(clientName string) (daily, monthly int64, err error) {
+	now := r.now().UTC()
+	if daily, err = r.quotaUsage(clientName, now.Format(dailyPeriodLayout)); err != nil {
+		return 0, 0, err
+	}
+	if monthly, err = r.quotaUsage(clientName, now.Format(monthlyPeriodLayout)); err != nil {
+		return 0, 0, err
+	}
+	return daily, monthly, nil
+}
+
+// checkQuotas returns a rejection decision if the client has exhausted its daily or monthly quota.
+func (r *RateLimitService) checkQuotas(l *model.RateLimit, now time.Time) (*Decision, error) {
+	now = now.UTC()
+	if l.DailyQuota > 0 {
+		used, err := r.quotaUsage(l.Target, now.Format(dailyPeriodLayout))
+		if err != nil {
+			return nil, err
+		}
+		if used >= l.DailyQuota {
+			return dailyQuotaExhausted(l, now), nil
+		}
+	}
+	if l.MonthlyQuota > 0 {
+		used, err := r.quotaUsage(l.Target, now.Format(monthlyPeriodLayout))
+		if err != nil {
+			return nil, err
+		}
+		if used >= l.MonthlyQuota {
+			return monthlyQuotaExhausted(l, now), nil
+		}
+	}
+	return nil, nil
+}
+
+func dailyQuotaExhausted(l *model.RateLimit, now time.Time) *Decision {
+	nextDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	return &Decision{
+		Scope:      types.RateLimitScopeClient,
+		Target:     l.Target,
+		RetryAfter: nextDay.Sub(now),
+		Reason:     fmt.Sprintf("daily quota of %d calls exhausted for client %s", l.DailyQuota, l.Target),
+	}
+}
+
+func monthlyQuotaExhausted(l *model.RateLimit, now time.Time) *Decision {
+	nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return &Decision{
+		Scope:      types.RateLimitScopeClient,
+		Target:     l.Target,
+		RetryAfter: nextMonth.Sub(now),
+		Reason:     fmt.Sprintf("monthly quota of %d calls exhausted for client %s", l.MonthlyQuota, l.Target),
+	}
+}
+
+// chargePeriod increments the client's call counter for the period unless it has already reached the quota.
+// The check and the increment are a single statement, so concurrent calls can't exceed the quota.
+// It returns false if the quota is exhausted.
+func chargePeriod(tx *gorm.DB, clientName, period string, quota int64, now time.Time) (bool, error) {
+	usage := &model.ClientQuotaUsage{ClientName: clientName, Period: period, Calls: 1}
+	res := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "client_name"}, {Name: "period"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"calls":      gorm.Expr("client_quota_usages.calls + 1"),
+			"updated_at": now,
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{gorm.Expr("client_quota_usages.calls < ?", quota)}},
+	}).Create(usage)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *RateLimitService) quotaUsage(clientName, period string) (int64, error) {
+	var usage model.ClientQuotaUsage
+	err := r.db.Where("client_name = ? AND period = ?", clientName, period).First(&usage).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get quota usage for client %s: %w", clientName, err)
+	}
+	return usage.Calls, nil
+}
+
+// loadLimits returns all rate limits, loading them from the database if they haven't been loaded yet.
+func (r *RateLimitService) loadLimits() (map[limitKey]*model.RateLimit, error) {
+	r.mu.Lock()
+	limits, generation := r.limits, r.generation
+	r.mu.Unlock()
+	if limits != nil {
+		return limits, nil
+	}
+
+	var rows []model.RateLimit
+	if err := r.db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load rate limits: %w", err)
+	}
+	limits = make(map[limitKey]*model.RateLimit, len(rows))
+	for i := range rows {
+		l := &rows[i]
+		limits[limitKey{scope: l.Scope, target: l.Target}] = l
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.generation == generation {
+		r.limits = limits
+	}
+	return limits, nil
+}
+
+// bucket returns the token bucket of the given limit, creating it if it doesn't exist yet.
+func (r *RateLimitService) bucket(k limitKey, l *model.RateLimit, now time.Time) *tokenBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.buckets[k]
+	if !ok {
+		b = newTokenBucket(l, now)
+		r.buckets[k] = b
+	}
+	return b
+}
+
+// invalidate drops the cached limits and resets the token buckets of the given limits,
+// so that changes take effect on the next call.
+func (r *RateLimitService) invalidate(keys ...limitKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits = nil
+	r.generation++
+	for _, k := range keys {
+		delete(r.buckets, k)
+	}
+}
+
+func validateRateLimit(l *model.RateLimit) error {
+	if _, err := types.ValidateRateLimitScope(string(l.Scope)); err != nil {
+		return err
+	}
+	if l.Target == "" {
+		return errors.New("rate limit target is required")
+	}
+	if l.RequestsPerMinute < 0 || l.Burst < 0 || l.DailyQuota < 0 || l.MonthlyQuota < 0 {
+		return errors.New("rate limits and quotas must not be negative")
+	}
+	if l.Scope != types.RateLimitScopeClient && (l.DailyQuota > 0 || l.MonthlyQuota > 0) {
+		return errors.New("daily and monthly quotas are only supported for MCP clients")
+	}
+	if l.RequestsPerMinute == 0 && l.DailyQuota == 0 && l.MonthlyQuota == 0 {
+		return errors.New("at least one of requests per minute, daily quota or monthly quota must be set")
+	}
+	return nil
+}
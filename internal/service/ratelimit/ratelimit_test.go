@@ -0,0 +1,234 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// setupRateLimitTest returns a rate limit service whose clock can be moved forward by the returned function.
+func setupRateLimitTest(t *testing.T) (*testhelpers.TestDBSetup, *RateLimitService, func(time.Duration)) {
+	t.Helper()
+
+	setup := testhelpers.SetupTestDB(t)
+	svc := NewRateLimitService(setup.DB)
+
+	clock := testhelpers.NewFakeClock(time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC))
+	svc.now = clock.Now
+
+	return setup, svc, clock.Advance
+}
+
+func TestSetRateLimitValidation(t *testing.T) {
+	setup, svc, _ := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	tests := []struct {
+		name  string
+		limit model.RateLimit
+	}{
+		{"invalid scope", model.RateLimit{Scope: "user", Target: "alice", RequestsPerMinute: 10}},
+		{"missing target", model.RateLimit{Scope: types.RateLimitScopeClient, RequestsPerMinute: 10}},
+		{"negative rate", model.RateLimit{Scope: types.RateLimitScopeTool, Target: "github__search_code", RequestsPerMinute: -1}},
+		{"quota on server", model.RateLimit{Scope: types.RateLimitScopeServer, Target: "github", DailyQuota: 100}},
+		{"no limit", model.RateLimit{Scope: types.RateLimitScopeClient, Target: "agent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testhelpers.AssertError(t, svc.SetRateLimit(&tt.limit))
+		})
+	}
+}
+
+func TestSetRateLimitReplacesExisting(t *testing.T) {
+	setup, svc, _ := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	l := &model.RateLimit{Scope: types.RateLimitScopeServer, Target: "github", RequestsPerMinute: 10}
+	testhelpers.AssertNoError(t, svc.SetRateLimit(l))
+
+	l = &model.RateLimit{Scope: types.RateLimitScopeServer, Target: "github", RequestsPerMinute: 20, Burst: 5}
+	testhelpers.AssertNoError(t, svc.SetRateLimit(l))
+
+	limits, err := svc.ListRateLimits()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(limits))
+	testhelpers.AssertEqual(t, 20, limits[0].RequestsPerMinute)
+	testhelpers.AssertEqual(t, 5, limits[0].Burst)
+
+	testhelpers.AssertNoError(t, svc.DeleteRateLimit(types.RateLimitScopeServer, "github"))
+	testhelpers.AssertError(t, svc.DeleteRateLimit(types.RateLimitScopeServer, "github"))
+}
+
+func TestAllowTokenBucket(t *testing.T) {
+	setup, svc, advance := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	err := svc.SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeTool, Target: "github__search_code", RequestsPerMinute: 60, Burst: 2,
+	})
+	testhelpers.AssertNoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		d, err := svc.Allow("agent", "github", "github__search_code")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, d.Allowed, "expected calls within the burst to be allowed")
+	}
+
+	d, err := svc.Allow("agent", "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, d.Allowed, "expected call beyond the burst to be rejected")
+	testhelpers.AssertEqual(t, types.RateLimitScopeTool, d.Scope)
+	testhelpers.AssertEqual(t, time.Second, d.RetryAfter)
+
+	// other tools of the same server are not affected
+	d, err = svc.Allow("agent", "github", "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, d.Allowed, "expected call to another tool to be allowed")
+
+	// one token is refilled every second
+	advance(time.Second)
+	d, err = svc.Allow("agent", "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, d.Allowed, "expected call to be allowed after the bucket was refilled")
+}
+
+func TestAllowRejectionDoesNotConsumeTokens(t *testing.T) {
+	setup, svc, _ := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	testhelpers.AssertNoError(t, svc.SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeClient, Target: "agent", RequestsPerMinute: 10, Burst: 2,
+	}))
+	testhelpers.AssertNoError(t, svc.SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeServer, Target: "github", RequestsPerMinute: 1, Burst: 1,
+	}))
+
+	d, err := svc.Allow("agent", "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, d.Allowed, "expected first call to be allowed")
+
+	// rejected by the server limit, so the client's bucket must not be drained
+	d, err = svc.Allow("agent", "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, d.Allowed, "expected call to be rejected by the server limit")
+	testhelpers.AssertEqual(t, types.RateLimitScopeServer, d.Scope)
+
+	d, err = svc.Allow("agent", "slack", "slack__post_message")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, d.Allowed, "expected client bucket to still have a token")
+}
+
+// allowAndCharge checks a call like the MCP proxy does and counts it against the quotas if it is allowed.
+func allowAndCharge(t *testing.T, svc *RateLimitService, clientName string) *Decision {
+	t.Helper()
+	d, err := svc.Allow(clientName, "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	if !d.Allowed {
+		return d
+	}
+	d, err = svc.ChargeQuota(clientName)
+	testhelpers.AssertNoError(t, err)
+	return d
+}
+
+func TestAllowQuotas(t *testing.T) {
+	setup, svc, advance := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	testhelpers.AssertNoError(t, svc.SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeClient, Target: "agent", DailyQuota: 2, MonthlyQuota: 3,
+	}))
+
+	for i := 0; i < 2; i++ {
+		d := allowAndCharge(t, svc, "agent")
+		testhelpers.AssertTrue(t, d.Allowed, "expected calls within the daily quota to be allowed")
+	}
+
+	d, err := svc.Allow("agent", "github", "github__search_code")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, d.Allowed, "expected call beyond the daily quota to be rejected")
+	testhelpers.AssertEqual(t, time.Minute, d.RetryAfter)
+	testhelpers.AssertStringContains(t, d.Reason, "daily quota")
+
+	daily, monthly, err := svc.GetClientQuotaUsage("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(2), daily)
+	testhelpers.AssertEqual(t, int64(2), monthly)
+
+	// a new day and a new month start
+	advance(time.Minute)
+	for i := 0; i < 2; i++ {
+		d = allowAndCharge(t, svc, "agent")
+		testhelpers.AssertTrue(t, d.Allowed, "expected calls to be allowed in the new period")
+	}
+
+	// other clients are not limited
+	d = allowAndCharge(t, svc, "other")
+	testhelpers.AssertTrue(t, d.Allowed, "expected call by another client to be allowed")
+}
+
+func TestChargeQuota(t *testing.T) {
+	setup, svc, _ := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	testhelpers.AssertNoError(t, svc.SetRateLimit(&model.RateLimit{
+		Scope: types.RateLimitScopeClient, Target: "agent", DailyQuota: 1,
+	}))
+
+	// calls that are allowed but never dispatched don't use up the quota
+	for i := 0; i < 2; i++ {
+		d, err := svc.Allow("agent", "github", "github__search_code")
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertTrue(t, d.Allowed, "expected call within the quota to be allowed")
+	}
+	daily, _, err := svc.GetClientQuotaUsage("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), daily)
+
+	// two calls that were both allowed can't both be charged once the quota is exhausted
+	d, err := svc.ChargeQuota("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, d.Allowed, "expected first call to be charged")
+	d, err = svc.ChargeQuota("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, d.Allowed, "expected second call to be rejected")
+	testhelpers.AssertStringContains(t, d.Reason, "daily quota")
+
+	daily, _, err = svc.GetClientQuotaUsage("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(1), daily)
+}
+
+func TestDeleteRateLimitsOfRemovedEntities(t *testing.T) {
+	setup, svc, _ := setupRateLimitTest(t)
+	defer setup.Cleanup()
+
+	for _, l := range []*model.RateLimit{
+		{Scope: types.RateLimitScopeClient, Target: "agent", RequestsPerMinute: 10, DailyQuota: 5},
+		{Scope: types.RateLimitScopeServer, Target: "github", RequestsPerMinute: 10},
+		{Scope: types.RateLimitScopeTool, Target: "github__search_code", RequestsPerMinute: 10},
+		{Scope: types.RateLimitScopeTool, Target: "github_enterprise__search_code", RequestsPerMinute: 10},
+	} {
+		testhelpers.AssertNoError(t, svc.SetRateLimit(l))
+	}
+	testhelpers.AssertTrue(t, allowAndCharge(t, svc, "agent").Allowed, "expected call to be allowed")
+
+	testhelpers.AssertNoError(t, svc.DeleteServerRateLimits("github"))
+	limits, err := svc.ListRateLimits()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(limits))
+	testhelpers.AssertEqual(t, "agent", limits[0].Target)
+	testhelpers.AssertEqual(t, "github_enterprise__search_code", limits[1].Target)
+
+	testhelpers.AssertNoError(t, svc.DeleteClientRateLimits("agent"))
+	limits, err = svc.ListRateLimits()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(limits))
+	daily, _, err := svc.GetClientQuotaUsage("agent")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, int64(0), daily)
+}
@@ -0,0 +1,212 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Embedder computes vector embeddings of texts for semantic search.
+type Embedder interface {
+	// Name identifies the embedder and its model.
+	// Embeddings computed by a different embedder are not comparable and are recomputed.
+	Name() string
+
+	// Embed returns one embedding per text, in the same order as the texts.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// DefaultLocalEmbedderDimensions is the number of dimensions of the vectors computed by the local embedder
+const DefaultLocalEmbedderDimensions = 1024
+
+// LocalEmbedder computes embeddings offline by hashing the words of a text and their character trigrams
+// into a fixed number of dimensions.
+// It doesn't understand synonyms like a language model does, but it matches words and word fragments
+// regardless of their order and tolerates small typos.
+// It is useful in tests and air-gapped deployments.
+type LocalEmbedder struct {
+	dimensions int
+}
+
+// NewLocalEmbedder creates a LocalEmbedder that computes vectors with the given number of dimensions.
+func NewLocalEmbedder(dimensions int) *LocalEmbedder {
+	if dimensions <= 0 {
+		dimensions = DefaultLocalEmbedderDimensions
+	}
+	return &LocalEmbedder{dimensions: dimensions}
+}
+
+func (e *LocalEmbedder) Name() string {
+	return fmt.Sprintf("local-hash-%d", e.dimensions)
+}
+
+func (e *LocalEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, t := range texts {
+		vectors[i] = e.embed(t)
+	}
+	return vectors, nil
+}
+
+func (e *LocalEmbedder) embed(text string) []float32 {
+	// count the features of the text: whole words weigh more than their trigrams
+	features := make(map[string]float64)
+	for _, w := range tokenize(text) {
+		features["w:"+w] += 1
+		padded := []rune("^" + w + "$")
+		for i := 0; i+3 <= len(padded); i++ {
+			features["t:"+string(padded[i:i+3])] += 0.5
+		}
+	}
+
+	v := make([]float32, e.dimensions)
+	for f, count := range features {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(f))
+		sum := h.Sum64()
+		// the sign bit reduces the bias introduced by features that collide in the same dimension
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		// sublinear term frequency, so that repeating a word doesn't dominate the vector
+		v[sum%uint64(e.dimensions)] += sign * float32(1+math.Log(count+1))
+	}
+	normalize(v)
+	return v
+}
+
+// DefaultOpenAIEmbeddingURL is the base URL of the OpenAI API
+const DefaultOpenAIEmbeddingURL = "https://api.openai.com/v1"
+
+// DefaultOpenAIEmbeddingModel is the embedding model used if none is specified
+const DefaultOpenAIEmbeddingModel = "text-embedding-3-small"
+
+// OpenAIEmbedder computes embeddings using an OpenAI-compatible embeddings API (POST <base url>/embeddings).
+// Besides OpenAI, many self-hosted model servers (eg- Ollama, vLLM, LocalAI) implement this API.
+type OpenAIEmbedder struct {
+	baseURL    string
+	model      string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewOpenAIEmbedder creates an OpenAIEmbedder.
+// baseURL is the URL the API's paths are relative to, eg- "https://api.openai.com/v1".
+// apiKey is optional, because self-hosted servers often don't require one.
+func NewOpenAIEmbedder(baseURL, model, apiKey string) *OpenAIEmbedder {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIEmbeddingURL
+	}
+	if model == "" {
+		model = DefaultOpenAIEmbeddingModel
+	}
+	return &OpenAIEmbedder{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		model:      model,
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (e *OpenAIEmbedder) Name() string {
+	return "openai:" + e.model
+}
+
+type openAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []openAIEmbedding `json:"data"`
+}
+
+type openAIEmbedding struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(openAIEmbeddingRequest{Model: e.model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send embedding request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embedding request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var out openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+	if len(out.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(out.Data))
+	}
+	// the embeddings aren't guaranteed to be in the order of the input
+	slices.SortFunc(out.Data, func(a, b openAIEmbedding) int {
+		return a.Index - b.Index
+	})
+
+	vectors := make([][]float32, len(out.Data))
+	for i, d := range out.Data {
+		vectors[i] = d.Embedding
+	}
+	return vectors, nil
+}
+
+// normalize scales v to unit length in place.
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= norm
+	}
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0 if they can't be compared.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
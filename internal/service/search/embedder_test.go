@@ -0,0 +1,80 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalEmbedder(t *testing.T) {
+	e := NewLocalEmbedder(256)
+	assert.Equal(t, "local-hash-256", e.Name())
+
+	vectors, err := e.Embed(context.Background(), []string{
+		"create a pull request",
+		"createPullRequest",
+		"send a slack message",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 3)
+	assert.Len(t, vectors[0], 256)
+
+	similar := cosineSimilarity(vectors[0], vectors[1])
+	different := cosineSimilarity(vectors[0], vectors[2])
+	assert.Greater(t, similar, different)
+	assert.InDelta(t, 1.0, cosineSimilarity(vectors[0], vectors[0]), 1e-6)
+}
+
+func TestOpenAIEmbedder(t *testing.T) {
+	var gotAuth string
+	var gotReq openAIEmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+
+		// return the embeddings out of order to check that they are sorted by index
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"index": 1, "embedding": []float32{0, 1}},
+				{"index": 0, "embedding": []float32{1, 0}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	e := NewOpenAIEmbedder(server.URL+"/v1/", "test-model", "secret")
+	assert.Equal(t, "openai:test-model", e.Name())
+
+	vectors, err := e.Embed(context.Background(), []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vectors)
+	assert.Equal(t, "Bearer secret", gotAuth)
+	assert.Equal(t, "test-model", gotReq.Model)
+	assert.Equal(t, []string{"a", "b"}, gotReq.Input)
+
+	t.Run("errors are returned", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid api key", http.StatusUnauthorized)
+		}))
+		defer failing.Close()
+
+		_, err := NewOpenAIEmbedder(failing.URL, "", "").Embed(context.Background(), []string{"a"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid api key")
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		e := NewOpenAIEmbedder("", "", "")
+		assert.Equal(t, DefaultOpenAIEmbeddingURL, e.baseURL)
+		assert.Equal(t, "openai:"+DefaultOpenAIEmbeddingModel, e.Name())
+	})
+}
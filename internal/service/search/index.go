@@ -0,0 +1,262 @@
+package search
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// BM25 parameters, see https://en.wikipedia.org/wiki/Okapi_BM25
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Weights of the fields of a tool. Matches in a tool's name are the strongest signal of relevance.
+const (
+	fieldWeightName        = 3.0
+	fieldWeightServer      = 1.0
+	fieldWeightDescription = 1.0
+	fieldWeightParameters  = 0.5
+)
+
+// Weights of the index terms that a query term is expanded to.
+// Fuzzy matches rank below exact matches, so that typos don't outrank what the user actually typed.
+const (
+	matchWeightExact  = 1.0
+	matchWeightPrefix = 0.7
+	matchWeightFuzzy  = 0.5
+)
+
+// docKey identifies a document in the BM25 index by its own name and the name of the MCP server that provides it.
+// The server is empty for documents that don't belong to a server, like MCP servers themselves and tool groups.
+type docKey struct {
+	server string
+	name   string
+}
+
+// field is a piece of the text of a document, with the weight of the matches in it.
+type field struct {
+	text   string
+	weight float64
+}
+
+// indexedDoc is a document in the BM25 index, eg- a tool.
+type indexedDoc struct {
+	key         docKey
+	description string
+	enabled     bool
+
+	// terms holds the weighted frequency of each term in the document's fields
+	terms map[string]float64
+	// length is the weighted number of terms in the document's fields
+	length float64
+}
+
+// bm25Index is an in-memory inverted index of documents, ranked with BM25F:
+// term frequencies and document lengths are the weighted sums of those of the document's fields.
+// It is not safe for concurrent use.
+type bm25Index struct {
+	docs map[docKey]*indexedDoc
+	// postings maps each term to the documents it occurs in and its weighted frequency in them
+	postings    map[string]map[docKey]float64
+	totalLength float64
+}
+
+func newBM25Index() *bm25Index {
+	return &bm25Index{
+		docs:     make(map[docKey]*indexedDoc),
+		postings: make(map[string]map[docKey]float64),
+	}
+}
+
+// addTool indexes a tool, replacing the existing entry of a tool with the same server and name.
+func (ix *bm25Index) addTool(serverName string, t *model.Tool) {
+	fields := []field{
+		{text: t.Name, weight: fieldWeightName},
+		{text: serverName, weight: fieldWeightServer},
+		{text: t.Description, weight: fieldWeightDescription},
+	}
+	for _, p := range inputParameters(t) {
+		fields = append(fields, field{text: p.name + " " + p.description, weight: fieldWeightParameters})
+	}
+	ix.add(docKey{server: serverName, name: t.Name}, t.Description, t.Enabled, fields...)
+}
+
+// add indexes a document, replacing the existing document with the same key.
+func (ix *bm25Index) add(key docKey, description string, enabled bool, fields ...field) {
+	ix.remove(key)
+
+	doc := &indexedDoc{
+		key:         key,
+		description: description,
+		enabled:     enabled,
+		terms:       make(map[string]float64),
+	}
+	for _, f := range fields {
+		for _, term := range tokenize(f.text) {
+			doc.terms[term] += f.weight
+			doc.length += f.weight
+		}
+	}
+
+	ix.docs[key] = doc
+	ix.totalLength += doc.length
+	for term, tf := range doc.terms {
+		p, ok := ix.postings[term]
+		if !ok {
+			p = make(map[docKey]float64)
+			ix.postings[term] = p
+		}
+		p[key] = tf
+	}
+}
+
+// remove deletes a document from the index. It is a no-op if the document isn't indexed.
+func (ix *bm25Index) remove(key docKey) {
+	doc, ok := ix.docs[key]
+	if !ok {
+		return
+	}
+	for term := range doc.terms {
+		p := ix.postings[term]
+		delete(p, key)
+		if len(p) == 0 {
+			delete(ix.postings, term)
+		}
+	}
+	ix.totalLength -= doc.length
+	delete(ix.docs, key)
+}
+
+// search returns the BM25 score of every document that matches at least one of the query terms.
+func (ix *bm25Index) search(queryTerms []string) map[docKey]float64 {
+	scores := make(map[docKey]float64)
+	if len(ix.docs) == 0 {
+		return scores
+	}
+	n := float64(len(ix.docs))
+	avgLength := ix.totalLength / n
+
+	for _, qt := range queryTerms {
+		// a query term only counts once per document, with its best matching index term
+		best := make(map[docKey]float64)
+		for term, weight := range ix.expand(qt) {
+			p := ix.postings[term]
+			idf := math.Log(1 + (n-float64(len(p))+0.5)/(float64(len(p))+0.5))
+			for key, tf := range p {
+				norm := tf + bm25K1*(1-bm25B+bm25B*ix.docs[key].length/avgLength)
+				s := weight * idf * tf * (bm25K1 + 1) / norm
+				if s > best[key] {
+					best[key] = s
+				}
+			}
+		}
+		for key, s := range best {
+			scores[key] += s
+		}
+	}
+	return scores
+}
+
+// expand returns the index terms that match a query term and the weight of each match.
+// Besides the term itself, it matches terms that start with it (eg- "repo" matches "repository")
+// and terms within a small edit distance to tolerate typos (eg- "serach" matches "search").
+func (ix *bm25Index) expand(queryTerm string) map[string]float64 {
+	matches := make(map[string]float64)
+	if _, ok := ix.postings[queryTerm]; ok {
+		matches[queryTerm] = matchWeightExact
+	}
+
+	qLen := len([]rune(queryTerm))
+	maxDistance := 0
+	switch {
+	case qLen >= 8:
+		maxDistance = 2
+	case qLen >= 4:
+		maxDistance = 1
+	}
+
+	for term := range ix.postings {
+		if term == queryTerm {
+			continue
+		}
+		if qLen >= 3 && strings.HasPrefix(term, queryTerm) {
+			matches[term] = matchWeightPrefix
+			continue
+		}
+		if maxDistance > 0 && editDistanceWithin(queryTerm, term, maxDistance) {
+			matches[term] = matchWeightFuzzy
+		}
+	}
+	return matches
+}
+
+// editDistanceWithin reports whether the optimal string alignment distance between a and b is at most maxDist,
+// ie, whether b can be obtained from a with at most maxDist insertions, deletions, substitutions
+// or transpositions of adjacent characters.
+func editDistanceWithin(a, b string, maxDist int) bool {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > maxDist || -d > maxDist {
+		return false
+	}
+
+	// rows i-2, i-1 and i of the dynamic programming matrix
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, cur[j])
+		}
+		if rowMin > maxDist {
+			return false
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(rb)] <= maxDist
+}
+
+type inputParameter struct {
+	name        string
+	description string
+}
+
+// inputParameters returns the names and descriptions of a tool's input parameters, sorted by name.
+func inputParameters(t *model.Tool) []inputParameter {
+	if len(t.InputSchema) == 0 {
+		return nil
+	}
+	var schema struct {
+		Properties map[string]struct {
+			Description string `json:"description"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(t.InputSchema, &schema); err != nil {
+		return nil
+	}
+	params := make([]inputParameter, 0, len(schema.Properties))
+	for name, p := range schema.Properties {
+		params = append(params, inputParameter{name: name, description: p.Description})
+	}
+	slices.SortFunc(params, func(a, b inputParameter) int {
+		return strings.Compare(a.name, b.name)
+	})
+	return params
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistanceWithin(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		maxDist int
+		want    bool
+	}{
+		{"search", "search", 0, true},
+		{"serach", "search", 1, true}, // transposition
+		{"seach", "search", 1, true},  // deletion
+		{"searchh", "search", 1, true},
+		{"saerhc", "search", 1, false},
+		{"repositry", "repository", 1, true},
+		{"repostry", "repository", 2, true},
+		{"repo", "repository", 2, false},
+		{"commit", "branch", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, editDistanceWithin(tt.a, tt.b, tt.maxDist))
+		})
+	}
+}
+
+func TestBM25Index(t *testing.T) {
+	schema, _ := json.Marshal(map[string]any{
+		"properties": map[string]any{
+			"branch": map[string]any{"type": "string", "description": "Name of the branch"},
+		},
+	})
+
+	ix := newBM25Index()
+	ix.addTool("git", &model.Tool{Name: "checkout_branch", Description: "Switch to another branch"})
+	ix.addTool("git", &model.Tool{Name: "log", Description: "Show the commit log", InputSchema: schema})
+	ix.addTool("git", &model.Tool{Name: "commit", Description: "Record changes to the repository"})
+
+	key := func(name string) docKey { return docKey{server: "git", name: name} }
+
+	t.Run("matches in names rank above matches in descriptions and parameters", func(t *testing.T) {
+		scores := ix.search([]string{"branch"})
+		assert.Len(t, scores, 2)
+		assert.Greater(t, scores[key("checkout_branch")], scores[key("log")])
+	})
+
+	t.Run("rare terms weigh more than common ones", func(t *testing.T) {
+		scores := ix.search([]string{"commit"})
+		assert.Greater(t, scores[key("commit")], scores[key("log")])
+	})
+
+	t.Run("prefixes and typos match", func(t *testing.T) {
+		assert.Contains(t, ix.search([]string{"repo"}), key("commit"))
+		assert.Contains(t, ix.search([]string{"chekout"}), key("checkout_branch"))
+		assert.Empty(t, ix.search([]string{"merge"}))
+	})
+
+	t.Run("exact matches rank above fuzzy ones", func(t *testing.T) {
+		ix.addTool("git", &model.Tool{Name: "logs", Description: "Show logs"})
+		scores := ix.search([]string{"log"})
+		assert.Greater(t, scores[key("log")], scores[key("logs")])
+		ix.remove(key("logs"))
+	})
+
+	t.Run("removed tools are not found", func(t *testing.T) {
+		ix.remove(key("commit"))
+		assert.NotContains(t, ix.search([]string{"commit"}), key("commit"))
+		assert.NotContains(t, ix.postings, "repository")
+	})
+}
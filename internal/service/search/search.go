@@ -1,7 +1,10 @@
 package search
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 
@@ -12,13 +15,27 @@ import (
 // SearchService provides search functionality for tools
 type SearchService struct {
 	db *gorm.DB
+	// mu guards the embedder
 	mu sync.RWMutex
+
+	// embedder computes the embeddings used by semantic search
+	embedder Embedder
+	// syncMu serializes the updates of the stored tool embeddings
+	syncMu sync.Mutex
+
+	// index is the keyword search index. It is nil until the first search.
+	index *bm25Index
+	// indexVersion is the version of the tools table the index was built from
+	indexVersion toolsVersion
+	indexMu      sync.Mutex
 }
 
-// NewSearchService creates a new SearchService
+// NewSearchService creates a new SearchService.
+// Semantic search uses the local embedder until a different one is set with SetEmbedder.
 func NewSearchService(db *gorm.DB) *SearchService {
 	return &SearchService{
-		db: db,
+		db:       db,
+		embedder: NewLocalEmbedder(DefaultLocalEmbedderDimensions),
 	}
 }
 
@@ -37,9 +54,18 @@ type SearchOptions struct {
 	MaxResults  int      `json:"max_results,omitempty"`
 	ServerNames []string `json:"server_names,omitempty"`
 	OnlyEnabled bool     `json:"only_enabled,omitempty"`
+
+	// Mode selects keyword or semantic search. It is only used by Search, and defaults to keyword search.
+	Mode SearchMode `json:"mode,omitempty"`
+
+	// Allow, if set, is called with the canonical name of each matching tool.
+	// Tools for which it returns false are left out of the results, eg- because the caller may not access them.
+	Allow func(toolName string) bool `json:"-"`
 }
 
-// SearchTools performs a keyword search across all tools
+// SearchTools performs a keyword search across all tools.
+// Tools are ranked with BM25 over the words of their names, descriptions and input parameters.
+// Query words also match words that start with them and, to tolerate typos, words that are spelled similarly.
 func (s *SearchService) SearchTools(opts SearchOptions) ([]SearchResult, error) {
 	if opts.Query == "" {
 		return nil, fmt.Errorf("search query cannot be empty")
@@ -50,51 +76,44 @@ func (s *SearchService) SearchTools(opts SearchOptions) ([]SearchResult, error)
 		opts.MaxResults = 20
 	}
 
-	// Normalize the query for case-insensitive search
-	query := strings.ToLower(opts.Query)
-	terms := strings.Fields(query) // Split into individual terms
-
-	// Build the base query
-	tx := s.db.Table("tools").
-		Select("tools.*, mcp_servers.name as server_name").
-		Joins("LEFT JOIN mcp_servers ON tools.server_id = mcp_servers.id")
-
-	// Apply filters
-	if opts.OnlyEnabled {
-		tx = tx.Where("tools.enabled = ?", true)
-	}
-
-	if len(opts.ServerNames) > 0 {
-		tx = tx.Where("mcp_servers.name IN ?", opts.ServerNames)
-	}
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
 
-	// Fetch all matching tools
-	var rawResults []struct {
-		model.Tool
-		ServerName string `gorm:"column:server_name"`
+	if err := s.ensureIndex(); err != nil {
+		return nil, err
 	}
 
-	if err := tx.Find(&rawResults).Error; err != nil {
-		return nil, fmt.Errorf("failed to search tools: %w", err)
+	servers := make(map[string]bool, len(opts.ServerNames))
+	for _, name := range opts.ServerNames {
+		servers[name] = true
 	}
 
-	// Score and rank results
 	results := make([]SearchResult, 0)
-	for _, raw := range rawResults {
-		score := s.calculateScore(raw.Name, raw.Description, terms)
-		if score > 0 {
-			results = append(results, SearchResult{
-				ToolName:    fmt.Sprintf("%s__%s", raw.ServerName, raw.Name),
-				ServerName:  raw.ServerName,
-				Description: raw.Description,
-				Score:       score,
-				Enabled:     raw.Enabled,
-			})
+	for key, score := range s.index.search(tokenize(opts.Query)) {
+		doc := s.index.docs[key]
+		if opts.OnlyEnabled && !doc.enabled {
+			continue
 		}
+		if len(servers) > 0 && !servers[key.server] {
+			continue
+		}
+		toolName := fmt.Sprintf("%s__%s", key.server, key.name)
+		if opts.Allow != nil && !opts.Allow(toolName) {
+			continue
+		}
+		results = append(results, SearchResult{
+			ToolName:    toolName,
+			ServerName:  key.server,
+			Description: doc.description,
+			Score:       score,
+			Enabled:     doc.enabled,
+		})
 	}
 
 	// Sort by score (highest first)
-	s.sortByScore(results)
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), strings.Compare(a.ToolName, b.ToolName))
+	})
 
 	// Limit results
 	if len(results) > opts.MaxResults {
@@ -104,63 +123,87 @@ func (s *SearchService) SearchTools(opts SearchOptions) ([]SearchResult, error)
 	return results, nil
 }
 
-// calculateScore calculates a relevance score for a tool based on search terms
-func (s *SearchService) calculateScore(name, description string, terms []string) float64 {
-	nameLower := strings.ToLower(name)
-	descLower := strings.ToLower(description)
-	
-	var totalScore float64
-	
-	for _, term := range terms {
-		termScore := 0.0
-		
-		// Exact match in name gets highest score
-		if nameLower == term {
-			termScore += 10.0
-		} else if strings.Contains(nameLower, term) {
-			// Partial match in name
-			termScore += 5.0
-		}
-		
-		// Match in description
-		if strings.Contains(descLower, term) {
-			// Count occurrences
-			count := strings.Count(descLower, term)
-			termScore += float64(count) * 1.0
-		}
-		
-		totalScore += termScore
+// RefreshTool updates a tool in the keyword search index after it was added, changed or removed.
+// It is cheaper than rebuilding the index and should be called whenever the tools in the registry change.
+func (s *SearchService) RefreshTool(serverName, toolName string) error {
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+
+	if s.index == nil {
+		// the index is built from scratch on the first search
+		return nil
 	}
-	
-	// Normalize by number of terms to favor matches for all terms
-	if len(terms) > 0 {
-		totalScore = totalScore / float64(len(terms))
+
+	var tool model.Tool
+	err := s.db.Model(&model.Tool{}).
+		Joins("JOIN mcp_servers ON tools.server_id = mcp_servers.id").
+		Where("mcp_servers.name = ? AND tools.name = ?", serverName, toolName).
+		Take(&tool).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		s.index.remove(docKey{server: serverName, name: toolName})
+	case err != nil:
+		return fmt.Errorf("failed to get tool %s of server %s: %w", toolName, serverName, err)
+	default:
+		s.index.addTool(serverName, &tool)
 	}
-	
-	return totalScore
+
+	s.indexVersion, err = s.toolsVersion()
+	return err
 }
 
-// sortByScore sorts results by score in descending order
-func (s *SearchService) sortByScore(results []SearchResult) {
-	// Simple bubble sort for now (can be optimized if needed)
-	n := len(results)
-	for i := 0; i < n-1; i++ {
-		for j := 0; j < n-i-1; j++ {
-			if results[j].Score < results[j+1].Score {
-				results[j], results[j+1] = results[j+1], results[j]
-			}
-		}
+// ensureIndex builds the keyword search index if it hasn't been built yet,
+// or rebuilds it if the tools were changed without refreshing it, eg- by another mcpjungle instance
+// sharing the same database.
+// The caller must hold s.indexMu.
+func (s *SearchService) ensureIndex() error {
+	version, err := s.toolsVersion()
+	if err != nil {
+		return err
+	}
+	if s.index != nil && version == s.indexVersion {
+		return nil
+	}
+
+	var tools []struct {
+		model.Tool
+		ServerName string `gorm:"column:server_name"`
+	}
+	err = s.db.Model(&model.Tool{}).
+		Select("tools.*, mcp_servers.name as server_name").
+		Joins("JOIN mcp_servers ON tools.server_id = mcp_servers.id").
+		Find(&tools).Error
+	if err != nil {
+		return fmt.Errorf("failed to load tools into the search index: %w", err)
+	}
+
+	index := newBM25Index()
+	for i := range tools {
+		index.addTool(tools[i].ServerName, &tools[i].Tool)
+	}
+	s.index = index
+	s.indexVersion = version
+	return nil
+}
+
+// toolsVersion summarizes the state of the tools table.
+// Tools are deleted from the database and recreated when they change, and enabling or disabling
+// a tool changes the number of enabled tools, so the summary changes whenever the index becomes outdated.
+func (s *SearchService) toolsVersion() (toolsVersion, error) {
+	var v toolsVersion
+	err := s.db.Model(&model.Tool{}).
+		Select("COUNT(*) AS count, COALESCE(MAX(id), 0) AS max_id, " +
+			"COALESCE(SUM(CASE WHEN enabled THEN 1 ELSE 0 END), 0) AS enabled").
+		Scan(&v).Error
+	if err != nil {
+		return toolsVersion{}, fmt.Errorf("failed to check for changes to tools: %w", err)
 	}
+	return v, nil
 }
 
-// SemanticSearchTools performs semantic search using embeddings (future enhancement)
-// For now, it delegates to keyword search
-func (s *SearchService) SemanticSearchTools(opts SearchOptions) ([]SearchResult, error) {
-	// TODO: Implement semantic search using embeddings
-	// This would involve:
-	// 1. Computing embeddings for tool names and descriptions
-	// 2. Computing embedding for the query
-	// 3. Finding tools with highest cosine similarity
-	// For now, we delegate to keyword search
-	return s.SearchTools(opts)
+// toolsVersion is a summary of the tools table used to detect changes to it.
+type toolsVersion struct {
+	Count   int64
+	MaxID   int64
+	Enabled int64
 }
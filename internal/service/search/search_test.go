@@ -193,78 +193,78 @@ func TestSearchService_SearchTools(t *testing.T) {
 	})
 }
 
-func TestSearchService_CalculateScore(t *testing.T) {
-	service := &SearchService{}
+func TestSearchService_SearchToolsIndex(t *testing.T) {
+	db := setupSemanticTestDB(t)
+	service := NewSearchService(db)
 
-	tests := []struct {
-		name        string
-		toolName    string
-		description string
-		terms       []string
-		minScore    float64
-	}{
-		{
-			name:        "Exact name match",
-			toolName:    "commit",
-			description: "Create a git commit",
-			terms:       []string{"commit"},
-			minScore:    10.0,
-		},
-		{
-			name:        "Partial name match",
-			toolName:    "git_commit",
-			description: "Create a commit",
-			terms:       []string{"commit"},
-			minScore:    5.0,
-		},
-		{
-			name:        "Description match",
-			toolName:    "create",
-			description: "commit changes to repository",
-			terms:       []string{"commit"},
-			minScore:    1.0,
-		},
-		{
-			name:        "Multiple term matches",
-			toolName:    "git_commit",
-			description: "Create a git commit with message",
-			terms:       []string{"git", "commit"},
-			minScore:    3.0, // Average of matches
-		},
-		{
-			name:        "No match",
-			toolName:    "branch",
-			description: "Switch branches",
-			terms:       []string{"commit"},
-			minScore:    0.0,
-		},
+	search := func(query string) []SearchResult {
+		results, err := service.SearchTools(SearchOptions{Query: query})
+		require.NoError(t, err)
+		return results
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			score := service.calculateScore(tt.toolName, tt.description, tt.terms)
-			assert.GreaterOrEqual(t, score, tt.minScore)
+	t.Run("Matches camelCase names", func(t *testing.T) {
+		results := search("issues")
+		require.NotEmpty(t, results)
+		assert.Equal(t, "github__listIssues", results[0].ToolName)
+	})
+
+	t.Run("Matches parameters", func(t *testing.T) {
+		results := search("owner")
+		require.Len(t, results, 2)
+		for _, r := range results {
+			assert.Equal(t, "github", r.ServerName)
+		}
+	})
+
+	t.Run("Tolerates typos and prefixes", func(t *testing.T) {
+		results := search("mesage")
+		require.NotEmpty(t, results)
+		assert.Equal(t, "slack__post_message", results[0].ToolName)
+
+		results = search("pul req")
+		require.NotEmpty(t, results)
+		assert.Equal(t, "github__create_pull_request", results[0].ToolName)
+	})
+
+	t.Run("Leaves out tools that are not allowed", func(t *testing.T) {
+		results, err := service.SearchTools(SearchOptions{
+			Query: "owner",
+			Allow: func(toolName string) bool { return toolName == "github__listIssues" },
 		})
-	}
-}
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "github__listIssues", results[0].ToolName)
+	})
 
-func TestSearchService_SortByScore(t *testing.T) {
-	service := &SearchService{}
+	t.Run("Refreshed tools are updated in the index", func(t *testing.T) {
+		require.NoError(t, db.Model(&model.Tool{}).Where("name = ?", "post_message").
+			Update("description", "Send a chat notification").Error)
+		require.NoError(t, service.RefreshTool("slack", "post_message"))
 
-	results := []SearchResult{
-		{ToolName: "tool1", Score: 2.0},
-		{ToolName: "tool2", Score: 5.0},
-		{ToolName: "tool3", Score: 1.0},
-		{ToolName: "tool4", Score: 8.0},
-		{ToolName: "tool5", Score: 3.0},
-	}
+		results := search("notification")
+		require.Len(t, results, 1)
+		assert.Equal(t, "slack__post_message", results[0].ToolName)
+	})
+
+	t.Run("Removed tools are removed from the index", func(t *testing.T) {
+		require.NoError(t, db.Unscoped().Where("name = ?", "post_message").Delete(&model.Tool{}).Error)
+		require.NoError(t, service.RefreshTool("slack", "post_message"))
+		assert.Empty(t, search("notification"))
+	})
+
+	t.Run("Index is rebuilt after changes it was not notified of", func(t *testing.T) {
+		var slack model.McpServer
+		require.NoError(t, db.Where("name = ?", "slack").First(&slack).Error)
+		require.NoError(t, db.Create(&model.Tool{ServerID: slack.ID, Name: "add_reaction", Description: "React with an emoji"}).Error)
 
-	service.sortByScore(results)
+		results := search("emoji")
+		require.Len(t, results, 1)
+		assert.Equal(t, "slack__add_reaction", results[0].ToolName)
 
-	// Verify descending order
-	assert.Equal(t, 8.0, results[0].Score)
-	assert.Equal(t, 5.0, results[1].Score)
-	assert.Equal(t, 3.0, results[2].Score)
-	assert.Equal(t, 2.0, results[3].Score)
-	assert.Equal(t, 1.0, results[4].Score)
+		require.NoError(t, db.Model(&model.Tool{}).Where("name = ?", "add_reaction").Update("enabled", false).Error)
+		results, err := service.SearchTools(SearchOptions{Query: "emoji", OnlyEnabled: true})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
 }
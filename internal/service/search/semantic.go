@@ -0,0 +1,289 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+// embeddingBatchSize is the maximum number of tools embedded in a single call to the embedder
+const embeddingBatchSize = 64
+
+// SearchMode selects how tools are matched against a search query.
+type SearchMode string
+
+const (
+	// SearchModeKeyword matches the words of the query against the names and descriptions of tools.
+	SearchModeKeyword SearchMode = "keyword"
+	// SearchModeSemantic ranks tools by the cosine similarity of their embeddings to the query's embedding.
+	SearchModeSemantic SearchMode = "semantic"
+)
+
+// ValidateSearchMode returns the search mode with the given name.
+// An empty name selects SearchModeKeyword.
+func ValidateSearchMode(name string) (SearchMode, error) {
+	switch m := SearchMode(strings.ToLower(name)); m {
+	case "":
+		return SearchModeKeyword, nil
+	case SearchModeKeyword, SearchModeSemantic:
+		return m, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid search mode '%s', valid values are '%s' and '%s'", name, SearchModeKeyword, SearchModeSemantic,
+		)
+	}
+}
+
+// Search searches tools using the mode selected in the options.
+func (s *SearchService) Search(ctx context.Context, opts SearchOptions) ([]SearchResult, error) {
+	if opts.Mode == SearchModeSemantic {
+		return s.SemanticSearchTools(ctx, opts)
+	}
+	return s.SearchTools(opts)
+}
+
+// SetEmbedder changes the embedder used by semantic search.
+// Existing embeddings computed by a different embedder are recomputed on the next sync.
+func (s *SearchService) SetEmbedder(e Embedder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.embedder = e
+}
+
+// SemanticSearchTools ranks tools by the cosine similarity of their embeddings to the embedding of the query.
+// Only tools whose embeddings were stored by SyncEmbeddings or SyncServerEmbeddings are found.
+func (s *SearchService) SemanticSearchTools(ctx context.Context, opts SearchOptions) ([]SearchResult, error) {
+	if opts.Query == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = 20
+	}
+
+	embedder := s.currentEmbedder()
+
+	queryVectors, err := embedder.Embed(ctx, []string{opts.Query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed search query: %w", err)
+	}
+	queryVector := queryVectors[0]
+
+	tx := s.db.Model(&model.ToolEmbedding{}).
+		Select("tool_embeddings.vector, tools.name, tools.description, tools.enabled, mcp_servers.name as server_name").
+		Joins("JOIN tools ON tools.id = tool_embeddings.tool_id").
+		Joins("JOIN mcp_servers ON tools.server_id = mcp_servers.id").
+		Where("tool_embeddings.embedder = ?", embedder.Name())
+	if opts.OnlyEnabled {
+		tx = tx.Where("tools.enabled = ?", true)
+	}
+	if len(opts.ServerNames) > 0 {
+		tx = tx.Where("mcp_servers.name IN ?", opts.ServerNames)
+	}
+
+	var rows []struct {
+		Vector      []byte
+		Name        string
+		Description string
+		Enabled     bool
+		ServerName  string
+	}
+	if err := tx.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to search tools: %w", err)
+	}
+
+	results := make([]SearchResult, 0)
+	for _, r := range rows {
+		toolName := fmt.Sprintf("%s__%s", r.ServerName, r.Name)
+		if opts.Allow != nil && !opts.Allow(toolName) {
+			continue
+		}
+		score := cosineSimilarity(queryVector, decodeVector(r.Vector))
+		if score <= 0 {
+			continue
+		}
+		results = append(results, SearchResult{
+			ToolName:    toolName,
+			ServerName:  r.ServerName,
+			Description: r.Description,
+			Score:       score,
+			Enabled:     r.Enabled,
+		})
+	}
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), strings.Compare(a.ToolName, b.ToolName))
+	})
+	if len(results) > opts.MaxResults {
+		results = results[:opts.MaxResults]
+	}
+	return results, nil
+}
+
+// currentEmbedder returns the embedder used by semantic search.
+func (s *SearchService) currentEmbedder() Embedder {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.embedder
+}
+
+// SyncEmbeddings brings the stored embeddings of all tools up to date.
+// It computes the embeddings of tools that don't have one yet, whose name, description or input schema changed
+// or that were embedded by a different embedder, and deletes the embeddings of tools that no longer exist.
+// It reads all tools, so it is meant to be called once at startup, eg- after changing the embedder.
+func (s *SearchService) SyncEmbeddings(ctx context.Context) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if err := s.pruneEmbeddings(); err != nil {
+		return err
+	}
+	return s.syncEmbeddings(ctx, "")
+}
+
+// SyncServerEmbeddings brings the stored embeddings of the tools of an MCP server up to date,
+// eg- after the server was registered.
+func (s *SearchService) SyncServerEmbeddings(ctx context.Context, serverName string) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	return s.syncEmbeddings(ctx, serverName)
+}
+
+// syncEmbeddings computes the outdated embeddings of the tools of the given server, or of all tools if
+// serverName is empty. The caller must hold s.syncMu.
+// Searches are not blocked while the embedder runs, they keep using the previous embeddings.
+func (s *SearchService) syncEmbeddings(ctx context.Context, serverName string) error {
+	embedder := s.currentEmbedder()
+
+	var tools []struct {
+		model.Tool
+		ServerName string `gorm:"column:server_name"`
+	}
+	tx := s.db.Model(&model.Tool{}).
+		Select("tools.*, mcp_servers.name as server_name").
+		Joins("JOIN mcp_servers ON tools.server_id = mcp_servers.id")
+	if serverName != "" {
+		tx = tx.Where("mcp_servers.name = ?", serverName)
+	}
+	if err := tx.Find(&tools).Error; err != nil {
+		return fmt.Errorf("failed to get tools to embed: %w", err)
+	}
+
+	var existing []model.ToolEmbedding
+	tx = s.db.Select("tool_id", "embedder", "content_hash")
+	if serverName != "" {
+		ids := make([]uint, len(tools))
+		for i := range tools {
+			ids[i] = tools[i].ID
+		}
+		tx = tx.Where("tool_id IN ?", ids)
+	}
+	if err := tx.Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to get tool embeddings: %w", err)
+	}
+	current := make(map[uint]model.ToolEmbedding, len(existing))
+	for _, e := range existing {
+		current[e.ToolID] = e
+	}
+
+	var (
+		outdated []model.ToolEmbedding
+		texts    []string
+	)
+	name := embedder.Name()
+	for _, t := range tools {
+		text := toolEmbeddingText(t.ServerName, &t.Tool)
+		hash := contentHash(text)
+		if e, ok := current[t.ID]; ok && e.Embedder == name && e.ContentHash == hash {
+			continue
+		}
+		outdated = append(outdated, model.ToolEmbedding{ToolID: t.ID, Embedder: name, ContentHash: hash})
+		texts = append(texts, text)
+	}
+
+	for start := 0; start < len(outdated); start += embeddingBatchSize {
+		end := min(start+embeddingBatchSize, len(outdated))
+		vectors, err := embedder.Embed(ctx, texts[start:end])
+		if err != nil {
+			return fmt.Errorf("failed to embed tools: %w", err)
+		}
+		if len(vectors) != end-start {
+			return fmt.Errorf("embedder returned %d embeddings for %d tools", len(vectors), end-start)
+		}
+		for i, v := range vectors {
+			e := &outdated[start+i]
+			e.Vector = encodeVector(v)
+			err := s.db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "tool_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"embedder", "content_hash", "vector", "updated_at"}),
+			}).Create(e).Error
+			if err != nil {
+				return fmt.Errorf("failed to save embedding of tool %d: %w", e.ToolID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PruneEmbeddings deletes the embeddings of tools that no longer exist.
+func (s *SearchService) PruneEmbeddings() error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+	return s.pruneEmbeddings()
+}
+
+// pruneEmbeddings deletes the embeddings of tools that no longer exist. The caller must hold s.syncMu.
+func (s *SearchService) pruneEmbeddings() error {
+	err := s.db.Unscoped().
+		Where("tool_id NOT IN (?)", s.db.Model(&model.Tool{}).Select("id")).
+		Delete(&model.ToolEmbedding{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete embeddings of removed tools: %w", err)
+	}
+	return nil
+}
+
+// toolEmbeddingText returns the text that represents a tool for semantic search:
+// its canonical name, its description and the names and descriptions of its input parameters.
+func toolEmbeddingText(serverName string, t *model.Tool) string {
+	var b strings.Builder
+	b.WriteString(serverName + "__" + t.Name)
+	if t.Description != "" {
+		b.WriteString("\n" + t.Description)
+	}
+	for _, p := range inputParameters(t) {
+		b.WriteString("\n" + p.name)
+		if p.description != "" {
+			b.WriteString(": " + p.description)
+		}
+	}
+	return b.String()
+}
+
+func contentHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func encodeVector(v []float32) []byte {
+	b := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(x))
+	}
+	return b
+}
+
+func decodeVector(b []byte) []float32 {
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
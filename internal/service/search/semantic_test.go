@@ -0,0 +1,198 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// countingEmbedder wraps an embedder and counts the texts it embeds.
+type countingEmbedder struct {
+	Embedder
+	embedded int
+}
+
+func (e *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	e.embedded += len(texts)
+	return e.Embedder.Embed(ctx, texts)
+}
+
+func setupSemanticTestDB(t *testing.T) *gorm.DB {
+	db := setupTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.ToolEmbedding{}))
+
+	config, _ := json.Marshal(model.StdioConfig{Command: "mcp"})
+	servers := map[string]*model.McpServer{
+		"github": {Name: "github", Transport: types.TransportStdio, Config: datatypes.JSON(config)},
+		"slack":  {Name: "slack", Transport: types.TransportStdio, Config: datatypes.JSON(config)},
+	}
+	for _, s := range servers {
+		require.NoError(t, db.Create(s).Error)
+	}
+
+	schema, _ := json.Marshal(map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"repository": map[string]any{"type": "string", "description": "Owner and name of the repository"},
+		},
+	})
+	tools := []model.Tool{
+		{ServerID: servers["github"].ID, Name: "create_pull_request", Description: "Open a pull request to merge a branch", InputSchema: schema},
+		{ServerID: servers["github"].ID, Name: "listIssues", Description: "List the issues of a repository", InputSchema: schema},
+		{ServerID: servers["slack"].ID, Name: "post_message", Description: "Post a message to a channel"},
+	}
+	for i := range tools {
+		require.NoError(t, db.Create(&tools[i]).Error)
+	}
+	return db
+}
+
+func TestSemanticSearchTools(t *testing.T) {
+	db := setupSemanticTestDB(t)
+	service := NewSearchService(db)
+	ctx := context.Background()
+	require.NoError(t, service.SyncEmbeddings(ctx))
+
+	t.Run("ranks tools by similarity", func(t *testing.T) {
+		results, err := service.SemanticSearchTools(ctx, SearchOptions{Query: "open pull requests"})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "github__create_pull_request", results[0].ToolName)
+		assert.Equal(t, "github", results[0].ServerName)
+		for i := 1; i < len(results); i++ {
+			assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+		}
+	})
+
+	t.Run("matches camelCase names and parameters", func(t *testing.T) {
+		results, err := service.SemanticSearchTools(ctx, SearchOptions{Query: "issues of a repo"})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "github__listIssues", results[0].ToolName)
+	})
+
+	t.Run("tolerates typos", func(t *testing.T) {
+		results, err := service.SemanticSearchTools(ctx, SearchOptions{Query: "post mesage"})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "slack__post_message", results[0].ToolName)
+	})
+
+	t.Run("filters by server and limits results", func(t *testing.T) {
+		results, err := service.SemanticSearchTools(ctx, SearchOptions{
+			Query: "message", ServerNames: []string{"github"}, MaxResults: 1,
+		})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(results), 1)
+		for _, r := range results {
+			assert.Equal(t, "github", r.ServerName)
+		}
+	})
+
+	t.Run("empty query returns error", func(t *testing.T) {
+		_, err := service.SemanticSearchTools(ctx, SearchOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestSyncEmbeddingsIsIncremental(t *testing.T) {
+	db := setupSemanticTestDB(t)
+	service := NewSearchService(db)
+	embedder := &countingEmbedder{Embedder: NewLocalEmbedder(64)}
+	service.SetEmbedder(embedder)
+	ctx := context.Background()
+
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 3, embedder.embedded)
+
+	// nothing changed, so nothing is embedded again
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 3, embedder.embedded)
+
+	// a changed description is embedded again
+	require.NoError(t, db.Model(&model.Tool{}).Where("name = ?", "post_message").
+		Update("description", "Send a chat message").Error)
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 4, embedder.embedded)
+
+	// disabling a tool doesn't change its embedding
+	require.NoError(t, db.Model(&model.Tool{}).Where("name = ?", "listIssues").Update("enabled", false).Error)
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 4, embedder.embedded)
+
+	// the embeddings of deleted tools are removed
+	require.NoError(t, db.Unscoped().Where("name = ?", "listIssues").Delete(&model.Tool{}).Error)
+	require.NoError(t, service.PruneEmbeddings())
+	var count int64
+	require.NoError(t, db.Model(&model.ToolEmbedding{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	// switching to a different embedder recomputes all embeddings
+	other := &countingEmbedder{Embedder: NewLocalEmbedder(128)}
+	service.SetEmbedder(other)
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 2, other.embedded)
+}
+
+func TestSyncServerEmbeddings(t *testing.T) {
+	db := setupSemanticTestDB(t)
+	service := NewSearchService(db)
+	embedder := &countingEmbedder{Embedder: NewLocalEmbedder(64)}
+	service.SetEmbedder(embedder)
+	ctx := context.Background()
+
+	// searching doesn't embed any tools, only the query
+	results, err := service.SemanticSearchTools(ctx, SearchOptions{Query: "post message"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Equal(t, 1, embedder.embedded)
+
+	// only the tools of the given server are embedded
+	require.NoError(t, service.SyncServerEmbeddings(ctx, "slack"))
+	assert.Equal(t, 2, embedder.embedded)
+
+	results, err = service.SemanticSearchTools(ctx, SearchOptions{Query: "post message"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "slack__post_message", results[0].ToolName)
+
+	// the tools of the other servers are embedded by a full sync
+	require.NoError(t, service.SyncEmbeddings(ctx))
+	assert.Equal(t, 5, embedder.embedded)
+}
+
+func TestToolEmbeddingText(t *testing.T) {
+	schema, _ := json.Marshal(map[string]any{
+		"properties": map[string]any{
+			"query": map[string]any{"type": "string", "description": "Search query"},
+			"limit": map[string]any{"type": "integer"},
+		},
+	})
+	text := toolEmbeddingText("github", &model.Tool{Name: "search_code", Description: "Search code", InputSchema: schema})
+	assert.Equal(t, "github__search_code\nSearch code\nlimit\nquery: Search query", text)
+}
+
+func TestVectorEncoding(t *testing.T) {
+	v := []float32{0.5, -1.25, 3}
+	assert.Equal(t, v, decodeVector(encodeVector(v)))
+}
+
+func TestValidateSearchMode(t *testing.T) {
+	mode, err := ValidateSearchMode("")
+	require.NoError(t, err)
+	assert.Equal(t, SearchModeKeyword, mode)
+
+	mode, err = ValidateSearchMode("Semantic")
+	require.NoError(t, err)
+	assert.Equal(t, SearchModeSemantic, mode)
+
+	_, err = ValidateSearchMode("fuzzy")
+	assert.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits text into lowercase words.
+// Words are separated by anything that isn't a letter or a digit (eg- "_", "-", whitespace),
+// and camelCase words are split too, so "listPullRequests" becomes "list", "pull" and "requests".
+func tokenize(text string) []string {
+	var (
+		tokens []string
+		cur    []rune
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			tokens = append(tokens, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+
+	runes := []rune(text)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(cur) > 0 {
+			prev := cur[len(cur)-1]
+			// split "fooBar" before "B", and "HTTPServer" before "S"
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return tokens
+}
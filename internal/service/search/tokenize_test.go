@@ -0,0 +1,26 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"search_code", []string{"search", "code"}},
+		{"list-pull-requests", []string{"list", "pull", "requests"}},
+		{"listPullRequests", []string{"list", "pull", "requests"}},
+		{"getHTTPServer v2", []string{"get", "http", "server", "v2"}},
+		{"  Read a FILE!  ", []string{"read", "a", "file"}},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenize(tt.input))
+		})
+	}
+}
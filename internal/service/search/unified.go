@@ -0,0 +1,226 @@
+package search
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// UnifiedSearchOptions contains options for searching tools, prompts, MCP servers and tool groups.
+type UnifiedSearchOptions struct {
+	Query      string
+	MaxResults int
+
+	// Kinds limits the search to the given kinds of entities. All kinds are searched if it is empty.
+	Kinds []types.SearchKind
+
+	// OnlyEnabled excludes disabled tools and prompts.
+	OnlyEnabled bool
+
+	// Allow, if set, is called with each matching result.
+	// Results for which it returns false are left out, eg- because the caller may not access them.
+	Allow func(types.SearchResult) bool
+}
+
+// SearchAll performs a keyword search across tools, prompts, MCP servers and tool groups.
+// Results of all kinds are ranked together with BM25, like SearchTools ranks tools.
+func (s *SearchService) SearchAll(opts UnifiedSearchOptions) ([]types.SearchResult, error) {
+	if opts.Query == "" {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = 20
+	}
+	if len(opts.Kinds) == 0 {
+		opts.Kinds = types.AllSearchKinds
+	}
+
+	results := make([]types.SearchResult, 0)
+	for _, kind := range opts.Kinds {
+		var (
+			found []types.SearchResult
+			err   error
+		)
+		switch kind {
+		case types.SearchKindTool:
+			found, err = s.searchToolsForUnifiedSearch(opts)
+		case types.SearchKindPrompt:
+			found, err = s.searchPrompts(opts)
+		case types.SearchKindServer:
+			found, err = s.searchServers(opts)
+		case types.SearchKindToolGroup:
+			found, err = s.searchToolGroups(opts)
+		default:
+			return nil, fmt.Errorf("unsupported search kind: %s", kind)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if opts.Allow != nil {
+			found = slices.DeleteFunc(found, func(r types.SearchResult) bool { return !opts.Allow(r) })
+		}
+		results = append(results, found...)
+	}
+
+	slices.SortFunc(results, func(a, b types.SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			strings.Compare(string(a.Kind), string(b.Kind)),
+			strings.Compare(a.Name, b.Name),
+		)
+	})
+	if len(results) > opts.MaxResults {
+		results = results[:opts.MaxResults]
+	}
+	return results, nil
+}
+
+// searchToolsForUnifiedSearch searches tools using the incrementally maintained tool index.
+// All matching tools are returned, because results are only limited after filtering and merging them with other kinds.
+func (s *SearchService) searchToolsForUnifiedSearch(opts UnifiedSearchOptions) ([]types.SearchResult, error) {
+	tools, err := s.SearchTools(SearchOptions{
+		Query:       opts.Query,
+		MaxResults:  math.MaxInt,
+		OnlyEnabled: opts.OnlyEnabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]types.SearchResult, len(tools))
+	for i, t := range tools {
+		results[i] = types.SearchResult{
+			Kind:        types.SearchKindTool,
+			Name:        t.ToolName,
+			ServerName:  t.ServerName,
+			Description: t.Description,
+			Score:       t.Score,
+			Enabled:     t.Enabled,
+		}
+	}
+	return results, nil
+}
+
+// searchPrompts searches prompts by their names, server names, descriptions and arguments.
+// There are far fewer prompts than tools, so they are indexed on every search instead of being kept in an index.
+func (s *SearchService) searchPrompts(opts UnifiedSearchOptions) ([]types.SearchResult, error) {
+	var prompts []struct {
+		model.Prompt
+		ServerName string `gorm:"column:server_name"`
+	}
+	tx := s.db.Model(&model.Prompt{}).
+		Select("prompts.*, mcp_servers.name as server_name").
+		Joins("JOIN mcp_servers ON prompts.server_id = mcp_servers.id")
+	if opts.OnlyEnabled {
+		tx = tx.Where("prompts.enabled = ?", true)
+	}
+	if err := tx.Find(&prompts).Error; err != nil {
+		return nil, fmt.Errorf("failed to search prompts: %w", err)
+	}
+
+	ix := newBM25Index()
+	for _, p := range prompts {
+		fields := []field{
+			{text: p.Name, weight: fieldWeightName},
+			{text: p.ServerName, weight: fieldWeightServer},
+			{text: p.Description, weight: fieldWeightDescription},
+		}
+		for _, arg := range promptArguments(&p.Prompt) {
+			fields = append(fields, field{text: arg.name + " " + arg.description, weight: fieldWeightParameters})
+		}
+		ix.add(docKey{server: p.ServerName, name: p.Name}, p.Description, p.Enabled, fields...)
+	}
+	return indexResults(ix, types.SearchKindPrompt, opts.Query), nil
+}
+
+// searchServers searches MCP servers by their names and descriptions.
+func (s *SearchService) searchServers(opts UnifiedSearchOptions) ([]types.SearchResult, error) {
+	var servers []model.McpServer
+	if err := s.db.Select("name", "description").Find(&servers).Error; err != nil {
+		return nil, fmt.Errorf("failed to search MCP servers: %w", err)
+	}
+
+	ix := newBM25Index()
+	for _, srv := range servers {
+		ix.add(
+			docKey{name: srv.Name},
+			srv.Description,
+			true,
+			field{text: srv.Name, weight: fieldWeightName},
+			field{text: srv.Description, weight: fieldWeightDescription},
+		)
+	}
+	return indexResults(ix, types.SearchKindServer, opts.Query), nil
+}
+
+// searchToolGroups searches tool groups by their names, descriptions and the names of the tools,
+// servers and prompts they include.
+func (s *SearchService) searchToolGroups(opts UnifiedSearchOptions) ([]types.SearchResult, error) {
+	var groups []model.ToolGroup
+	if err := s.db.Find(&groups).Error; err != nil {
+		return nil, fmt.Errorf("failed to search tool groups: %w", err)
+	}
+
+	ix := newBM25Index()
+	for _, g := range groups {
+		fields := []field{
+			{text: g.Name, weight: fieldWeightName},
+			{text: g.Description, weight: fieldWeightDescription},
+		}
+		// the contents of a group are a weaker signal than its own name and description,
+		// and a broken list of contents shouldn't make the group unsearchable, so errors are ignored
+		for _, get := range []func() ([]string, error){g.GetTools, g.GetServers, g.GetPrompts} {
+			names, _ := get()
+			fields = append(fields, field{text: strings.Join(names, " "), weight: fieldWeightParameters})
+		}
+		ix.add(docKey{name: g.Name}, g.Description, true, fields...)
+	}
+	return indexResults(ix, types.SearchKindToolGroup, opts.Query), nil
+}
+
+// indexResults searches an index and converts the matching documents to search results of the given kind.
+// The names of tools and prompts are converted to their canonical names.
+func indexResults(ix *bm25Index, kind types.SearchKind, query string) []types.SearchResult {
+	scores := ix.search(tokenize(query))
+	results := make([]types.SearchResult, 0, len(scores))
+	for key, score := range scores {
+		doc := ix.docs[key]
+		name := key.name
+		if key.server != "" {
+			name = fmt.Sprintf("%s__%s", key.server, key.name)
+		}
+		results = append(results, types.SearchResult{
+			Kind:        kind,
+			Name:        name,
+			ServerName:  key.server,
+			Description: doc.description,
+			Score:       score,
+			Enabled:     doc.enabled,
+		})
+	}
+	return results
+}
+
+// promptArguments returns the names and descriptions of a prompt's arguments.
+func promptArguments(p *model.Prompt) []inputParameter {
+	if len(p.Arguments) == 0 {
+		return nil
+	}
+	var args []struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(p.Arguments, &args); err != nil {
+		return nil
+	}
+	params := make([]inputParameter, len(args))
+	for i, a := range args {
+		params[i] = inputParameter{name: a.Name, description: a.Description}
+	}
+	return params
+}
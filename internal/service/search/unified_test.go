@@ -0,0 +1,130 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_SearchAll(t *testing.T) {
+	db := setupSemanticTestDB(t)
+	require.NoError(t, db.AutoMigrate(&model.Prompt{}, &model.ToolGroup{}))
+	service := NewSearchService(db)
+
+	require.NoError(t, db.Model(&model.McpServer{}).Where("name = ?", "github").
+		Update("description", "Manage repositories, issues and pull requests on GitHub").Error)
+
+	var github model.McpServer
+	require.NoError(t, db.Where("name = ?", "github").First(&github).Error)
+	args, _ := json.Marshal([]map[string]any{
+		{"name": "diff", "description": "The changes to review", "required": true},
+	})
+	prompts := []model.Prompt{
+		{ServerID: github.ID, Name: "review_pull_request", Description: "Review the changes of a pull request", Arguments: args},
+		{ServerID: github.ID, Name: "summarize_issue", Description: "Summarize an issue"},
+	}
+	for i := range prompts {
+		require.NoError(t, db.Create(&prompts[i]).Error)
+	}
+	require.NoError(t, db.Model(&model.Prompt{}).Where("name = ?", "summarize_issue").Update("enabled", false).Error)
+
+	tools, _ := json.Marshal([]string{"github__create_pull_request"})
+	require.NoError(t, db.Create(&model.ToolGroup{
+		Name: "code-review", Description: "Tools for reviewing code", IncludedTools: tools,
+	}).Error)
+
+	kindsOf := func(results []types.SearchResult) map[types.SearchKind][]string {
+		kinds := make(map[types.SearchKind][]string)
+		for _, r := range results {
+			kinds[r.Kind] = append(kinds[r.Kind], r.Name)
+		}
+		return kinds
+	}
+
+	t.Run("Searches all kinds", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{Query: "pull request"})
+		require.NoError(t, err)
+		kinds := kindsOf(results)
+		assert.Contains(t, kinds[types.SearchKindTool], "github__create_pull_request")
+		assert.Contains(t, kinds[types.SearchKindPrompt], "github__review_pull_request")
+		assert.Equal(t, []string{"github"}, kinds[types.SearchKindServer])
+		assert.Equal(t, []string{"code-review"}, kinds[types.SearchKindToolGroup])
+		for i := 1; i < len(results); i++ {
+			assert.GreaterOrEqual(t, results[i-1].Score, results[i].Score)
+		}
+	})
+
+	t.Run("Filters by kind", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{
+			Query: "review", Kinds: []types.SearchKind{types.SearchKindPrompt},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, types.SearchResult{
+			Kind:        types.SearchKindPrompt,
+			Name:        "github__review_pull_request",
+			ServerName:  "github",
+			Description: "Review the changes of a pull request",
+			Score:       results[0].Score,
+			Enabled:     true,
+		}, results[0])
+	})
+
+	t.Run("Matches prompt arguments", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{
+			Query: "diff", Kinds: []types.SearchKind{types.SearchKindPrompt},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "github__review_pull_request", results[0].Name)
+	})
+
+	t.Run("Filters disabled prompts", func(t *testing.T) {
+		opts := UnifiedSearchOptions{Query: "summarize", Kinds: []types.SearchKind{types.SearchKindPrompt}}
+		results, err := service.SearchAll(opts)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Enabled)
+
+		opts.OnlyEnabled = true
+		results, err = service.SearchAll(opts)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Matches the contents of tool groups", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{
+			Query: "create_pull_request", Kinds: []types.SearchKind{types.SearchKindToolGroup},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "code-review", results[0].Name)
+		assert.Empty(t, results[0].ServerName)
+	})
+
+	t.Run("Leaves out results that are not allowed", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{
+			Query:      "pull request",
+			MaxResults: 1,
+			Allow:      func(r types.SearchResult) bool { return r.Kind == types.SearchKindToolGroup },
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "code-review", results[0].Name)
+	})
+
+	t.Run("Respects max results", func(t *testing.T) {
+		results, err := service.SearchAll(UnifiedSearchOptions{Query: "pull request", MaxResults: 2})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("Empty query returns error", func(t *testing.T) {
+		_, err := service.SearchAll(UnifiedSearchOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,197 @@
+package toolgroup
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+)
+
+// clientToolAccess holds the precomputed set of tools and prompts that an MCP client is allowed to access
+// through its allowed tool groups.
+type clientToolAccess struct {
+	// allowedToolGroups is the raw JSON value of the client's AllowedToolGroups that this entry was computed from.
+	// If the client's tool groups change, the entry no longer matches and is recomputed.
+	allowedToolGroups string
+
+	// tools is the set of canonical tool names the client can access
+	tools map[string]struct{}
+	// groupTools maps the names of the client's tool groups to the names of the tools that the client
+	// can access only through that group, see model.ToolGroup.ResolveScopedTools
+	groupTools map[string]map[string]struct{}
+	// prompts is the set of canonical prompt names the client can access
+	prompts map[string]struct{}
+}
+
+// accessIndex is an in-memory cache of tool access decisions for MCP clients, keyed by client name.
+// Entries are computed lazily on the first access check for a client and dropped whenever
+// the set of tools or tool groups in mcpjungle changes.
+type accessIndex struct {
+	mu      sync.RWMutex
+	entries map[string]*clientToolAccess
+
+	// generation is incremented on every invalidation.
+	// It prevents an entry computed from stale data from being stored after an invalidation happened.
+	generation uint64
+}
+
+func newAccessIndex() *accessIndex {
+	return &accessIndex{
+		entries: make(map[string]*clientToolAccess),
+	}
+}
+
+// get returns the cached entry for a client if it exists and was computed from the given tool groups.
+func (a *accessIndex) get(clientName, allowedToolGroups string) (*clientToolAccess, uint64, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	e, ok := a.entries[clientName]
+	if !ok || e.allowedToolGroups != allowedToolGroups {
+		return nil, a.generation, false
+	}
+	return e, a.generation, true
+}
+
+// put stores an entry for a client, unless the index was invalidated since generation was read.
+func (a *accessIndex) put(clientName string, e *clientToolAccess, generation uint64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.generation != generation {
+		return
+	}
+	a.entries[clientName] = e
+}
+
+// invalidate drops all cached entries.
+func (a *accessIndex) invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.generation++
+	a.entries = make(map[string]*clientToolAccess)
+}
+
+// CheckClientToolAccess returns true if the MCP client is allowed to call the given tool.
+// The tool name must be in its canonical form (eg- "github__create_issue").
+// If the client has allowed tool groups, the decision is served from the in-memory access index,
+// which is (re)built on demand. Otherwise, it falls back to the client's server-level ACL.
+func (s *ToolGroupService) CheckClientToolAccess(c *model.McpClient, toolName string) (bool, error) {
+	allowedGroups, err := c.GetAllowedToolGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to get allowed tool groups: %w", err)
+	}
+	if len(allowedGroups) == 0 {
+		// no tool groups, so tool-level ACL doesn't apply
+		return c.CheckHasToolAccess(toolName, s, s.mcpService)
+	}
+
+	e, err := s.getClientAccess(c, allowedGroups)
+	if err != nil {
+		return false, err
+	}
+	_, allowed := e.tools[toolName]
+	return allowed, nil
+}
+
+// CheckClientGroupToolAccess returns true if the MCP client is allowed to call the tool that a tool group's
+// MCP proxy server exposes under the given name and that is scoped to the group, eg- a tool alias.
+// Clients with allowed tool groups can only access it if the group is one of them.
+// Otherwise, it falls back to the client's server-level ACL for the underlying tool with the given canonical name.
+func (s *ToolGroupService) CheckClientGroupToolAccess(c *model.McpClient, groupName, toolName, canonicalName string) (bool, error) {
+	allowedGroups, err := c.GetAllowedToolGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to get allowed tool groups: %w", err)
+	}
+	if len(allowedGroups) == 0 {
+		return c.CheckHasToolAccess(canonicalName, s, s.mcpService)
+	}
+
+	e, err := s.getClientAccess(c, allowedGroups)
+	if err != nil {
+		return false, err
+	}
+	_, allowed := e.groupTools[groupName][toolName]
+	return allowed, nil
+}
+
+// CheckClientPromptAccess returns true if the MCP client is allowed to access the given prompt.
+// The prompt name must be in its canonical form (eg- "github__summarize_pr").
+// Just like tools, prompt access is determined by the client's allowed tool groups if it has any,
+// otherwise by its server-level ACL.
+func (s *ToolGroupService) CheckClientPromptAccess(c *model.McpClient, promptName string) (bool, error) {
+	allowedGroups, err := c.GetAllowedToolGroups()
+	if err != nil {
+		return false, fmt.Errorf("failed to get allowed tool groups: %w", err)
+	}
+	if len(allowedGroups) == 0 {
+		return c.CheckHasPromptAccess(promptName), nil
+	}
+
+	e, err := s.getClientAccess(c, allowedGroups)
+	if err != nil {
+		return false, err
+	}
+	_, allowed := e.prompts[promptName]
+	return allowed, nil
+}
+
+// getClientAccess returns the access index entry for a client, computing it if it isn't cached yet.
+func (s *ToolGroupService) getClientAccess(c *model.McpClient, allowedGroups []string) (*clientToolAccess, error) {
+	key := string(c.AllowedToolGroups)
+	e, generation, ok := s.accessIndex.get(c.Name, key)
+	if ok {
+		return e, nil
+	}
+	e, err := s.computeClientToolAccess(allowedGroups)
+	if err != nil {
+		return nil, err
+	}
+	e.allowedToolGroups = key
+	s.accessIndex.put(c.Name, e, generation)
+	return e, nil
+}
+
+// InvalidateAccessIndex drops all cached tool access decisions.
+// It must be called whenever a change in mcpjungle could affect which tools or prompts an MCP client can access.
+func (s *ToolGroupService) InvalidateAccessIndex() {
+	s.accessIndex.invalidate()
+}
+
+// computeClientToolAccess resolves the effective tools and prompts of all the given tool groups.
+// Groups that don't exist are skipped.
+func (s *ToolGroupService) computeClientToolAccess(groupNames []string) (*clientToolAccess, error) {
+	e := &clientToolAccess{
+		tools:      make(map[string]struct{}),
+		groupTools: make(map[string]map[string]struct{}),
+		prompts:    make(map[string]struct{}),
+	}
+	for _, groupName := range groupNames {
+		group, err := s.GetToolGroup(groupName)
+		if err != nil {
+			// If the group doesn't exist, skip it
+			continue
+		}
+		tools, err := group.ResolveAccessibleTools(s.mcpService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve tools for group %s: %w", groupName, err)
+		}
+		for _, t := range tools {
+			e.tools[t] = struct{}{}
+		}
+		scoped, err := group.ResolveScopedTools()
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve scoped tools for group %s: %w", groupName, err)
+		}
+		e.groupTools[groupName] = make(map[string]struct{}, len(scoped))
+		for _, t := range scoped {
+			e.groupTools[groupName][t] = struct{}{}
+		}
+		prompts, err := group.ResolveEffectivePrompts(s.mcpService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve prompts for group %s: %w", groupName, err)
+		}
+		for _, p := range prompts {
+			e.prompts[p] = struct{}{}
+		}
+	}
+	return e, nil
+}
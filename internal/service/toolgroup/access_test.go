@@ -0,0 +1,392 @@
+package toolgroup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/internal/telemetry"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// setupAccessTest creates a tool group service backed by a registry containing
+// a single stdio server "github" with the tools "create_issue" and "delete_repo".
+func setupAccessTest(t *testing.T) (*testhelpers.TestDBSetup, *mcp.MCPService, *ToolGroupService) {
+	t.Helper()
+
+	setup := testhelpers.SetupMCPTest(t)
+	s := setup.CreateTestMcpServer("github", "", types.TransportStdio, []byte(`{"command":"echo"}`))
+	setup.CreateTestTool("create_issue", "", s.ID, true, []byte(
+		`{"type":"object","properties":{"title":{"type":"string"},"repo":{"type":"string"}},"required":["title","repo"]}`,
+	))
+	setup.CreateTestTool("delete_repo", "", s.ID, true, []byte(`{"type":"object"}`))
+
+	mcpService, err := mcp.NewMCPService(
+		setup.DB, &server.MCPServer{}, &server.MCPServer{}, telemetry.NewNoopCustomMetrics(), logger.NewNop(),
+	)
+	testhelpers.AssertNoError(t, err)
+
+	svc, err := NewToolGroupService(setup.DB, mcpService, logger.NewNop())
+	testhelpers.AssertNoError(t, err)
+
+	return setup, mcpService, svc
+}
+
+func TestCheckClientToolAccess(t *testing.T) {
+	setup, _, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "issues",
+		IncludedTools: []byte(`["github__create_issue"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["issues"]`)}
+
+	ok, err := svc.CheckClientToolAccess(c, "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access to a tool in an allowed group")
+
+	ok, err = svc.CheckClientToolAccess(c, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, ok, "expected no access to a tool outside the allowed groups")
+
+	// a client without tool groups falls back to the server-level ACL
+	serverClient := &model.McpClient{Name: "legacy", AllowList: []byte(`["github"]`)}
+	ok, err = svc.CheckClientToolAccess(serverClient, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected server-level ACL to grant access")
+}
+
+func TestCheckClientToolAccessInvalidation(t *testing.T) {
+	setup, _, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:            "github",
+		IncludedServers: []byte(`["github"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["github"]`)}
+
+	ok, err := svc.CheckClientToolAccess(c, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access before the group is updated")
+
+	// updating the group must invalidate the cached decision
+	_, err = svc.UpdateToolGroup("github", &model.ToolGroup{
+		IncludedServers: []byte(`["github"]`),
+		ExcludedTools:   []byte(`["github__delete_repo"]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	ok, err = svc.CheckClientToolAccess(c, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, ok, "expected no access after the tool was excluded from the group")
+
+	// deleting a tool from the registry must invalidate the cached decision as well
+	ok, err = svc.CheckClientToolAccess(c, "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access to a tool in the group")
+
+	testhelpers.AssertNoError(t, setup.DB.Where("name = ?", "create_issue").Delete(&model.Tool{}).Error)
+	svc.handleToolDeletion("github__create_issue")
+
+	ok, err = svc.CheckClientToolAccess(c, "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, ok, "expected no access after the tool was deleted")
+
+	// a change in the client's own tool groups is picked up without explicit invalidation
+	c.AllowedToolGroups = []byte(`[]`)
+	c.AllowList = []byte(`["github"]`)
+	ok, err = svc.CheckClientToolAccess(c, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected server-level ACL after the client's groups were removed")
+}
+
+func TestLazyToolGroup(t *testing.T) {
+	setup, _, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:            "lazy",
+		IncludedServers: []byte(`["github"]`),
+		LazyToolLoading: true,
+	})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, svc.isLazyGroup("lazy")(), "expected the group to run in lazy tool loading mode")
+
+	// turning lazy tool loading off must be persisted, even though it is the zero value
+	_, err = svc.UpdateToolGroup("lazy", &model.ToolGroup{IncludedServers: []byte(`["github"]`)})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, svc.isLazyGroup("lazy")(), "expected lazy tool loading to be turned off")
+
+	group, err := svc.GetToolGroup("lazy")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, group.LazyToolLoading, "expected lazy tool loading to be off in the DB")
+
+	_, err = svc.UpdateToolGroup("lazy", &model.ToolGroup{IncludedServers: []byte(`["github"]`), LazyToolLoading: true})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNoError(t, svc.DeleteToolGroup("lazy"))
+	testhelpers.AssertFalse(t, svc.isLazyGroup("lazy")(), "expected a deleted group to forget its mode")
+}
+
+func TestToolGroupIncludedServersFilter(t *testing.T) {
+	setup, _, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := setup.DB.Model(&model.Tool{}).Where("name = ?", "create_issue").
+		Update("annotations", []byte(`{"destructiveHint":false}`)).Error
+	testhelpers.AssertNoError(t, err)
+
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:                  "safe",
+		IncludedServers:       []byte(`["github"]`),
+		IncludedServersFilter: []byte(`{"destructive":false}`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	groupServer, ok := svc.GetToolGroupMCPServer("safe")
+	testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+	testhelpers.AssertNotNil(t, groupServer.GetTool("github__create_issue"))
+	testhelpers.AssertTrue(t, groupServer.GetTool("github__delete_repo") == nil, "expected the destructive tool to be filtered out")
+
+	// removing the filter must be persisted and include all tools of the server
+	_, err = svc.UpdateToolGroup("safe", &model.ToolGroup{IncludedServers: []byte(`["github"]`)})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, groupServer.GetTool("github__delete_repo"))
+
+	group, err := svc.GetToolGroup("safe")
+	testhelpers.AssertNoError(t, err)
+	filter, err := group.GetIncludedServersFilter()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, filter == nil, "expected the filter to be removed from the DB")
+}
+
+func TestToolGroupRulesApplyToNewTools(t *testing.T) {
+	setup, mcpService, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	_, err := mcpService.DisableTools("github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:      "github",
+		ToolRules: []byte(`[{"pattern":"github__*"}]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	groupServer, ok := svc.GetToolGroupMCPServer("github")
+	testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+	testhelpers.AssertNotNil(t, groupServer.GetTool("github__create_issue"))
+	testhelpers.AssertTrue(t, groupServer.GetTool("github__delete_repo") == nil, "expected disabled tools not to match")
+
+	// a tool that becomes available later joins the group because it matches the rule
+	_, err = mcpService.EnableTools("github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, groupServer.GetTool("github__delete_repo"))
+
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["github"]`)}
+	ok, err = svc.CheckClientToolAccess(c, "github__delete_repo")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access to a tool that joined the group through a rule")
+
+	// invalid rules are rejected
+	err = svc.CreateToolGroup(&model.ToolGroup{Name: "invalid", ToolRules: []byte(`[{"exclude":true}]`)})
+	testhelpers.AssertTrue(t, err != nil, "expected an error for an invalid rule")
+}
+
+func TestToolGroupAliases(t *testing.T) {
+	setup, mcpService, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:        "issues",
+		ToolAliases: []byte(`[{"name":"new_issue","tool":"github__create_issue","description":"Open an issue"}]`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	groupServer, ok := svc.GetToolGroupMCPServer("issues")
+	testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+	alias := groupServer.GetTool("new_issue")
+	testhelpers.AssertNotNil(t, alias)
+	testhelpers.AssertEqual(t, "Open an issue", alias.Tool.Description)
+	testhelpers.AssertTrue(t, groupServer.GetTool("github__create_issue") == nil, "expected the tool to be exposed under its alias only")
+
+	// clients of the group can call the alias through the group, but not the underlying tool
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["issues"]`)}
+	ok, err = svc.CheckClientGroupToolAccess(c, "issues", "new_issue", "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access to the alias through the group")
+	ok, err = svc.CheckClientGroupToolAccess(c, "other", "new_issue", "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, ok, "expected no access to the alias through another group")
+	ok, err = svc.CheckClientToolAccess(c, "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertFalse(t, ok, "expected no access to the aliased tool under its canonical name")
+
+	// the alias follows the availability of the underlying tool
+	_, err = mcpService.DisableTools("github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, groupServer.GetTool("new_issue") == nil, "expected the alias of a disabled tool to be removed")
+	_, err = mcpService.EnableTools("github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertNotNil(t, groupServer.GetTool("new_issue"))
+
+	// renaming the alias replaces it and is persisted
+	_, err = svc.UpdateToolGroup("issues", &model.ToolGroup{
+		ToolAliases: []byte(`[{"name":"issue","tool":"github__create_issue"}]`),
+	})
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, groupServer.GetTool("new_issue") == nil, "expected the old alias to be removed")
+	testhelpers.AssertNotNil(t, groupServer.GetTool("issue"))
+
+	group, err := svc.GetToolGroup("issues")
+	testhelpers.AssertNoError(t, err)
+	aliases, err := group.GetToolAliases()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 1, len(aliases))
+	testhelpers.AssertEqual(t, "issue", aliases[0].Name)
+
+	// aliases must be valid and refer to existing tools
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:        "invalid",
+		ToolAliases: []byte(`[{"name":"github__issue","tool":"github__create_issue"}]`),
+	})
+	testhelpers.AssertTrue(t, err != nil, "expected an error for an alias name with a separator")
+	err = svc.CreateToolGroup(&model.ToolGroup{
+		Name:        "invalid",
+		ToolAliases: []byte(`[{"name":"missing","tool":"github__missing"}]`),
+	})
+	testhelpers.AssertTrue(t, err != nil, "expected an error for an alias of a missing tool")
+}
+
+func TestToolGroupArgumentPresets(t *testing.T) {
+	setup, _, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "mcpjungle",
+		IncludedTools: []byte(`["github__create_issue"]`),
+		ToolAliases:   []byte(`[{"name":"issue","tool":"github__create_issue"}]`),
+		ToolArguments: []byte(`{"github__create_issue":{"fixed":{"repo":"mcpjungle"}},"issue":{"defaults":{"repo":"docs"}}}`),
+	})
+	testhelpers.AssertNoError(t, err)
+
+	groupServer, ok := svc.GetToolGroupMCPServer("mcpjungle")
+	testhelpers.AssertTrue(t, ok, "expected the group's MCP server to exist")
+	tool := groupServer.GetTool("github__create_issue")
+	testhelpers.AssertNotNil(t, tool)
+	_, exposed := tool.Tool.InputSchema.Properties["repo"]
+	testhelpers.AssertFalse(t, exposed, "expected the fixed argument to be removed from the schema")
+	testhelpers.AssertEqual(t, 1, len(tool.Tool.InputSchema.Required))
+
+	// presets apply to aliases by their alias name
+	alias := groupServer.GetTool("issue")
+	testhelpers.AssertNotNil(t, alias)
+	testhelpers.AssertEqual(t, "docs", alias.Tool.InputSchema.Properties["repo"].(map[string]any)["default"])
+
+	// removing the presets must be persisted and restore the tool's schema
+	_, err = svc.UpdateToolGroup("mcpjungle", &model.ToolGroup{IncludedTools: []byte(`["github__create_issue"]`)})
+	testhelpers.AssertNoError(t, err)
+	_, exposed = groupServer.GetTool("github__create_issue").Tool.InputSchema.Properties["repo"]
+	testhelpers.AssertTrue(t, exposed, "expected the argument to be exposed again")
+
+	group, err := svc.GetToolGroup("mcpjungle")
+	testhelpers.AssertNoError(t, err)
+	presets, err := group.GetToolArguments()
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 0, len(presets))
+
+	// presets are validated against the tool's schema and the group's tools
+	invalid := []string{
+		`{"github__create_issue":{"fixed":{"owner":"mcpjungle"}}}`,
+		`{"github__create_issue":{"fixed":{"repo":42}}}`,
+		`{"github__delete_repo":{"fixed":{"repo":"mcpjungle"}}}`,
+	}
+	for _, presets := range invalid {
+		_, err = svc.UpdateToolGroup("mcpjungle", &model.ToolGroup{
+			IncludedTools: []byte(`["github__create_issue"]`),
+			ToolArguments: []byte(presets),
+		})
+		testhelpers.AssertTrue(t, err != nil, "expected an error for the presets "+presets)
+	}
+}
+
+// callTool sends a tools/call request to an MCP proxy server and returns the error message of the call,
+// or an empty string if it succeeded.
+func callTool(t *testing.T, ctx context.Context, srv *server.MCPServer, name string, args map[string]any) string {
+	t.Helper()
+	msg, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	switch resp := srv.HandleMessage(ctx, msg).(type) {
+	case mcpgo.JSONRPCError:
+		return resp.Error.Message
+	case mcpgo.JSONRPCResponse:
+		result := resp.Result.(mcpgo.CallToolResult)
+		if result.IsError {
+			return result.Content[0].(mcpgo.TextContent).Text
+		}
+		return ""
+	default:
+		t.Fatalf("unexpected response %T", resp)
+		return ""
+	}
+}
+
+func TestArgumentPresetsCannotBeBypassed(t *testing.T) {
+	setup, mcpService, svc := setupAccessTest(t)
+	defer setup.Cleanup()
+
+	err := svc.CreateToolGroup(&model.ToolGroup{
+		Name:          "mcpjungle",
+		IncludedTools: []byte(`["github__create_issue"]`),
+		ToolArguments: []byte(`{"github__create_issue":{"fixed":{"repo":"mcpjungle"}}}`),
+	})
+	testhelpers.AssertNoError(t, err)
+	groupServer, _ := svc.GetToolGroupMCPServer("mcpjungle")
+
+	// the global proxy server serves the tool without the group's presets
+	global := server.NewMCPServer("global", "0.1.0",
+		mcpService.ProxyServerOptions(mcp.ProxyEndpointGlobal, types.TransportStreamableHTTP, func() bool { return false })...,
+	)
+	mcpService.AddMetaTools(global)
+	tool, _ := mcpService.GetToolInstance("github__create_issue")
+	global.AddTool(tool, mcpService.MCPProxyToolCallHandler)
+
+	c := &model.McpClient{Name: "agent", AllowedToolGroups: []byte(`["mcpjungle"]`)}
+	ctx := context.WithValue(context.Background(), "mode", model.ModeEnterprise)
+	ctx = context.WithValue(ctx, "client", c)
+	ctx = context.WithValue(ctx, "toolGroupChecker", svc)
+	args := map[string]any{"title": "bug", "repo": "other"}
+
+	// the tool can't be called with another value of the fixed argument at the global endpoint
+	errMsg := callTool(t, ctx, global, "github__create_issue", args)
+	testhelpers.AssertTrue(t, strings.Contains(errMsg, "not authorized"), "expected the call to be rejected, got: "+errMsg)
+	errMsg = callTool(t, ctx, global, mcp.CallToolMetaToolName, map[string]any{"name": "github__create_issue", "arguments": args})
+	testhelpers.AssertTrue(t, strings.Contains(errMsg, "not allowed"), "expected the call to be rejected, got: "+errMsg)
+
+	// nor through the group, directly or through the call meta-tool
+	errMsg = callTool(t, ctx, groupServer, "github__create_issue", args)
+	testhelpers.AssertTrue(t, strings.Contains(errMsg, "is fixed"), "expected the call to be rejected, got: "+errMsg)
+	errMsg = callTool(t, ctx, groupServer, mcp.CallToolMetaToolName, map[string]any{"name": "github__create_issue", "arguments": args})
+	testhelpers.AssertTrue(t, strings.Contains(errMsg, "is fixed"), "expected the call to be rejected, got: "+errMsg)
+
+	// the group still grants access to the tool through its endpoints
+	ok, err := svc.CheckClientGroupToolAccess(c, "mcpjungle", "github__create_issue", "github__create_issue")
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertTrue(t, ok, "expected access to the tool through the group")
+}
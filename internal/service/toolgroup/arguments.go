@@ -0,0 +1,158 @@
+package toolgroup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// groupTool returns the tool that a tool group's MCP proxy server serves for the given tool definition,
+// which is either a registered tool or a tool exposed under an alias.
+// Tools exposed under an alias are scoped to the group, so that MCP clients can only call them through it.
+// If the group presets arguments of the tool, they are applied to its input schema and injected into its calls,
+// and the tool is scoped to the group as well, so that the presets apply to every call made through the group.
+func (s *ToolGroupService) groupTool(group string, tool mcpgo.Tool, presets map[string]types.ToolArguments) (server.ServerTool, error) {
+	args, ok := presets[tool.Name]
+	if ok || mcp.CanonicalToolName(tool) != tool.Name {
+		tool = mcp.ScopeToolToGroup(tool, group)
+	}
+	if !ok {
+		return server.ServerTool{Tool: tool, Handler: s.mcpService.MCPProxyToolCallHandler}, nil
+	}
+	preset, err := applyArgumentPresets(tool, args)
+	if err != nil {
+		return server.ServerTool{}, fmt.Errorf("invalid arguments for tool %s: %w", tool.Name, err)
+	}
+	return server.ServerTool{
+		Tool:    preset,
+		Handler: argumentPresetHandler(tool.Name, args, s.mcpService.MCPProxyToolCallHandler),
+	}, nil
+}
+
+// checkArgumentPresetTargets returns an error if the group presets the arguments of a tool
+// that it doesn't expose under the given names.
+func checkArgumentPresetTargets(presets map[string]types.ToolArguments, toolNames []string, aliases []types.ToolAlias) error {
+	for name := range presets {
+		isAlias := slices.ContainsFunc(aliases, func(a types.ToolAlias) bool { return a.Name == name })
+		if isAlias || slices.Contains(toolNames, name) {
+			continue
+		}
+		return fmt.Errorf("tool_arguments contains %s, which is not a tool of the group", name)
+	}
+	return nil
+}
+
+// applyArgumentPresets returns a copy of the tool whose input schema reflects the argument presets:
+// fixed arguments are removed from it and defaulted arguments are optional and carry their default value.
+// It returns an error if a preset argument is not a parameter of the tool or its value doesn't match the parameter's type.
+func applyArgumentPresets(tool mcpgo.Tool, args types.ToolArguments) (mcpgo.Tool, error) {
+	properties := maps.Clone(tool.InputSchema.Properties)
+	for name, value := range args.Fixed {
+		if err := checkArgumentValue(properties, name, value); err != nil {
+			return mcpgo.Tool{}, err
+		}
+		delete(properties, name)
+	}
+	for name, value := range args.Defaults {
+		if err := checkArgumentValue(properties, name, value); err != nil {
+			return mcpgo.Tool{}, err
+		}
+		if prop, ok := properties[name].(map[string]any); ok {
+			prop = maps.Clone(prop)
+			prop["default"] = value
+			properties[name] = prop
+		}
+	}
+
+	var required []string
+	for _, name := range tool.InputSchema.Required {
+		_, fixed := args.Fixed[name]
+		_, defaulted := args.Defaults[name]
+		if !fixed && !defaulted {
+			required = append(required, name)
+		}
+	}
+
+	preset := tool
+	preset.InputSchema.Properties = properties
+	preset.InputSchema.Required = required
+	return preset, nil
+}
+
+// checkArgumentValue returns an error if the tool has no parameter with the given name or
+// the value doesn't match the parameter's JSON schema type and enum.
+func checkArgumentValue(properties map[string]any, name string, value any) error {
+	prop, ok := properties[name]
+	if !ok {
+		return fmt.Errorf("tool has no parameter %s", name)
+	}
+	schema, ok := prop.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(v any) bool { return reflect.DeepEqual(v, value) }) {
+			return fmt.Errorf("value %v of argument %s is not one of %v", value, name, enum)
+		}
+	}
+
+	typ, ok := schema["type"].(string)
+	if !ok {
+		// parameters without a type or with multiple types accept any value
+		return nil
+	}
+	var valid bool
+	switch typ {
+	case "string":
+		_, valid = value.(string)
+	case "number":
+		_, valid = value.(float64)
+	case "integer":
+		n, isNumber := value.(float64)
+		valid = isNumber && n == math.Trunc(n)
+	case "boolean":
+		_, valid = value.(bool)
+	case "array":
+		_, valid = value.([]any)
+	case "object":
+		_, valid = value.(map[string]any)
+	case "null":
+		valid = value == nil
+	default:
+		valid = true
+	}
+	if !valid {
+		return fmt.Errorf("value %v of argument %s is not of type %s", value, name, typ)
+	}
+	return nil
+}
+
+// argumentPresetHandler returns a tool call handler that injects the argument presets into the call
+// before passing it on to the next handler.
+// Fixed arguments always take precedence, so a call that passes one is rejected rather than silently overridden.
+func argumentPresetHandler(toolName string, args types.ToolArguments, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		callArgs := request.GetArguments()
+		merged := make(map[string]any, len(args.Defaults)+len(callArgs)+len(args.Fixed))
+		maps.Copy(merged, args.Defaults)
+		for name, value := range callArgs {
+			if _, fixed := args.Fixed[name]; fixed {
+				return nil, fmt.Errorf("argument %s of tool %s is fixed and can't be passed", name, toolName)
+			}
+			merged[name] = value
+		}
+		maps.Copy(merged, args.Fixed)
+
+		request.Params.Arguments = merged
+		return next(ctx, request)
+	}
+}
@@ -0,0 +1,93 @@
+package toolgroup
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	mcpgo "github.com/mark3labs/mcp-go/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+func queryTool() mcpgo.Tool {
+	return mcpgo.Tool{
+		Name: "postgres__query",
+		InputSchema: mcpgo.ToolInputSchema{
+			Type: "object",
+			Properties: map[string]any{
+				"sql":      map[string]any{"type": "string"},
+				"database": map[string]any{"type": "string"},
+				"limit":    map[string]any{"type": "integer"},
+				"format":   map[string]any{"type": "string", "enum": []any{"csv", "json"}},
+			},
+			Required: []string{"sql", "database"},
+		},
+	}
+}
+
+func TestApplyArgumentPresets(t *testing.T) {
+	tool := queryTool()
+	preset, err := applyArgumentPresets(tool, types.ToolArguments{
+		Fixed:    map[string]any{"database": "analytics_ro"},
+		Defaults: map[string]any{"limit": float64(100)},
+	})
+	testhelpers.AssertNoError(t, err)
+
+	_, exposed := preset.InputSchema.Properties["database"]
+	testhelpers.AssertFalse(t, exposed, "expected the fixed argument to be removed from the schema")
+	testhelpers.AssertEqual(t, float64(100), preset.InputSchema.Properties["limit"].(map[string]any)["default"])
+	testhelpers.AssertTrue(t, reflect.DeepEqual([]string{"sql"}, preset.InputSchema.Required), "expected only sql to be required")
+
+	// the original tool must not be modified
+	testhelpers.AssertEqual(t, 4, len(tool.InputSchema.Properties))
+	_, hasDefault := tool.InputSchema.Properties["limit"].(map[string]any)["default"]
+	testhelpers.AssertFalse(t, hasDefault, "expected the original schema to be unchanged")
+
+	invalid := []types.ToolArguments{
+		{Fixed: map[string]any{"schema": "public"}},
+		{Fixed: map[string]any{"database": 1}},
+		{Defaults: map[string]any{"limit": 2.5}},
+		{Defaults: map[string]any{"format": "xml"}},
+	}
+	for _, args := range invalid {
+		_, err := applyArgumentPresets(tool, args)
+		testhelpers.AssertError(t, err)
+	}
+}
+
+func TestArgumentPresetHandler(t *testing.T) {
+	var received map[string]any
+	next := func(_ context.Context, request mcpgo.CallToolRequest) (*mcpgo.CallToolResult, error) {
+		received = request.GetArguments()
+		return mcpgo.NewToolResultText("ok"), nil
+	}
+	handler := argumentPresetHandler("postgres__query", types.ToolArguments{
+		Fixed:    map[string]any{"database": "analytics_ro"},
+		Defaults: map[string]any{"limit": float64(100), "format": "csv"},
+	}, next)
+
+	var request mcpgo.CallToolRequest
+	request.Params.Name = "postgres__query"
+	request.Params.Arguments = map[string]any{"sql": "select 1", "format": "json"}
+	_, err := handler(context.Background(), request)
+	testhelpers.AssertNoError(t, err)
+	want := map[string]any{"sql": "select 1", "database": "analytics_ro", "limit": float64(100), "format": "json"}
+	testhelpers.AssertTrue(t, reflect.DeepEqual(want, received), "expected the presets to be merged into the arguments")
+
+	// fixed arguments can't be overridden
+	request.Params.Arguments = map[string]any{"sql": "select 1", "database": "prod"}
+	_, err = handler(context.Background(), request)
+	testhelpers.AssertError(t, err)
+}
+
+func TestCheckArgumentPresetTargets(t *testing.T) {
+	presets := map[string]types.ToolArguments{
+		"postgres__query": {Fixed: map[string]any{"database": "analytics_ro"}},
+		"query":           {Defaults: map[string]any{"limit": 10}},
+	}
+	aliases := []types.ToolAlias{{Name: "query", Tool: "postgres__query"}}
+
+	testhelpers.AssertNoError(t, checkArgumentPresetTargets(presets, []string{"postgres__query"}, aliases))
+	testhelpers.AssertError(t, checkArgumentPresetTargets(presets, []string{"postgres__query"}, nil))
+}
@@ -2,10 +2,12 @@
 package toolgroup
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"sync"
 
 	mcpgo "github.com/mark3labs/mcp-go/mcp"
@@ -13,6 +15,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/audit"
 	"github.com/mcpjungle/mcpjungle/internal/service/mcp"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"github.com/mcpjungle/mcpjungle/pkg/util"
 	"gorm.io/gorm"
@@ -33,6 +36,8 @@ type ToolGroupService struct {
 	mcpService   *mcp.MCPService
 	auditService *audit.AuditService
 
+	logger logger.Logger
+
 	// mcpServers manages the MCP proxy servers for all the tool groups
 	// key: tool group name, value: MCP proxy server
 	mcpServers map[string]*server.MCPServer
@@ -44,19 +49,33 @@ type ToolGroupService struct {
 	sseMcpServers map[string]*server.MCPServer
 	// sseMcpServerMu protects access to the sseMcpServers map
 	sseMcpServerMu sync.RWMutex
+
+	// accessIndex caches the tools each MCP client can access through its allowed tool groups,
+	// so that authorizing a tool call doesn't require resolving tool groups from the DB.
+	accessIndex *accessIndex
+
+	// lazyGroups holds the names of the tool groups whose MCP proxy servers run in lazy tool loading mode
+	lazyGroups   map[string]bool
+	lazyGroupsMu sync.RWMutex
 }
 
-func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService) (*ToolGroupService, error) {
+func NewToolGroupService(db *gorm.DB, mcpService *mcp.MCPService, l logger.Logger) (*ToolGroupService, error) {
 	s := &ToolGroupService{
 		db:           db,
 		mcpService:   mcpService,
-		auditService: audit.NewAuditService(db),
+		auditService: audit.NewAuditService(db, l),
+
+		logger: l,
 
 		mcpServers:   make(map[string]*server.MCPServer),
 		mcpServersMu: sync.RWMutex{},
 
 		sseMcpServers:  make(map[string]*server.MCPServer),
 		sseMcpServerMu: sync.RWMutex{},
+
+		accessIndex: newAccessIndex(),
+
+		lazyGroups: make(map[string]bool),
 	}
 
 	// register callbacks with mcp service to be notified when a tool gets added/removed
@@ -91,9 +110,20 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 	if err != nil {
 		return fmt.Errorf("failed to resolve effective tools: %w", err)
 	}
-	if len(toolNames) == 0 {
+	aliases, err := group.GetToolAliases()
+	if err != nil {
+		return fmt.Errorf("invalid tool aliases: %w", err)
+	}
+	if len(toolNames) == 0 && len(aliases) == 0 {
 		return errors.New("tool group must contain at least one tool after resolving servers and exclusions")
 	}
+	presets, err := group.GetToolArguments()
+	if err != nil {
+		return fmt.Errorf("invalid tool arguments: %w", err)
+	}
+	if err := checkArgumentPresetTargets(presets, toolNames, aliases); err != nil {
+		return err
+	}
 
 	// create the proxy MCP servers that expose only specified tools
 	mcpServer := s.newMCPServer(group.Name)
@@ -113,10 +143,31 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 			return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
 		}
 
+		groupTool, err := s.groupTool(group.Name, tool, presets)
+		if err != nil {
+			return err
+		}
 		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+			sseMcpServer.AddTools(groupTool)
 		} else {
-			mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+			mcpServer.AddTools(groupTool)
+		}
+	}
+
+	// populate the MCP servers with the tools exposed under an alias
+	for _, alias := range aliases {
+		tool, sse, err := s.aliasTool(alias)
+		if err != nil {
+			return err
+		}
+		groupTool, err := s.groupTool(group.Name, tool, presets)
+		if err != nil {
+			return err
+		}
+		if sse {
+			sseMcpServer.AddTools(groupTool)
+		} else {
+			mcpServer.AddTools(groupTool)
 		}
 	}
 
@@ -152,15 +203,21 @@ func (s *ToolGroupService) CreateToolGroup(group *model.ToolGroup) error {
 	}
 
 	// finally, add the proxy MCPs to the tool group MCPs manager so that it is ready to serve
+	s.setLazyGroup(group.Name, group.LazyToolLoading)
 	s.addToolGroupMCPServer(group.Name, mcpServer)
 	s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
 
+	// clients may already reference this group by name, so their cached access decisions are stale
+	s.InvalidateAccessIndex()
+
 	// Log tool group creation
 	s.auditService.LogCreate(context.Background(), model.AuditEntityToolGroup, group.Name, group.Name, map[string]interface{}{
 		"description":      group.Description,
 		"tools_count":      len(toolNames),
 		"prompts_count":    len(promptNames),
 		"included_servers": group.IncludedServers,
+		"aliases_count":    len(aliases),
+		"tool_arguments":   group.ToolArguments,
 	})
 
 	return nil
@@ -204,10 +261,30 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 
 	// if nothing was actually changed in the group, no need to proceed further
 	if updatedGroup.Description == oldGroup.Description && len(toolsAdded) == 0 && len(toolsRemoved) == 0 &&
-		len(promptsAdded) == 0 && len(promptsRemoved) == 0 {
+		len(promptsAdded) == 0 && len(promptsRemoved) == 0 && updatedGroup.LazyToolLoading == oldGroup.LazyToolLoading &&
+		bytes.Equal(updatedGroup.IncludedServersFilter, oldGroup.IncludedServersFilter) &&
+		bytes.Equal(updatedGroup.ToolRules, oldGroup.ToolRules) &&
+		bytes.Equal(updatedGroup.ToolAliases, oldGroup.ToolAliases) &&
+		bytes.Equal(updatedGroup.ToolArguments, oldGroup.ToolArguments) {
 		return oldGroup, nil
 	}
 
+	oldAliases, err := oldGroup.GetToolAliases()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool aliases in original group: %w", err)
+	}
+	updatedAliases, err := updatedGroup.GetToolAliases()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool aliases: %w", err)
+	}
+	presets, err := updatedGroup.GetToolArguments()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool arguments: %w", err)
+	}
+	if err := checkArgumentPresetTargets(presets, updatedToolNames, updatedAliases); err != nil {
+		return nil, err
+	}
+
 	// determine the changes to make to the tool group's proxy MCP server instances (normal + SSE)
 	// all changes are ultimately made at the end of this method to avoid inconsistent state in case of errors.
 	mcpServer, exists := s.GetToolGroupMCPServer(name)
@@ -219,9 +296,14 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 		return nil, fmt.Errorf("SSE MCP server for tool group %s does not exist", name)
 	}
 
-	// tools added to the group must be added to its MCP server instances
-	var sseToolsToAdd, normalToolsToAdd []mcpgo.Tool
-	for _, toolName := range toolsAdded {
+	// tools added to the group must be added to its MCP server instances.
+	// if the argument presets changed, all tools of the group are re-added to apply the new presets.
+	toolsToAdd := toolsAdded
+	if !bytes.Equal(updatedGroup.ToolArguments, oldGroup.ToolArguments) {
+		toolsToAdd = updatedToolNames
+	}
+	var sseToolsToAdd, normalToolsToAdd []server.ServerTool
+	for _, toolName := range toolsToAdd {
 		tool, exists := s.mcpService.GetToolInstance(toolName)
 		if !exists {
 			return nil, fmt.Errorf("tool %s does not exist or is disabled", toolName)
@@ -232,10 +314,14 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 			return nil, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", toolName, err)
 		}
 
+		groupTool, err := s.groupTool(name, tool, presets)
+		if err != nil {
+			return nil, err
+		}
 		if parentServer.Transport == types.TransportSSE {
-			sseToolsToAdd = append(sseToolsToAdd, tool)
+			sseToolsToAdd = append(sseToolsToAdd, groupTool)
 		} else {
-			normalToolsToAdd = append(normalToolsToAdd, tool)
+			normalToolsToAdd = append(normalToolsToAdd, groupTool)
 		}
 	}
 
@@ -254,6 +340,33 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 		}
 	}
 
+	// aliases are (re)added to the group's MCP server instances, which replaces the previous definition of an alias.
+	// aliases that were removed from the group are deleted from both instances.
+	var sseAliasesToAdd, normalAliasesToAdd []server.ServerTool
+	updatedAliasNames := make([]string, 0, len(updatedAliases))
+	for _, alias := range updatedAliases {
+		tool, sse, err := s.aliasTool(alias)
+		if err != nil {
+			return nil, err
+		}
+		groupTool, err := s.groupTool(name, tool, presets)
+		if err != nil {
+			return nil, err
+		}
+		if sse {
+			sseAliasesToAdd = append(sseAliasesToAdd, groupTool)
+		} else {
+			normalAliasesToAdd = append(normalAliasesToAdd, groupTool)
+		}
+		updatedAliasNames = append(updatedAliasNames, alias.Name)
+	}
+	var aliasesToRemove []string
+	for _, alias := range oldAliases {
+		if !slices.Contains(updatedAliasNames, alias.Name) {
+			aliasesToRemove = append(aliasesToRemove, alias.Name)
+		}
+	}
+
 	// prompts added to the group must be added to its MCP server instances
 	var ssePromptsToAdd, normalPromptsToAdd []mcpgo.Prompt
 	for _, promptName := range promptsAdded {
@@ -290,16 +403,17 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 	}
 
 	// make all the changes together to avoid inconsistent state in case of errors
-	mcpServer.DeleteTools(normalToolsToRemove...)
-	sseMcpServer.DeleteTools(sseToolsToRemove...)
+	mcpServer.DeleteTools(append(normalToolsToRemove, aliasesToRemove...)...)
+	sseMcpServer.DeleteTools(append(sseToolsToRemove, aliasesToRemove...)...)
 	mcpServer.DeletePrompts(normalPromptsToRemove...)
 	sseMcpServer.DeletePrompts(ssePromptsToRemove...)
 
-	for _, tool := range normalToolsToAdd {
-		mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+	// AddTools notifies clients even if there is nothing to add, so only call it when needed
+	if toAdd := append(normalToolsToAdd, normalAliasesToAdd...); len(toAdd) > 0 {
+		mcpServer.AddTools(toAdd...)
 	}
-	for _, tool := range sseToolsToAdd {
-		sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+	if toAdd := append(sseToolsToAdd, sseAliasesToAdd...); len(toAdd) > 0 {
+		sseMcpServer.AddTools(toAdd...)
 	}
 	for _, prompt := range normalPromptsToAdd {
 		mcpServer.AddPrompt(prompt, s.mcpService.GetPromptHandler())
@@ -316,6 +430,26 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 	if err := s.db.Model(&model.ToolGroup{}).Where("name = ?", name).Updates(updatedGroup).Error; err != nil {
 		return nil, fmt.Errorf("failed to update tool group in DB: %w", err)
 	}
+	// Updates() skips zero values, so turning lazy tool loading off and removing the filter, the rules,
+	// the aliases or the argument presets have to be written explicitly
+	err = s.db.Model(&model.ToolGroup{}).Where("name = ?", name).Updates(map[string]any{
+		"lazy_tool_loading":       updatedGroup.LazyToolLoading,
+		"included_servers_filter": updatedGroup.IncludedServersFilter,
+		"tool_rules":              updatedGroup.ToolRules,
+		"tool_aliases":            updatedGroup.ToolAliases,
+		"tool_arguments":          updatedGroup.ToolArguments,
+	}).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tool group in DB: %w", err)
+	}
+	s.InvalidateAccessIndex()
+
+	if updatedGroup.LazyToolLoading != oldGroup.LazyToolLoading {
+		// switching modes changes the tools that sessions of the group list
+		s.setLazyGroup(name, updatedGroup.LazyToolLoading)
+		mcpServer.SendNotificationToAllClients(mcpgo.MethodNotificationToolsListChanged, nil)
+		sseMcpServer.SendNotificationToAllClients(mcpgo.MethodNotificationToolsListChanged, nil)
+	}
 
 	// Log tool group update with detailed changes
 	changes := make(map[string]interface{})
@@ -337,6 +471,15 @@ func (s *ToolGroupService) UpdateToolGroup(name string, updatedGroup *model.Tool
 	if len(promptsRemoved) > 0 {
 		changes["prompts_removed"] = promptsRemoved
 	}
+	if updatedGroup.LazyToolLoading != oldGroup.LazyToolLoading {
+		changes["lazy_tool_loading"] = updatedGroup.LazyToolLoading
+	}
+	if !bytes.Equal(updatedGroup.ToolAliases, oldGroup.ToolAliases) {
+		changes["tool_aliases"] = updatedGroup.ToolAliases
+	}
+	if !bytes.Equal(updatedGroup.ToolArguments, oldGroup.ToolArguments) {
+		changes["tool_arguments"] = updatedGroup.ToolArguments
+	}
 	s.auditService.LogUpdate(context.Background(), model.AuditEntityToolGroup, name, name, changes)
 
 	return oldGroup, nil
@@ -365,11 +508,13 @@ func (s *ToolGroupService) ListToolGroups() ([]model.ToolGroup, error) {
 
 func (s *ToolGroupService) DeleteToolGroup(name string) error {
 	s.deleteToolGroupMCPServers(name)
+	s.setLazyGroup(name, false)
 
 	err := s.db.Unscoped().Where("name = ?", name).Delete(&model.ToolGroup{}).Error
 	if err != nil {
 		return fmt.Errorf("failed to delete toolgroup: %w", err)
 	}
+	s.InvalidateAccessIndex()
 
 	// Log tool group deletion
 	s.auditService.LogDelete(context.Background(), model.AuditEntityToolGroup, name, name)
@@ -393,24 +538,83 @@ func (s *ToolGroupService) GetToolGroupSseMCPServer(name string) (*server.MCPSer
 	return mcpServer, exists
 }
 
+// setLazyGroup turns lazy tool loading on or off for the MCP proxy servers of a tool group.
+func (s *ToolGroupService) setLazyGroup(name string, lazy bool) {
+	s.lazyGroupsMu.Lock()
+	defer s.lazyGroupsMu.Unlock()
+	if lazy {
+		s.lazyGroups[name] = true
+	} else {
+		delete(s.lazyGroups, name)
+	}
+}
+
+// isLazyGroup returns a function that reports whether the MCP proxy servers of a tool group
+// run in lazy tool loading mode.
+func (s *ToolGroupService) isLazyGroup(name string) func() bool {
+	return func() bool {
+		s.lazyGroupsMu.RLock()
+		defer s.lazyGroupsMu.RUnlock()
+		return s.lazyGroups[name]
+	}
+}
+
+// aliasTool returns the definition of a tool exposed under an alias and whether it must be served by
+// the SSE proxy server of the group, which is the case if the underlying tool is provided by an SSE server.
+func (s *ToolGroupService) aliasTool(alias types.ToolAlias) (mcpgo.Tool, bool, error) {
+	tool, err := s.mcpService.AliasTool(alias)
+	if err != nil {
+		return mcpgo.Tool{}, false, fmt.Errorf("invalid tool alias %s: %w", alias.Name, err)
+	}
+	parentServer, err := s.mcpService.GetToolParentServer(alias.Tool)
+	if err != nil {
+		return mcpgo.Tool{}, false, fmt.Errorf("failed to get parent MCP server of the tool %s: %w", alias.Tool, err)
+	}
+	return tool, parentServer.Transport == types.TransportSSE, nil
+}
+
+// aliasesOf returns the names under which an MCP proxy server exposes aliases of the given tools.
+func aliasesOf(srv *server.MCPServer, tools []string) []string {
+	var aliases []string
+	for name, t := range srv.ListTools() {
+		if canonical := mcp.CanonicalToolName(t.Tool); canonical != name && slices.Contains(tools, canonical) {
+			aliases = append(aliases, name)
+		}
+	}
+	return aliases
+}
+
 // newMCPServer creates a new MCP proxy server for a given tool group name.
+// The server offers mcpjungle's meta-tools, which only find, describe, call and activate the tools of the group.
+// The group's tools must be added to the server as returned by groupTool, so that their argument presets apply.
 func (s *ToolGroupService) newMCPServer(groupName string) *server.MCPServer {
-	return server.NewMCPServer(
+	mcpServer := server.NewMCPServer(
 		fmt.Sprintf("MCPJungle proxy MCP server for tool group: %s", groupName),
 		"0.1.0",
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
+		append(
+			s.mcpService.ProxyServerOptions(mcp.ToolGroupProxyEndpoint(groupName), types.TransportStreamableHTTP, s.isLazyGroup(groupName)),
+			server.WithToolCapabilities(true),
+			server.WithPromptCapabilities(true),
+		)...,
 	)
+	s.mcpService.AddMetaTools(mcpServer)
+	return mcpServer
 }
 
 // newSseMCPServer creates a new SSE MCP proxy server for a given tool group name.
+// Just like newMCPServer, the server offers mcpjungle's meta-tools.
 func (s *ToolGroupService) newSseMCPServer(groupName string) *server.MCPServer {
-	return server.NewMCPServer(
+	sseMcpServer := server.NewMCPServer(
 		fmt.Sprintf("MCPJungle proxy MCP server for SSE transport for tool group: %s", groupName),
 		"0.1.0",
-		server.WithToolCapabilities(true),
-		server.WithPromptCapabilities(true),
+		append(
+			s.mcpService.ProxyServerOptions(mcp.ToolGroupProxyEndpoint(groupName), types.TransportSSE, s.isLazyGroup(groupName)),
+			server.WithToolCapabilities(true),
+			server.WithPromptCapabilities(true),
+		)...,
 	)
+	s.mcpService.AddMetaTools(sseMcpServer)
+	return sseMcpServer
 }
 
 // addToolGroupMCPServer adds or updates the MCP proxy server for a given tool group name.
@@ -458,7 +662,18 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 		if err != nil {
 			return fmt.Errorf("failed to resolve effective tools for group %s: %w", group.Name, err)
 		}
-		// TODO: Log a warning if a group has no tools, ie, len(toolNames) == 0
+		l := s.logger.WithFields(logger.String("tool_group", group.Name))
+		aliases, err := group.GetToolAliases()
+		if err != nil {
+			return fmt.Errorf("invalid tool aliases in group %s: %w", group.Name, err)
+		}
+		if len(toolNames) == 0 && len(aliases) == 0 {
+			l.Warn("tool group has no tools")
+		}
+		presets, err := group.GetToolArguments()
+		if err != nil {
+			return fmt.Errorf("invalid tool arguments in group %s: %w", group.Name, err)
+		}
 
 		mcpServer := s.newMCPServer(group.Name)
 		sseMcpServer := s.newSseMCPServer(group.Name)
@@ -468,7 +683,7 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 			if !exists {
 				// it is possible that a tool group contains a tool that does not exist.
 				// this should not prevent server startup, so just skip instead of returning an error.
-				// TODO: Add a warning log here.
+				l.Warn("tool group includes a tool that does not exist", logger.String("tool", name))
 				continue
 			}
 
@@ -477,10 +692,35 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 				return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", name, err)
 			}
 
+			groupTool, err := s.groupTool(group.Name, tool, presets)
+			if err != nil {
+				// the tool's schema may have changed since the group was created.
+				// the tool must not be exposed without its fixed arguments, so skip it.
+				l.Warn("skipping tool with invalid argument presets", logger.String("tool", name), logger.ErrorField(err))
+				continue
+			}
 			if parentServer.Transport == types.TransportSSE {
-				sseMcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+				sseMcpServer.AddTools(groupTool)
 			} else {
-				mcpServer.AddTool(tool, s.mcpService.MCPProxyToolCallHandler)
+				mcpServer.AddTools(groupTool)
+			}
+		}
+
+		for _, alias := range aliases {
+			var groupTool server.ServerTool
+			tool, sse, err := s.aliasTool(alias)
+			if err == nil {
+				groupTool, err = s.groupTool(group.Name, tool, presets)
+			}
+			if err != nil {
+				// just like a missing tool, an alias of a missing tool should not prevent server startup
+				l.Warn("skipping tool alias", logger.String("alias", alias.Name), logger.ErrorField(err))
+				continue
+			}
+			if sse {
+				sseMcpServer.AddTools(groupTool)
+			} else {
+				mcpServer.AddTools(groupTool)
 			}
 		}
 
@@ -495,7 +735,7 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 			if !exists {
 				// it is possible that a tool group contains a prompt that does not exist.
 				// this should not prevent server startup, so just skip instead of returning an error.
-				// TODO: Add a warning log here.
+				l.Warn("tool group includes a prompt that does not exist", logger.String("prompt", name))
 				continue
 			}
 
@@ -511,6 +751,7 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 			}
 		}
 
+		s.setLazyGroup(group.Name, group.LazyToolLoading)
 		s.addToolGroupMCPServer(group.Name, mcpServer)
 		s.addToolGroupSseMCPServer(group.Name, sseMcpServer)
 	}
@@ -519,8 +760,10 @@ func (s *ToolGroupService) initToolGroupMCPServers() error {
 }
 
 // handleToolDeletion is a callback that is called when one or more tools is deleted or disabled.
-// It removes the tools from all tool group MCP proxy servers.
+// It removes the tools and their aliases from all tool group MCP proxy servers.
 func (s *ToolGroupService) handleToolDeletion(tools ...string) {
+	s.InvalidateAccessIndex()
+
 	s.mcpServersMu.RLock()
 	defer s.mcpServersMu.RUnlock()
 
@@ -528,42 +771,25 @@ func (s *ToolGroupService) handleToolDeletion(tools ...string) {
 	defer s.sseMcpServerMu.Unlock()
 
 	for _, mcpServer := range s.mcpServers {
-		mcpServer.DeleteTools(tools...)
+		mcpServer.DeleteTools(append(aliasesOf(mcpServer, tools), tools...)...)
 	}
 
 	for _, sseMcpServer := range s.sseMcpServers {
-		sseMcpServer.DeleteTools(tools...)
+		sseMcpServer.DeleteTools(append(aliasesOf(sseMcpServer, tools), tools...)...)
 	}
 }
 
 // handleToolAddition is a callback that is called when a tool is added or (re)enabled in mcpjungle.
-// this callback adds the new tool to MCP proxy servers of all groups that include it.
+// this callback adds the new tool to MCP proxy servers of all groups that include it or expose it under an alias.
 func (s *ToolGroupService) handleToolAddition(newTool string) error {
+	s.InvalidateAccessIndex()
+
 	// get all tool groups from the database
 	groups, err := s.ListToolGroups()
 	if err != nil {
 		return fmt.Errorf("failed to list tool groups from DB: %w", err)
 	}
 
-	// find all groups that include the added tool
-	groupsToUpdate := make([]string, 0, len(groups))
-	for i := range groups {
-		name := groups[i].Name
-		groupTools, err := groups[i].ResolveEffectiveTools(s.mcpService)
-		if err != nil {
-			return fmt.Errorf("failed to resolve effective tools for group %s: %w", name, err)
-		}
-		for _, t := range groupTools {
-			if t != newTool {
-				continue
-			}
-			// current group includes the added tool, so add the tool instance to the group's MCP server
-			groupsToUpdate = append(groupsToUpdate, name)
-			// no need to check other tools in this group anymore, so exit the loop and move on to the next group
-			break
-		}
-	}
-
 	newToolInstance, exists := s.mcpService.GetToolInstance(newTool)
 	if !exists {
 		// this should not happen because the tool should exist if we are in this callback
@@ -574,6 +800,59 @@ func (s *ToolGroupService) handleToolAddition(newTool string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get parent MCP server of the tool %s: %w", newTool, err)
 	}
+	toolRecord, err := s.mcpService.GetTool(newTool)
+	if err != nil {
+		return fmt.Errorf("failed to get tool %s: %w", newTool, err)
+	}
+
+	// find all groups that include the added tool or expose it under an alias,
+	// and the tools to add to the MCP servers of each group with the group's argument presets applied.
+	toolsToAdd := make(map[string][]server.ServerTool)
+	for i := range groups {
+		name := groups[i].Name
+		l := s.logger.WithFields(logger.String("tool_group", name))
+
+		presets, err := groups[i].GetToolArguments()
+		if err != nil {
+			return fmt.Errorf("invalid tool arguments in group %s: %w", name, err)
+		}
+
+		// only the added tool is matched against the group, resolving all of its tools would list the registry
+		included, err := groups[i].IncludesTool(parentServer.Name, toolRecord)
+		if err != nil {
+			return fmt.Errorf("failed to resolve effective tools for group %s: %w", name, err)
+		}
+		if included {
+			// current group includes the added tool, so add the tool instance to the group's MCP server
+			groupTool, err := s.groupTool(name, newToolInstance, presets)
+			if err != nil {
+				// the tool must not be exposed without its fixed arguments
+				l.Warn("skipping tool with invalid argument presets", logger.String("tool", newTool), logger.ErrorField(err))
+			} else {
+				toolsToAdd[name] = append(toolsToAdd[name], groupTool)
+			}
+		}
+
+		aliases, err := groups[i].GetToolAliases()
+		if err != nil {
+			return fmt.Errorf("invalid tool aliases in group %s: %w", name, err)
+		}
+		for _, alias := range aliases {
+			if alias.Tool != newTool {
+				continue
+			}
+			var groupTool server.ServerTool
+			tool, _, err := s.aliasTool(alias)
+			if err == nil {
+				groupTool, err = s.groupTool(name, tool, presets)
+			}
+			if err != nil {
+				l.Warn("skipping tool alias", logger.String("alias", alias.Name), logger.ErrorField(err))
+				continue
+			}
+			toolsToAdd[name] = append(toolsToAdd[name], groupTool)
+		}
+	}
 
 	// add the new tool instance to all relevant MCP proxy servers
 	s.mcpServersMu.RLock()
@@ -582,18 +861,13 @@ func (s *ToolGroupService) handleToolAddition(newTool string) error {
 	s.sseMcpServerMu.Lock()
 	defer s.sseMcpServerMu.Unlock()
 
-	for _, name := range groupsToUpdate {
+	for name, tools := range toolsToAdd {
+		srv, exists := s.mcpServers[name]
 		if parentServer.Transport == types.TransportSSE {
-			sseMcpServer, exists := s.sseMcpServers[name]
-			if exists {
-				sseMcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
-			}
-			continue
+			srv, exists = s.sseMcpServers[name]
 		}
-
-		mcpServer, exists := s.mcpServers[name]
 		if exists {
-			mcpServer.AddTool(newToolInstance, s.mcpService.MCPProxyToolCallHandler)
+			srv.AddTools(tools...)
 		}
 	}
 
@@ -603,6 +877,8 @@ func (s *ToolGroupService) handleToolAddition(newTool string) error {
 // handlePromptDeletion is a callback that is called when one or more prompts is deleted or disabled.
 // It removes the prompts from all tool group MCP proxy servers.
 func (s *ToolGroupService) handlePromptDeletion(prompts ...string) {
+	s.InvalidateAccessIndex()
+
 	s.mcpServersMu.RLock()
 	defer s.mcpServersMu.RUnlock()
 
@@ -621,6 +897,8 @@ func (s *ToolGroupService) handlePromptDeletion(prompts ...string) {
 // handlePromptAddition is a callback that is called when a prompt is added or (re)enabled in mcpjungle.
 // this callback adds the new prompt to MCP proxy servers of all groups that include it.
 func (s *ToolGroupService) handlePromptAddition(newPrompt string) error {
+	s.InvalidateAccessIndex()
+
 	// get all tool groups from the database
 	groups, err := s.ListToolGroups()
 	if err != nil {
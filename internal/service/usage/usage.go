@@ -0,0 +1,170 @@
+// Package usage provides analytics about which tools are called, by which MCP clients and how often.
+package usage
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UsageService aggregates tool calls into hourly buckets and reports on them.
+type UsageService struct {
+	db *gorm.DB
+
+	// now returns the current time, it can be overridden in tests.
+	now func() time.Time
+}
+
+// NewUsageService creates a new UsageService.
+func NewUsageService(db *gorm.DB) *UsageService {
+	return &UsageService{db: db, now: time.Now}
+}
+
+// Record counts a tool call in the bucket of the current hour.
+// toolName must be the canonical name of the tool (eg- "github__search_code").
+func (u *UsageService) Record(serverName, toolName, clientName, outcome string, duration time.Duration) error {
+	now := u.now().UTC()
+	usage := &model.ToolUsage{
+		Hour:       now.Truncate(time.Hour),
+		ServerName: serverName,
+		ToolName:   toolName,
+		ClientName: clientName,
+		Outcome:    outcome,
+		Calls:      1,
+		DurationMs: duration.Milliseconds(),
+	}
+	err := u.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "hour"}, {Name: "server_name"}, {Name: "tool_name"}, {Name: "client_name"}, {Name: "outcome"},
+		},
+		DoUpdates: clause.Assignments(map[string]any{
+			"calls":       gorm.Expr("tool_usages.calls + 1"),
+			"duration_ms": gorm.Expr("tool_usages.duration_ms + ?", usage.DurationMs),
+			"updated_at":  now,
+		}),
+	}).Create(usage).Error
+	if err != nil {
+		return fmt.Errorf("failed to record usage of tool %s: %w", toolName, err)
+	}
+	return nil
+}
+
+// Report aggregates the tool calls made between since and until by the given dimension.
+// The rows are sorted by the number of calls, most called first, and truncated to limit rows if limit is positive.
+// Calls are counted per hour, so the whole hour that since falls in is included.
+func (u *UsageService) Report(since, until time.Time, by types.UsageGroupBy, limit int) ([]types.UsageRow, error) {
+	buckets, err := u.buckets(since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make(map[string]*types.UsageRow)
+	durations := make(map[string]int64)
+	for _, b := range buckets {
+		var name string
+		switch by {
+		case types.UsageGroupByClient:
+			name = b.ClientName
+		case types.UsageGroupByServer:
+			name = b.ServerName
+		default:
+			name = b.ToolName
+		}
+
+		r, ok := rows[name]
+		if !ok {
+			r = &types.UsageRow{Name: name, Outcomes: make(map[string]int64)}
+			rows[name] = r
+		}
+		r.Calls += b.Calls
+		r.Outcomes[b.Outcome] += b.Calls
+		if b.Hour.After(r.LastCalledAt) {
+			r.LastCalledAt = b.Hour
+		}
+		durations[name] += b.DurationMs
+	}
+
+	result := make([]types.UsageRow, 0, len(rows))
+	for name, r := range rows {
+		if r.Calls > 0 {
+			r.AvgDurationMs = float64(durations[name]) / float64(r.Calls)
+		}
+		result = append(result, *r)
+	}
+	slices.SortFunc(result, func(a, b types.UsageRow) int {
+		return cmp.Or(cmp.Compare(b.Calls, a.Calls), strings.Compare(a.Name, b.Name))
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+// UnusedTools returns the canonical names of the given tools that are enabled, were registered before since
+// and haven't been called since then.
+// These tools are candidates to disable.
+// The names of the tools must be canonical (eg- "github__search_code"), as returned by MCPService.ListTools.
+func (u *UsageService) UnusedTools(tools []model.Tool, since time.Time) ([]string, error) {
+	var used []string
+	err := u.db.Model(&model.ToolUsage{}).
+		Where("hour >= ?", since.UTC().Truncate(time.Hour)).
+		Distinct().
+		Pluck("tool_name", &used).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the used tools: %w", err)
+	}
+
+	unused := make([]string, 0)
+	for _, t := range tools {
+		if !t.Enabled || !t.CreatedAt.Before(since) || slices.Contains(used, t.Name) {
+			continue
+		}
+		unused = append(unused, t.Name)
+	}
+	slices.Sort(unused)
+	return unused, nil
+}
+
+func (u *UsageService) buckets(since, until time.Time) ([]model.ToolUsage, error) {
+	var buckets []model.ToolUsage
+	err := u.db.
+		Where("hour >= ? AND hour <= ?", since.UTC().Truncate(time.Hour), until.UTC()).
+		Find(&buckets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tool usage: %w", err)
+	}
+	return buckets, nil
+}
+
+// ParseSince parses the start of a usage report's time range relative to now.
+// It accepts a number of days (eg- "7d"), a Go duration (eg- "12h") or an RFC3339 timestamp.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return time.Time{}, fmt.Errorf("invalid number of days: %s", s)
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("duration must be positive: %s", s)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"invalid time: %s (expected a number of days like '7d', a duration like '12h' or an RFC3339 timestamp)", s,
+		)
+	}
+	return t, nil
+}
@@ -0,0 +1,163 @@
+package usage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mcpjungle/mcpjungle/internal/model"
+	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
+	"github.com/mcpjungle/mcpjungle/pkg/types"
+)
+
+// setupUsageTest returns a usage service whose clock can be moved forward by the returned function.
+func setupUsageTest(t *testing.T) (*testhelpers.TestDBSetup, *UsageService, func(time.Duration)) {
+	t.Helper()
+
+	setup := testhelpers.SetupTestDB(t)
+	svc := NewUsageService(setup.DB)
+
+	clock := testhelpers.NewFakeClock(time.Date(2025, 3, 10, 12, 15, 0, 0, time.UTC))
+	svc.now = clock.Now
+
+	return setup, svc, clock.Advance
+}
+
+func TestRecordAggregatesCallsPerHour(t *testing.T) {
+	setup, svc, advance := setupUsageTest(t)
+	defer setup.Cleanup()
+
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "success", 100*time.Millisecond))
+	advance(10 * time.Minute)
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "success", 300*time.Millisecond))
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "error", 50*time.Millisecond))
+	advance(time.Hour)
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "success", 200*time.Millisecond))
+
+	var buckets []model.ToolUsage
+	testhelpers.AssertNoError(t, setup.DB.Order("hour, outcome").Find(&buckets).Error)
+	testhelpers.AssertEqual(t, 3, len(buckets))
+
+	testhelpers.AssertEqual(t, "error", buckets[0].Outcome)
+	testhelpers.AssertEqual(t, int64(1), buckets[0].Calls)
+
+	testhelpers.AssertEqual(t, "success", buckets[1].Outcome)
+	testhelpers.AssertEqual(t, int64(2), buckets[1].Calls)
+	testhelpers.AssertEqual(t, int64(400), buckets[1].DurationMs)
+	testhelpers.AssertTrue(t, buckets[1].Hour.Equal(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)), "hour must be truncated")
+
+	testhelpers.AssertEqual(t, int64(1), buckets[2].Calls)
+}
+
+func TestReport(t *testing.T) {
+	setup, svc, advance := setupUsageTest(t)
+	defer setup.Cleanup()
+
+	// an old call outside the time range of the report
+	testhelpers.AssertNoError(t, svc.Record("github", "github__get_issue", "agent", "success", time.Second))
+	advance(10 * 24 * time.Hour)
+
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "success", 100*time.Millisecond))
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "ide", "error", 300*time.Millisecond))
+	testhelpers.AssertNoError(t, svc.Record("github", "github__get_issue", "ide", "success", 200*time.Millisecond))
+	advance(time.Hour)
+	testhelpers.AssertNoError(t, svc.Record("time", "time__now", "ide", "success", 0))
+
+	now := svc.now()
+	since := now.AddDate(0, 0, -7)
+
+	t.Run("by tool", func(t *testing.T) {
+		rows, err := svc.Report(since, now, types.UsageGroupByTool, 0)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 3, len(rows))
+
+		testhelpers.AssertEqual(t, "github__search_code", rows[0].Name)
+		testhelpers.AssertEqual(t, int64(2), rows[0].Calls)
+		testhelpers.AssertEqual(t, int64(1), rows[0].Outcomes["success"])
+		testhelpers.AssertEqual(t, int64(1), rows[0].Outcomes["error"])
+		testhelpers.AssertEqual(t, 200.0, rows[0].AvgDurationMs)
+
+		// ties are sorted by name
+		testhelpers.AssertEqual(t, "github__get_issue", rows[1].Name)
+		testhelpers.AssertEqual(t, int64(1), rows[1].Calls)
+		testhelpers.AssertEqual(t, "time__now", rows[2].Name)
+		testhelpers.AssertTrue(t, rows[2].LastCalledAt.After(rows[1].LastCalledAt), "last call must be the latest hour")
+	})
+
+	t.Run("by client", func(t *testing.T) {
+		rows, err := svc.Report(since, now, types.UsageGroupByClient, 0)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 2, len(rows))
+		testhelpers.AssertEqual(t, "ide", rows[0].Name)
+		testhelpers.AssertEqual(t, int64(3), rows[0].Calls)
+		testhelpers.AssertEqual(t, "agent", rows[1].Name)
+		testhelpers.AssertEqual(t, int64(1), rows[1].Calls)
+	})
+
+	t.Run("by server with limit", func(t *testing.T) {
+		rows, err := svc.Report(since, now, types.UsageGroupByServer, 1)
+		testhelpers.AssertNoError(t, err)
+		testhelpers.AssertEqual(t, 1, len(rows))
+		testhelpers.AssertEqual(t, "github", rows[0].Name)
+		testhelpers.AssertEqual(t, int64(3), rows[0].Calls)
+	})
+}
+
+func TestUnusedTools(t *testing.T) {
+	setup, svc, advance := setupUsageTest(t)
+	defer setup.Cleanup()
+
+	testhelpers.AssertNoError(t, svc.Record("github", "github__get_issue", "agent", "success", 0))
+	advance(10 * 24 * time.Hour)
+	testhelpers.AssertNoError(t, svc.Record("github", "github__search_code", "agent", "success", 0))
+
+	now := svc.now()
+	since := now.AddDate(0, 0, -7)
+	registered := since.Add(-24 * time.Hour)
+
+	tools := []model.Tool{
+		{Name: "github__search_code", Enabled: true},
+		{Name: "github__get_issue", Enabled: true},
+		{Name: "github__create_issue", Enabled: false},
+		{Name: "time__now", Enabled: true},
+	}
+	for i := range tools {
+		tools[i].CreatedAt = registered
+	}
+	// a tool registered recently isn't flagged because it hasn't had the chance to be called yet
+	recent := model.Tool{Name: "time__convert", Enabled: true}
+	recent.CreatedAt = now.Add(-time.Hour)
+	tools = append(tools, recent)
+
+	unused, err := svc.UnusedTools(tools, since)
+	testhelpers.AssertNoError(t, err)
+	testhelpers.AssertEqual(t, 2, len(unused))
+	testhelpers.AssertEqual(t, "github__get_issue", unused[0])
+	testhelpers.AssertEqual(t, "time__now", unused[1])
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"7d", time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"12h", time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"2025-03-01T00:00:00Z", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSince(tt.input, now)
+			testhelpers.AssertNoError(t, err)
+			testhelpers.AssertTrue(t, got.Equal(tt.want), "got "+got.String())
+		})
+	}
+
+	for _, input := range []string{"", "0d", "xd", "-1h", "last week"} {
+		t.Run("invalid "+input, func(t *testing.T) {
+			_, err := ParseSince(input, now)
+			testhelpers.AssertError(t, err)
+		})
+	}
+}
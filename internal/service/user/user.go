@@ -9,6 +9,7 @@ import (
 	"github.com/mcpjungle/mcpjungle/internal"
 	"github.com/mcpjungle/mcpjungle/internal/model"
 	"github.com/mcpjungle/mcpjungle/internal/service/audit"
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 	"gorm.io/gorm"
 )
@@ -19,10 +20,10 @@ type UserService struct {
 	auditService *audit.AuditService
 }
 
-func NewUserService(db *gorm.DB) *UserService {
+func NewUserService(db *gorm.DB, l logger.Logger) *UserService {
 	return &UserService{
 		db:           db,
-		auditService: audit.NewAuditService(db),
+		auditService: audit.NewAuditService(db, l),
 	}
 }
 
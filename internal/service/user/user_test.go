@@ -3,6 +3,7 @@ package user
 import (
 	"testing"
 
+	"github.com/mcpjungle/mcpjungle/pkg/logger"
 	"github.com/mcpjungle/mcpjungle/pkg/testhelpers"
 	"github.com/mcpjungle/mcpjungle/pkg/types"
 )
@@ -10,7 +11,7 @@ import (
 func TestNewUserService(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	testhelpers.AssertNotNil(t, svc)
 	testhelpers.AssertEqual(t, setup.DB, svc.db)
 }
@@ -18,7 +19,7 @@ func TestNewUserService(t *testing.T) {
 func TestCreateUser(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	username := "testuser2"
 	user, err := svc.CreateUser(username)
 	testhelpers.AssertNoError(t, err)
@@ -34,7 +35,7 @@ func TestCreateUser(t *testing.T) {
 func TestCreateUserWithExistingUsername(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	username := "testuser2"
 	// Create first user
 	user1, _ := svc.CreateUser(username)
@@ -50,7 +51,7 @@ func TestCreateUserWithExistingUsername(t *testing.T) {
 func TestCreateAdminUser(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	user, err := svc.CreateAdminUser()
 	testhelpers.AssertNoError(t, err)
 	testhelpers.AssertNotNil(t, user)
@@ -65,7 +66,7 @@ func TestCreateAdminUser(t *testing.T) {
 func TestGetUserByAccessToken(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	// Create a test user first
 	username := "testuser2"
 	user, _ := svc.CreateUser(username)
@@ -82,7 +83,7 @@ func TestGetUserByAccessToken(t *testing.T) {
 func TestListUsers(t *testing.T) {
 	setup := testhelpers.SetupTestDB(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	// Initially should be empty
 	users, err := svc.ListUsers()
 	testhelpers.AssertNoError(t, err)
@@ -109,7 +110,7 @@ func TestListUsers(t *testing.T) {
 func TestDeleteUser(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	// Create a test user
 	username := "testuser2"
 	user, _ := svc.CreateUser(username)
@@ -127,7 +128,7 @@ func TestDeleteUser(t *testing.T) {
 func TestDeleteUserNotFound(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	// Try to delete non-existent user
 	err := svc.DeleteUser("nonexistent")
 	testhelpers.AssertError(t, err)
@@ -136,7 +137,7 @@ func TestDeleteUserNotFound(t *testing.T) {
 func TestDeleteAdminUser(t *testing.T) {
 	setup, _ := testhelpers.SetupUserTest(t)
 	defer setup.Cleanup()
-	svc := NewUserService(setup.DB)
+	svc := NewUserService(setup.DB, logger.NewNop())
 	// Create admin user
 	admin, _ := svc.CreateAdminUser()
 	// Try to delete admin user (should fail)
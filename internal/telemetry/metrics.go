@@ -16,8 +16,48 @@ const (
 	ToolCallOutcomeSuccess ToolCallOutcome = "success"
 	// ToolCallOutcomeError indicates a failed tool call
 	ToolCallOutcomeError ToolCallOutcome = "error"
+	// ToolCallOutcomeRateLimited indicates a tool call that was rejected by a rate limit or quota
+	ToolCallOutcomeRateLimited ToolCallOutcome = "rate_limited"
+	// ToolCallOutcomeTimeout indicates a tool call that the upstream MCP server didn't respond to in time
+	ToolCallOutcomeTimeout ToolCallOutcome = "timeout"
 )
 
+// AuthFailureReason is the reason a request was rejected by authentication or authorization.
+type AuthFailureReason string
+
+const (
+	// AuthFailureMissingToken indicates a request without an access token
+	AuthFailureMissingToken AuthFailureReason = "missing_token"
+	// AuthFailureInvalidToken indicates a request with an access token that doesn't belong to any user or MCP client
+	AuthFailureInvalidToken AuthFailureReason = "invalid_token"
+	// AuthFailureInsufficientRole indicates a user without the role required for the request
+	AuthFailureInsufficientRole AuthFailureReason = "insufficient_role"
+	// AuthFailureAccessDenied indicates an MCP client that isn't allowed to access a tool or prompt
+	AuthFailureAccessDenied AuthFailureReason = "access_denied"
+)
+
+// PayloadDirection tells whether a payload was sent to or received from an upstream MCP server.
+type PayloadDirection string
+
+const (
+	// PayloadDirectionRequest is the payload of a call sent to an upstream MCP server, eg- tool arguments
+	PayloadDirectionRequest PayloadDirection = "request"
+	// PayloadDirectionResponse is the payload returned by an upstream MCP server, eg- a tool result
+	PayloadDirectionResponse PayloadDirection = "response"
+)
+
+// InventoryCounts holds the number of MCP servers and tools known to mcpjungle.
+type InventoryCounts struct {
+	RegisteredServers int64
+	// EnabledServers is the number of servers with at least one enabled tool
+	EnabledServers  int64
+	RegisteredTools int64
+	EnabledTools    int64
+}
+
+// InventoryObserver returns the current inventory counts. It is called whenever metrics are collected.
+type InventoryObserver func(ctx context.Context) (InventoryCounts, error)
+
 const (
 	// PromptCallOutcomeSuccess indicates a successful prompt call
 	PromptCallOutcomeSuccess PromptCallOutcome = "success"
@@ -33,4 +73,43 @@ type CustomMetrics interface {
 
 	// RecordPromptCall records a prompt invocation, its latency, and its outcome (success or error).
 	RecordPromptCall(ctx context.Context, serverName, promptName string, outcome PromptCallOutcome, elapsedTime time.Duration)
+
+	// RecordRateLimitRejection records a tool call rejected by the rate limit or quota of the given scope and target.
+	RecordRateLimitRejection(ctx context.Context, scope, target string)
+
+	// RecordServerQueueDepthChange records a change in the number of calls waiting for an upstream MCP server
+	// that reached its max concurrency.
+	RecordServerQueueDepthChange(ctx context.Context, serverName string, delta int64)
+
+	// RecordServerQueueWait records the time a call waited for a free slot of an upstream MCP server,
+	// and whether it was eventually admitted.
+	RecordServerQueueWait(ctx context.Context, serverName string, waitTime time.Duration, admitted bool)
+
+	// RecordCircuitBreakerStateChange records a transition of an upstream MCP server's circuit breaker
+	// to the given state ("closed", "open" or "half_open").
+	RecordCircuitBreakerStateChange(ctx context.Context, serverName, state string)
+
+	// RecordUpstreamRetry records a retry of a failed call to an upstream MCP server.
+	RecordUpstreamRetry(ctx context.Context, serverName string)
+
+	// RecordUpstreamSession records the creation of a session with an upstream MCP server, ie,
+	// connecting to it and completing the initialize handshake, along with its latency and whether it succeeded.
+	RecordUpstreamSession(ctx context.Context, serverName, transport string, elapsedTime time.Duration, success bool)
+
+	// RecordActiveSessionsChange records a change in the number of MCP client sessions connected to an endpoint
+	// of the MCP proxy, eg- the global proxy or a tool group, using the given transport.
+	RecordActiveSessionsChange(ctx context.Context, endpoint, transport string, delta int64)
+
+	// RecordAuthFailure records a request rejected by authentication or authorization.
+	RecordAuthFailure(ctx context.Context, reason AuthFailureReason)
+
+	// RecordClientToolCall records a tool call made by an MCP client and its outcome.
+	RecordClientToolCall(ctx context.Context, clientName, serverName string, outcome ToolCallOutcome)
+
+	// RecordToolCallPayloadSize records the size in bytes of the arguments or the result of a tool call.
+	RecordToolCallPayloadSize(ctx context.Context, serverName, toolName string, direction PayloadDirection, size int)
+
+	// ObserveInventory registers a function that reports the number of registered and enabled servers and tools.
+	// The function is called every time metrics are collected.
+	ObserveInventory(observer InventoryObserver) error
 }
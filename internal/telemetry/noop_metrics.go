@@ -25,3 +25,56 @@ func (m *NoopCustomMetrics) RecordPromptCall(
 ) {
 	// No-op
 }
+
+func (m *NoopCustomMetrics) RecordRateLimitRejection(ctx context.Context, scope, target string) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordServerQueueDepthChange(ctx context.Context, serverName string, delta int64) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordServerQueueWait(
+	ctx context.Context, serverName string, waitTime time.Duration, admitted bool,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordCircuitBreakerStateChange(ctx context.Context, serverName, state string) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordUpstreamRetry(ctx context.Context, serverName string) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordUpstreamSession(
+	ctx context.Context, serverName, transport string, elapsedTime time.Duration, success bool,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordActiveSessionsChange(ctx context.Context, endpoint, transport string, delta int64) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordAuthFailure(ctx context.Context, reason AuthFailureReason) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordClientToolCall(
+	ctx context.Context, clientName, serverName string, outcome ToolCallOutcome,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) RecordToolCallPayloadSize(
+	ctx context.Context, serverName, toolName string, direction PayloadDirection, size int,
+) {
+	// No-op
+}
+
+func (m *NoopCustomMetrics) ObserveInventory(observer InventoryObserver) error {
+	// No-op
+	return nil
+}
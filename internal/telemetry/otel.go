@@ -3,36 +3,50 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
 // Config holds otel configuration options
 type Config struct {
 	ServiceName string
-	Enabled     bool
+	// Enabled enables metrics
+	Enabled bool
+
+	// TraceExporter is the exporter spans are sent to. Tracing is disabled if it is empty or TraceExporterNone.
+	// Tracing is independent of Enabled, so traces can be collected without exposing metrics.
+	TraceExporter TraceExporter
+	// TraceFile is the file spans are written to when using TraceExporterFile
+	TraceFile string
 }
 
-// Providers holds the Otel configuration and metrics provider.
-// Eventually, it will also hold providers for tracing and logging
+// Providers holds the Otel configuration and the metrics and tracing providers.
+// Eventually, it will also hold a provider for logging
 type Providers struct {
 	Config        *Config
 	MeterProvider *sdkmetric.MeterProvider
 	Meter         metric.Meter
+
+	TracerProvider *sdktrace.TracerProvider
+	// traceFile is closed once the tracer provider has been shut down
+	traceFile io.Closer
 }
 
 // Init initializes Otel with the provided configuration
 func Init(ctx context.Context, config *Config) (*Providers, error) {
-	// If otel is disabled, return empty providers
-	if !config.Enabled {
-		return &Providers{
-			Config: config,
-		}, nil
+	providers := &Providers{
+		Config: config,
+	}
+	// If both metrics and tracing are disabled, return empty providers
+	if !config.Enabled && !config.isTracingEnabled() {
+		return providers, nil
 	}
 
 	// Create resource with service information
@@ -49,9 +63,23 @@ func Init(ctx context.Context, config *Config) (*Providers, error) {
 		return nil, fmt.Errorf("failed to create otel resource: %w", err)
 	}
 
+	if config.isTracingEnabled() {
+		tp, traceFile, err := initTracing(ctx, config, res)
+		if err != nil {
+			return nil, err
+		}
+		providers.TracerProvider = tp
+		providers.traceFile = traceFile
+	}
+
+	if !config.Enabled {
+		return providers, nil
+	}
+
 	// Create Prometheus exporter
 	exporter, err := prometheus.New()
 	if err != nil {
+		_ = providers.Shutdown(ctx)
 		return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
 	}
 
@@ -65,13 +93,9 @@ func Init(ctx context.Context, config *Config) (*Providers, error) {
 	otel.SetMeterProvider(meterProvider)
 
 	// Create meter for the service
-	meter := meterProvider.Meter(config.ServiceName)
+	providers.MeterProvider = meterProvider
+	providers.Meter = meterProvider.Meter(config.ServiceName)
 
-	providers := &Providers{
-		Config:        config,
-		MeterProvider: meterProvider,
-		Meter:         meter,
-	}
 	return providers, nil
 }
 
@@ -85,14 +109,34 @@ func (p *Providers) Shutdown(ctx context.Context) error {
 			return fmt.Errorf("failed to shutdown meter provider: %w", err)
 		}
 	}
+	if p.TracerProvider != nil {
+		// shutting down flushes the spans that haven't been exported yet
+		if err := p.TracerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+		}
+	}
+	if p.traceFile != nil {
+		if err := p.traceFile.Close(); err != nil {
+			return fmt.Errorf("failed to close trace file: %w", err)
+		}
+	}
 	return nil
 }
 
-// IsEnabled returns true if otel is enabled
+// IsEnabled returns true if otel metrics are enabled
 func (p *Providers) IsEnabled() bool {
 	return p.Config.Enabled
 }
 
+// IsTracingEnabled returns true if otel tracing is enabled
+func (p *Providers) IsTracingEnabled() bool {
+	return p.Config.isTracingEnabled()
+}
+
+func (c *Config) isTracingEnabled() bool {
+	return c.TraceExporter != "" && c.TraceExporter != TraceExporterNone
+}
+
 // ServiceName returns the service name configured for otel
 func (p *Providers) ServiceName() string {
 	return p.Config.ServiceName
@@ -13,6 +13,15 @@ const (
 	labelMCPServerName   = "mcp_server_name"
 	labelToolName        = "tool_name"
 	labelToolCallOutcome = "outcome"
+	labelRateLimitScope  = "scope"
+	labelRateLimitTarget = "target"
+	labelQueueAdmitted   = "admitted"
+	labelBreakerState    = "state"
+	labelTransport       = "transport"
+	labelEndpoint        = "endpoint"
+	labelAuthReason      = "reason"
+	labelMCPClientName   = "mcp_client_name"
+	labelPayloadDir      = "direction"
 )
 
 const (
@@ -26,6 +35,30 @@ const (
 type OtelCustomMetrics struct {
 	toolCalls       metric.Int64Counter
 	toolCallLatency metric.Float64Histogram
+
+	rateLimitRejections metric.Int64Counter
+
+	serverQueueDepth metric.Int64UpDownCounter
+	serverQueueWait  metric.Float64Histogram
+
+	circuitBreakerTransitions metric.Int64Counter
+	circuitBreakerOpen        metric.Int64UpDownCounter
+	upstreamRetries           metric.Int64Counter
+
+	upstreamSessionLatency metric.Float64Histogram
+	upstreamInitFailures   metric.Int64Counter
+
+	activeSessions  metric.Int64UpDownCounter
+	authFailures    metric.Int64Counter
+	clientToolCalls metric.Int64Counter
+	payloadSize     metric.Int64Histogram
+
+	// the inventory gauges are observed by callbacks registered with the meter
+	meter             metric.Meter
+	registeredServers metric.Int64ObservableGauge
+	enabledServers    metric.Int64ObservableGauge
+	registeredTools   metric.Int64ObservableGauge
+	enabledTools      metric.Int64ObservableGauge
 }
 
 // NewOtelCustomMetrics initializes all metric instruments required by MCPJungle.
@@ -55,9 +88,177 @@ func NewOtelCustomMetrics(meter metric.Meter) (CustomMetrics, error) {
 		return nil, fmt.Errorf("failed to create tool latency histogram: %w", err)
 	}
 
+	rateLimitRej, err := meter.Int64Counter(
+		"mcpjungle_rate_limit_rejections_total",
+		metric.WithDescription("Total number of tool calls rejected by a rate limit or quota"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rate limit rejections counter: %w", err)
+	}
+
+	queueDepth, err := meter.Int64UpDownCounter(
+		"mcpjungle_server_queue_depth",
+		metric.WithDescription("Number of calls waiting for an upstream MCP server that reached its max concurrency"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server queue depth counter: %w", err)
+	}
+
+	queueWait, err := meter.Float64Histogram(
+		"mcpjungle_server_queue_wait_seconds",
+		metric.WithDescription("Time calls spent waiting for an upstream MCP server that reached its max concurrency"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server queue wait histogram: %w", err)
+	}
+
+	breakerTransitions, err := meter.Int64Counter(
+		"mcpjungle_circuit_breaker_transitions_total",
+		metric.WithDescription("Total number of state transitions of upstream MCP server circuit breakers"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create circuit breaker transitions counter: %w", err)
+	}
+
+	breakerOpen, err := meter.Int64UpDownCounter(
+		"mcpjungle_circuit_breaker_open",
+		metric.WithDescription("Whether the circuit breaker of an upstream MCP server is open (1) or not (0)"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create circuit breaker open counter: %w", err)
+	}
+
+	retries, err := meter.Int64Counter(
+		"mcpjungle_upstream_retries_total",
+		metric.WithDescription("Total number of retried calls to upstream MCP servers"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream retries counter: %w", err)
+	}
+
+	sessionLat, err := meter.Float64Histogram(
+		"mcpjungle_upstream_session_creation_seconds",
+		metric.WithDescription("Time taken to connect to an upstream MCP server and initialize a session with it"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream session latency histogram: %w", err)
+	}
+
+	initFailures, err := meter.Int64Counter(
+		"mcpjungle_upstream_initialize_failures_total",
+		metric.WithDescription("Total number of failures to connect to or initialize a session with an upstream MCP server"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create upstream initialize failures counter: %w", err)
+	}
+
+	activeSessions, err := meter.Int64UpDownCounter(
+		"mcpjungle_active_mcp_sessions",
+		metric.WithDescription("Number of MCP client sessions connected to an endpoint of the MCP proxy"),
+		metric.WithUnit("{session}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create active sessions counter: %w", err)
+	}
+
+	authFailures, err := meter.Int64Counter(
+		"mcpjungle_auth_failures_total",
+		metric.WithDescription("Total number of requests rejected by authentication or authorization"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth failures counter: %w", err)
+	}
+
+	clientToolCalls, err := meter.Int64Counter(
+		"mcpjungle_client_tool_calls_total",
+		metric.WithDescription("Total number of tool calls made by each MCP client"),
+		metric.WithUnit("1"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client tool calls counter: %w", err)
+	}
+
+	payloadSize, err := meter.Int64Histogram(
+		"mcpjungle_tool_call_payload_bytes",
+		metric.WithDescription("Size of the arguments and results of tool calls in bytes"),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool call payload size histogram: %w", err)
+	}
+
+	registeredServers, err := meter.Int64ObservableGauge(
+		"mcpjungle_registered_servers",
+		metric.WithDescription("Number of registered MCP servers"),
+		metric.WithUnit("{server}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registered servers gauge: %w", err)
+	}
+
+	enabledServers, err := meter.Int64ObservableGauge(
+		"mcpjungle_enabled_servers",
+		metric.WithDescription("Number of registered MCP servers with at least one enabled tool"),
+		metric.WithUnit("{server}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create enabled servers gauge: %w", err)
+	}
+
+	registeredTools, err := meter.Int64ObservableGauge(
+		"mcpjungle_registered_tools",
+		metric.WithDescription("Number of registered tools"),
+		metric.WithUnit("{tool}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create registered tools gauge: %w", err)
+	}
+
+	enabledTools, err := meter.Int64ObservableGauge(
+		"mcpjungle_enabled_tools",
+		metric.WithDescription("Number of enabled tools"),
+		metric.WithUnit("{tool}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create enabled tools gauge: %w", err)
+	}
+
 	return &OtelCustomMetrics{
-		toolCalls:       toolInv,
-		toolCallLatency: toolLat,
+		toolCalls:           toolInv,
+		toolCallLatency:     toolLat,
+		rateLimitRejections: rateLimitRej,
+		serverQueueDepth:    queueDepth,
+		serverQueueWait:     queueWait,
+
+		circuitBreakerTransitions: breakerTransitions,
+		circuitBreakerOpen:        breakerOpen,
+		upstreamRetries:           retries,
+
+		upstreamSessionLatency: sessionLat,
+		upstreamInitFailures:   initFailures,
+
+		activeSessions:  activeSessions,
+		authFailures:    authFailures,
+		clientToolCalls: clientToolCalls,
+		payloadSize:     payloadSize,
+
+		meter:             meter,
+		registeredServers: registeredServers,
+		enabledServers:    enabledServers,
+		registeredTools:   registeredTools,
+		enabledTools:      enabledTools,
 	}, nil
 }
 
@@ -85,6 +286,121 @@ func (m *OtelCustomMetrics) RecordPromptCall(
 	m.toolCallLatency.Record(ctx, elapsedTime.Seconds(), metric.WithAttributes(attrs...))
 }
 
+func (m *OtelCustomMetrics) RecordRateLimitRejection(ctx context.Context, scope, target string) {
+	attrs := []attribute.KeyValue{
+		attribute.String(labelRateLimitScope, boundString(scope)),
+		attribute.String(labelRateLimitTarget, boundString(target)),
+	}
+	m.rateLimitRejections.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+func (m *OtelCustomMetrics) RecordServerQueueDepthChange(ctx context.Context, serverName string, delta int64) {
+	m.serverQueueDepth.Add(ctx, delta, metric.WithAttributes(
+		attribute.String(labelMCPServerName, boundString(serverName)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordServerQueueWait(
+	ctx context.Context, serverName string, waitTime time.Duration, admitted bool,
+) {
+	attrs := []attribute.KeyValue{
+		attribute.String(labelMCPServerName, boundString(serverName)),
+		attribute.Bool(labelQueueAdmitted, admitted),
+	}
+	m.serverQueueWait.Record(ctx, waitTime.Seconds(), metric.WithAttributes(attrs...))
+}
+
+func (m *OtelCustomMetrics) RecordCircuitBreakerStateChange(ctx context.Context, serverName, state string) {
+	server := attribute.String(labelMCPServerName, boundString(serverName))
+	m.circuitBreakerTransitions.Add(ctx, 1, metric.WithAttributes(server, attribute.String(labelBreakerState, state)))
+
+	// The breaker only ever enters the open state from a non-open state and always leaves it through half_open,
+	// so the open gauge can be maintained with +1/-1 increments.
+	switch state {
+	case "open":
+		m.circuitBreakerOpen.Add(ctx, 1, metric.WithAttributes(server))
+	case "half_open":
+		m.circuitBreakerOpen.Add(ctx, -1, metric.WithAttributes(server))
+	}
+}
+
+func (m *OtelCustomMetrics) RecordUpstreamRetry(ctx context.Context, serverName string) {
+	m.upstreamRetries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(labelMCPServerName, boundString(serverName)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordUpstreamSession(
+	ctx context.Context, serverName, transport string, elapsedTime time.Duration, success bool,
+) {
+	outcome := "success"
+	if !success {
+		outcome = "error"
+		m.upstreamInitFailures.Add(ctx, 1, metric.WithAttributes(
+			attribute.String(labelMCPServerName, boundString(serverName)),
+			attribute.String(labelTransport, boundString(transport)),
+		))
+	}
+	m.upstreamSessionLatency.Record(ctx, elapsedTime.Seconds(), metric.WithAttributes(
+		attribute.String(labelTransport, boundString(transport)),
+		attribute.String(labelToolCallOutcome, outcome),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordActiveSessionsChange(ctx context.Context, endpoint, transport string, delta int64) {
+	m.activeSessions.Add(ctx, delta, metric.WithAttributes(
+		attribute.String(labelEndpoint, boundString(endpoint)),
+		attribute.String(labelTransport, boundString(transport)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordAuthFailure(ctx context.Context, reason AuthFailureReason) {
+	m.authFailures.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(labelAuthReason, string(reason)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordClientToolCall(
+	ctx context.Context, clientName, serverName string, outcome ToolCallOutcome,
+) {
+	m.clientToolCalls.Add(ctx, 1, metric.WithAttributes(
+		attribute.String(labelMCPClientName, boundString(clientName)),
+		attribute.String(labelMCPServerName, boundString(serverName)),
+		attribute.String(labelToolCallOutcome, string(outcome)),
+	))
+}
+
+func (m *OtelCustomMetrics) RecordToolCallPayloadSize(
+	ctx context.Context, serverName, toolName string, direction PayloadDirection, size int,
+) {
+	m.payloadSize.Record(ctx, int64(size), metric.WithAttributes(
+		attribute.String(labelMCPServerName, boundString(serverName)),
+		attribute.String(labelToolName, boundString(toolName)),
+		attribute.String(labelPayloadDir, string(direction)),
+	))
+}
+
+func (m *OtelCustomMetrics) ObserveInventory(observer InventoryObserver) error {
+	_, err := m.meter.RegisterCallback(
+		func(ctx context.Context, o metric.Observer) error {
+			counts, err := observer(ctx)
+			if err != nil {
+				return err
+			}
+			o.ObserveInt64(m.registeredServers, counts.RegisteredServers)
+			o.ObserveInt64(m.enabledServers, counts.EnabledServers)
+			o.ObserveInt64(m.registeredTools, counts.RegisteredTools)
+			o.ObserveInt64(m.enabledTools, counts.EnabledTools)
+			return nil
+		},
+		m.registeredServers, m.enabledServers, m.registeredTools, m.enabledTools,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register inventory callback: %w", err)
+	}
+	return nil
+}
+
 // boundString ensures strings are capped at maxLen and not empty.
 func boundString(s string) string {
 	if s == "" {
@@ -0,0 +1,109 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TraceExporter selects where the spans recorded by mcpjungle are exported to.
+type TraceExporter string
+
+const (
+	// TraceExporterNone disables tracing.
+	TraceExporterNone TraceExporter = "none"
+	// TraceExporterOTLP exports spans to an OTLP collector over HTTP.
+	// The collector is configured with the standard OTEL_EXPORTER_OTLP_* environment variables.
+	TraceExporterOTLP TraceExporter = "otlp"
+	// TraceExporterStdout writes spans to stdout as JSON, which is useful for debugging without a collector.
+	TraceExporterStdout TraceExporter = "stdout"
+	// TraceExporterFile writes spans as JSON to a file, which is useful for analysing traces offline.
+	TraceExporterFile TraceExporter = "file"
+)
+
+// ValidateTraceExporter returns the trace exporter with the given name.
+// An empty name selects TraceExporterNone. "console" is accepted as an alias of "stdout".
+func ValidateTraceExporter(name string) (TraceExporter, error) {
+	switch e := TraceExporter(strings.ToLower(name)); e {
+	case "", TraceExporterNone:
+		return TraceExporterNone, nil
+	case "console":
+		return TraceExporterStdout, nil
+	case TraceExporterOTLP, TraceExporterStdout, TraceExporterFile:
+		return e, nil
+	default:
+		return "", fmt.Errorf(
+			"invalid trace exporter '%s', valid values are '%s', '%s', '%s' and '%s'",
+			name, TraceExporterNone, TraceExporterOTLP, TraceExporterStdout, TraceExporterFile,
+		)
+	}
+}
+
+// initTracing creates a tracer provider that exports spans using the configured exporter
+// and sets it as the global tracer provider.
+// It also configures the global propagator to propagate W3C trace context and baggage.
+func initTracing(ctx context.Context, config *Config, res *sdkresource.Resource) (*sdktrace.TracerProvider, io.Closer, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		file     *os.File
+		err      error
+	)
+	switch config.TraceExporter {
+	case TraceExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case TraceExporterStdout:
+		exporter, err = stdouttrace.New()
+	case TraceExporterFile:
+		if config.TraceFile == "" {
+			return nil, nil, fmt.Errorf("a file path is required for the file trace exporter")
+		}
+		file, err = os.OpenFile(config.TraceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return nil, nil, fmt.Errorf("unsupported trace exporter: %s", config.TraceExporter)
+	}
+	if err != nil {
+		if file != nil {
+			_ = file.Close()
+		}
+		return nil, nil, fmt.Errorf("failed to create %s trace exporter: %w", config.TraceExporter, err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	// the file must only be closed once the tracer provider has flushed all spans to it
+	var closer io.Closer
+	if file != nil {
+		closer = file
+	}
+	return tracerProvider, closer, nil
+}
+
+// InjectTraceContext returns the W3C trace context of ctx as a map of propagation fields,
+// eg- traceparent and tracestate.
+// The map is empty if ctx doesn't carry a span or tracing is disabled.
+func InjectTraceContext(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
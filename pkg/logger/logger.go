@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -16,6 +17,12 @@ type Logger interface {
 	Error(msg string, fields ...Field)
 	WithFields(fields ...Field) Logger
 	Sync() error
+
+	// Level returns the current minimum level of logged messages.
+	Level() string
+	// SetLevel changes the minimum level of logged messages at runtime.
+	// The change applies to all loggers derived from this one using WithFields.
+	SetLevel(level string) error
 }
 
 // Field represents a key-value pair for structured logging
@@ -33,6 +40,9 @@ type Config struct {
 // zapLogger implements the Logger interface using uber/zap
 type zapLogger struct {
 	*zap.Logger
+
+	// level is shared by all loggers derived from the same root logger
+	level *zap.AtomicLevel
 }
 
 // DefaultConfig returns a default configuration for development
@@ -106,7 +116,7 @@ func New(config *Config) (Logger, error) {
 	}
 
 	// Parse log level
-	level, err := zapcore.ParseLevel(config.Level)
+	level, err := zap.ParseAtomicLevel(config.Level)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse log level: %w", err)
 	}
@@ -130,7 +140,7 @@ func New(config *Config) (Logger, error) {
 	// Create zap logger
 	zapLog := zap.New(core)
 
-	return &zapLogger{Logger: zapLog}, nil
+	return &zapLogger{Logger: zapLog, level: &level}, nil
 }
 
 // NewDevelopment creates a logger with development configuration
@@ -143,6 +153,31 @@ func NewProduction() (Logger, error) {
 	return New(ProductionConfig())
 }
 
+// NewNop creates a logger that discards all messages.
+// It is useful as a default when no logger is supplied, eg- in tests.
+func NewNop() Logger {
+	level := zap.NewAtomicLevel()
+	return &zapLogger{Logger: zap.NewNop(), level: &level}
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries the given logger.
+// This is used to pass a logger enriched with request-scoped fields (eg- the request ID) down the call stack.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or fallback if ctx doesn't carry one.
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+			return l
+		}
+	}
+	return fallback
+}
+
 // Convert fields to zap fields
 func fieldsToZap(fields []Field) []zap.Field {
 	if len(fields) == 0 {
@@ -193,7 +228,7 @@ func (l *zapLogger) WithFields(fields ...Field) Logger {
 	zapFields := fieldsToZap(fields)
 	newLogger := l.With(zapFields...)
 
-	return &zapLogger{Logger: newLogger}
+	return &zapLogger{Logger: newLogger, level: l.level}
 }
 
 // Sync flushes any buffered log entries
@@ -203,3 +238,24 @@ func (l *zapLogger) Sync() error {
 	}
 	return nil
 }
+
+// Level returns the current minimum level of logged messages
+func (l *zapLogger) Level() string {
+	if l.level == nil {
+		return ""
+	}
+	return l.level.String()
+}
+
+// SetLevel changes the minimum level of logged messages
+func (l *zapLogger) SetLevel(level string) error {
+	if l.level == nil {
+		return fmt.Errorf("logger does not support changing the level")
+	}
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %s", level)
+	}
+	l.level.SetLevel(parsed)
+	return nil
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -344,6 +345,50 @@ func BenchmarkLoggerCreation(b *testing.B) {
 	}
 }
 
+func TestSetLevel(t *testing.T) {
+	logger, err := New(&Config{Level: "info", Development: false})
+	if err != nil {
+		t.Fatalf("Failed to create logger: %v", err)
+	}
+	child := logger.WithFields(String("request_id", "abc"))
+
+	if err := logger.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel() error = %v", err)
+	}
+	if logger.Level() != "debug" {
+		t.Errorf("Expected level debug, got %s", logger.Level())
+	}
+	// loggers derived from the root logger share its level
+	if child.Level() != "debug" {
+		t.Errorf("Expected derived logger to have level debug, got %s", child.Level())
+	}
+
+	if err := logger.SetLevel("verbose"); err == nil {
+		t.Error("SetLevel() expected error for invalid level")
+	}
+	if logger.Level() != "debug" {
+		t.Errorf("Invalid level must not change the level, got %s", logger.Level())
+	}
+
+	nilLogger := &zapLogger{Logger: nil}
+	if err := nilLogger.SetLevel("debug"); err == nil {
+		t.Error("SetLevel() expected error for logger without a level")
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	fallback := NewNop()
+	if FromContext(context.Background(), fallback) != fallback {
+		t.Error("FromContext() should return the fallback logger for a context without a logger")
+	}
+
+	l := NewNop().WithFields(String("request_id", "abc"))
+	ctx := NewContext(context.Background(), l)
+	if FromContext(ctx, fallback) != l {
+		t.Error("FromContext() should return the logger carried by the context")
+	}
+}
+
 func BenchmarkLoggerInfo(b *testing.B) {
 	logger, err := NewDevelopment()
 	if err != nil {
@@ -4,7 +4,9 @@ package testhelpers
 
 import (
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
 	"github.com/mcpjungle/mcpjungle/internal/model"
@@ -212,6 +214,32 @@ func FormatMapError(expected, actual any) string {
 	return fmt.Sprintf("Expected %v, got %v", expected, actual)
 }
 
+// FakeClock is a clock for tests that only moves forward when advanced.
+// Its Now method can replace time.Now in services that take a clock function.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a fake clock that starts at the given time.
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{now: start}
+}
+
+// Now returns the current time of the clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by the given duration.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
 // TestDBSetup represents a test database setup with common models
 type TestDBSetup struct {
 	DB *gorm.DB
@@ -234,6 +262,10 @@ func SetupTestDB(t *testing.T) *TestDBSetup {
 		&model.ToolGroup{},
 		&model.Prompt{},
 		&model.AuditLog{},
+		&model.RateLimit{},
+		&model.ClientQuotaUsage{},
+		&model.ToolUsage{},
+		&model.ToolEmbedding{},
 	)
 	AssertNoError(t, err)
 
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+// HealthStatus represents the health of an upstream MCP server as observed by mcpjungle's health checker.
+type HealthStatus string
+
+const (
+	// HealthStatusUnknown means that the server has not been checked yet.
+	HealthStatusUnknown HealthStatus = "unknown"
+	// HealthStatusHealthy means that the last health check of the server succeeded.
+	HealthStatusHealthy HealthStatus = "healthy"
+	// HealthStatusUnhealthy means that the last health check of the server failed.
+	HealthStatusUnhealthy HealthStatus = "unhealthy"
+)
+
+// ServerHealth describes the result of the latest health checks of an upstream MCP server.
+type ServerHealth struct {
+	Status HealthStatus `json:"status"`
+
+	// LastCheckedAt is the time of the latest health check, successful or not.
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	// LastSeen is the time of the latest successful health check.
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+	// LastError is the error returned by the latest health check, if it failed.
+	LastError string `json:"last_error,omitempty"`
+	// LatencyMs is the time taken by the latest health check to initialize a session with the server and ping it.
+	LatencyMs int64 `json:"latency_ms"`
+
+	// ConsecutiveFailures is the number of health checks that failed in a row.
+	ConsecutiveFailures int `json:"consecutive_failures,omitempty"`
+	// ToolsHidden is true if the server's tools are currently hidden from MCP clients because
+	// the server stayed unhealthy.
+	ToolsHidden bool `json:"tools_hidden,omitempty"`
+}
+
+// HealthResponse is the response of the /health endpoint.
+type HealthResponse struct {
+	// Status is "ok" if mcpjungle is up.
+	// In verbose mode, it is "degraded" if any upstream MCP server is unhealthy.
+	Status string `json:"status"`
+
+	// Servers maps the names of the registered MCP servers to their health.
+	// It is only populated in verbose mode.
+	Servers map[string]*ServerHealth `json:"servers,omitempty"`
+}
+
+// ReadinessCheckStatus is the result of a single readiness check.
+type ReadinessCheckStatus string
+
+const (
+	ReadinessCheckPass ReadinessCheckStatus = "pass"
+	ReadinessCheckFail ReadinessCheckStatus = "fail"
+)
+
+// ReadinessCheck is the result of one of the checks performed by the /readyz endpoint.
+type ReadinessCheck struct {
+	// Name identifies the check, eg- "database" or "upstream:github".
+	Name   string               `json:"name"`
+	Status ReadinessCheckStatus `json:"status"`
+	// Error explains why the check failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the response of the /readyz endpoint.
+type ReadinessResponse struct {
+	// Status is "ready" if all checks passed, "not_ready" otherwise.
+	Status string            `json:"status"`
+	Checks []*ReadinessCheck `json:"checks"`
+}
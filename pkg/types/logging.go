@@ -0,0 +1,7 @@
+package types
+
+// LogLevel is the minimum level of messages logged by the MCPJungle server.
+// Valid levels are "debug", "info", "warn" and "error".
+type LogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
@@ -1,6 +1,9 @@
 package types
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // McpServerTransport represents the transport protocol used by an MCP server.
 // All transport types supported by mcpjungle are defined in this file with this type.
@@ -23,6 +26,120 @@ type McpServer struct {
 	Command string            `json:"command"`
 	Args    []string          `json:"args"`
 	Env     map[string]string `json:"env"`
+
+	StdioSupervision
+
+	MaxConcurrency      int `json:"max_concurrency,omitempty"`
+	MaxQueueDepth       int `json:"max_queue_depth,omitempty"`
+	QueueTimeoutSeconds int `json:"queue_timeout_seconds,omitempty"`
+
+	CallTimeoutSeconds int            `json:"call_timeout_seconds,omitempty"`
+	ToolCallTimeouts   map[string]int `json:"tool_call_timeouts,omitempty"`
+
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
+	// CircuitBreakerState is the current state of the server's circuit breaker.
+	// valid values are "closed", "open" and "half_open".
+	CircuitBreakerState string `json:"circuit_breaker_state,omitempty"`
+
+	// Health is the result of the latest health checks of the server.
+	Health *ServerHealth `json:"health,omitempty"`
+}
+
+// RetryPolicy describes how mcpjungle retries failed calls to an upstream MCP server.
+// Failures to connect to or initialize the server are always retried.
+// Tool calls that fail after the connection was established are only retried for idempotent tools,
+// ie, tools listed in IdempotentTools or annotated as idempotent by the server.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int `json:"max_attempts"`
+
+	// InitialBackoffMs is the time to wait before the first retry, in milliseconds.
+	// The wait time doubles after every attempt.
+	InitialBackoffMs int `json:"initial_backoff_ms,omitempty"`
+
+	// MaxBackoffMs caps the time to wait between two attempts, in milliseconds.
+	MaxBackoffMs int `json:"max_backoff_ms,omitempty"`
+
+	// IdempotentTools lists the names of the tools (without the server name prefix) that are safe to retry.
+	IdempotentTools []string `json:"idempotent_tools,omitempty"`
+}
+
+// StdioSupervision configures how mcpjungle runs and supervises the process of a stdio MCP server.
+type StdioSupervision struct {
+	// WorkDir is the working directory of the process. By default, mcpjungle's working directory is used.
+	WorkDir string `json:"work_dir,omitempty"`
+
+	// EnvAllowList lists the environment variables of mcpjungle that are passed on to the process,
+	// in addition to a small set of basic variables like PATH and HOME.
+	// A trailing "*" matches all variables with the given prefix, eg- "AWS_*".
+	// Other variables of mcpjungle, eg- DATABASE_URL, are never passed on.
+	EnvAllowList []string `json:"env_allow_list,omitempty"`
+
+	// ResourceLimits restricts the resources the process may use. Only supported on Linux.
+	ResourceLimits *StdioResourceLimits `json:"resource_limits,omitempty"`
+
+	// RunAsUser is the name or numeric ID of the Unix user to run the process as.
+	// mcpjungle must run as root to switch users.
+	RunAsUser string `json:"run_as_user,omitempty"`
+
+	// RunAsGroup is the name or numeric ID of the Unix group to run the process as.
+	// If RunAsUser is set and RunAsGroup is not, the user's primary group is used.
+	RunAsGroup string `json:"run_as_group,omitempty"`
+
+	// KillTimeoutSeconds is how long mcpjungle waits for the process to exit after closing its session,
+	// before killing it. If not specified, a default timeout is used.
+	KillTimeoutSeconds int `json:"kill_timeout_seconds,omitempty"`
+
+	// Sandbox runs the process in an isolated sandbox. Only supported on Linux.
+	Sandbox *StdioSandbox `json:"sandbox,omitempty"`
+
+	// ErrorLogLines is the number of the most recent lines the process wrote to stderr that are appended
+	// to the error returned when initializing the server or calling one of its tools fails.
+	// By default, no lines are appended.
+	ErrorLogLines int `json:"error_log_lines,omitempty"`
+}
+
+// ServerLogLine is a line an MCP server wrote to its stderr.
+type ServerLogLine struct {
+	Time time.Time `json:"time"`
+	Line string    `json:"line"`
+}
+
+// StdioSandbox configures the sandbox of a stdio MCP server's process.
+// A sandboxed process runs in its own Linux namespaces with a read-only root filesystem, a private /tmp
+// and a seccomp filter that blocks system calls that could be used to escape the sandbox.
+type StdioSandbox struct {
+	// Enabled turns on the sandbox.
+	Enabled bool `json:"enabled"`
+
+	// AllowNetwork gives the process access to the host's network.
+	// By default, the process can only reach its own loopback interface.
+	AllowNetwork bool `json:"allow_network,omitempty"`
+
+	// WritablePaths lists absolute paths of files and directories that stay writable inside the sandbox.
+	WritablePaths []string `json:"writable_paths,omitempty"`
+}
+
+// IsEnabled returns true if the sandbox is configured and enabled.
+func (s *StdioSandbox) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// StdioResourceLimits describes the resource limits (rlimits) of the process of a stdio MCP server.
+// A zero value means that the limit is inherited from mcpjungle.
+type StdioResourceLimits struct {
+	// CPUSeconds is the maximum CPU time the process may consume.
+	CPUSeconds uint64 `json:"cpu_seconds,omitempty"`
+	// MemoryMB is the maximum size of the process's virtual memory, in megabytes.
+	MemoryMB uint64 `json:"memory_mb,omitempty"`
+	// OpenFiles is the maximum number of files the process may have open at the same time.
+	OpenFiles uint64 `json:"open_files,omitempty"`
+}
+
+// IsZero returns true if no limit is set.
+func (l *StdioResourceLimits) IsZero() bool {
+	return l == nil || (l.CPUSeconds == 0 && l.MemoryMB == 0 && l.OpenFiles == 0)
 }
 
 // RegisterServerInput is the input structure for registering a new MCP server with mcpjungle.
@@ -57,6 +174,42 @@ type RegisterServerInput struct {
 	// Env is the set of environment variables to pass to the mcp server when the transport is "stdio".
 	// Both the key and value must be of type string.
 	Env map[string]string `json:"env"`
+
+	// StdioSupervision configures how mcpjungle runs the process of the mcp server when the transport is "stdio".
+	StdioSupervision
+
+	// MaxConcurrency is the maximum number of calls that mcpjungle forwards to the mcp server at the same time.
+	// Calls above this limit wait in a queue. 0 (default) means no limit.
+	// This is useful for servers that can only handle one call at a time, eg- browser automation servers.
+	MaxConcurrency int `json:"max_concurrency,omitempty"`
+
+	// MaxQueueDepth is the maximum number of calls that can wait in the queue when MaxConcurrency is reached.
+	// If not specified, a default depth is used.
+	MaxQueueDepth int `json:"max_queue_depth,omitempty"`
+
+	// QueueTimeoutSeconds is the maximum time a call waits in the queue before failing.
+	// If not specified, a default timeout is used.
+	QueueTimeoutSeconds int `json:"queue_timeout_seconds,omitempty"`
+
+	// CallTimeoutSeconds is the maximum time a tool call to the mcp server may take.
+	// If not specified, mcpjungle's default tool call timeout is used.
+	CallTimeoutSeconds int `json:"call_timeout_seconds,omitempty"`
+
+	// ToolCallTimeouts overrides CallTimeoutSeconds for individual tools.
+	// It maps tool names (without the server name prefix) to timeouts in seconds.
+	ToolCallTimeouts map[string]int `json:"tool_call_timeouts,omitempty"`
+
+	// RetryPolicy is an optional policy for retrying failed calls to the mcp server with exponential backoff.
+	// By default, failed calls are not retried.
+	RetryPolicy *RetryPolicy `json:"retry_policy,omitempty"`
+
+	// CircuitBreakerThreshold is the number of consecutive failed calls after which mcpjungle stops
+	// sending calls to the mcp server for a while. If not specified, a default threshold is used.
+	CircuitBreakerThreshold int `json:"circuit_breaker_threshold,omitempty"`
+
+	// CircuitBreakerCooldownSeconds is the time after which mcpjungle tries to call the mcp server again
+	// once the circuit breaker opened. If not specified, a default cooldown is used.
+	CircuitBreakerCooldownSeconds int `json:"circuit_breaker_cooldown_seconds,omitempty"`
 }
 
 // ServerMetadata represents the server metadata response
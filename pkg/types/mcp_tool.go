@@ -1,5 +1,7 @@
 package types
 
+import "strings"
+
 // ToolInputSchema defines the schema for the input parameters of a tool
 type ToolInputSchema struct {
 	Type       string         `json:"type"`
@@ -13,6 +15,106 @@ type Tool struct {
 	Enabled     bool            `json:"enabled"`
 	Description string          `json:"description"`
 	InputSchema ToolInputSchema `json:"input_schema"`
+
+	// Annotations contains the hints the MCP server gave about the tool's behavior, if any.
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+	// OutputSchema is a JSON schema that describes the structured output of the tool, if the MCP server provided one.
+	OutputSchema map[string]any `json:"output_schema,omitempty"`
+}
+
+// ToolAnnotations contains the title of a tool and the hints about its behavior, as defined by the MCP spec.
+// A hint that is not set has the default value given by the spec.
+type ToolAnnotations struct {
+	Title string `json:"title,omitempty"`
+
+	// ReadOnlyHint is true if the tool does not modify its environment. Defaults to false.
+	ReadOnlyHint *bool `json:"readOnlyHint,omitempty"`
+	// DestructiveHint is true if the tool may perform destructive updates. Defaults to true.
+	// It is only meaningful for tools that are not read-only.
+	DestructiveHint *bool `json:"destructiveHint,omitempty"`
+	// IdempotentHint is true if calling the tool repeatedly with the same arguments has no additional effect.
+	// Defaults to false. It is only meaningful for tools that are not read-only.
+	IdempotentHint *bool `json:"idempotentHint,omitempty"`
+	// OpenWorldHint is true if the tool interacts with external entities. Defaults to true.
+	OpenWorldHint *bool `json:"openWorldHint,omitempty"`
+}
+
+// IsReadOnly returns true if the tool does not modify its environment.
+func (a *ToolAnnotations) IsReadOnly() bool {
+	return a != nil && a.ReadOnlyHint != nil && *a.ReadOnlyHint
+}
+
+// IsDestructive returns true if the tool may perform destructive updates.
+func (a *ToolAnnotations) IsDestructive() bool {
+	if a.IsReadOnly() {
+		return false
+	}
+	return a == nil || a.DestructiveHint == nil || *a.DestructiveHint
+}
+
+// IsIdempotent returns true if calling the tool repeatedly with the same arguments has no additional effect.
+// Read-only tools are always idempotent.
+func (a *ToolAnnotations) IsIdempotent() bool {
+	if a.IsReadOnly() {
+		return true
+	}
+	return a != nil && a.IdempotentHint != nil && *a.IdempotentHint
+}
+
+// IsOpenWorld returns true if the tool interacts with external entities.
+func (a *ToolAnnotations) IsOpenWorld() bool {
+	return a == nil || a.OpenWorldHint == nil || *a.OpenWorldHint
+}
+
+// ToolAnnotationFilter selects tools by their behavior, as described by their annotations.
+// Each field that is set must match the tool, so an empty filter matches every tool.
+// Hints that a tool's MCP server didn't set are assumed to have the default value given by the MCP spec.
+type ToolAnnotationFilter struct {
+	ReadOnly    *bool `json:"read_only,omitempty"`
+	Destructive *bool `json:"destructive,omitempty"`
+	Idempotent  *bool `json:"idempotent,omitempty"`
+	OpenWorld   *bool `json:"open_world,omitempty"`
+}
+
+// Matches returns true if a tool with the given annotations matches the filter.
+// nil annotations stand for a tool without annotations.
+func (f *ToolAnnotationFilter) Matches(a *ToolAnnotations) bool {
+	if f == nil {
+		return true
+	}
+	matches := func(want *bool, got bool) bool {
+		return want == nil || *want == got
+	}
+	return matches(f.ReadOnly, a.IsReadOnly()) &&
+		matches(f.Destructive, a.IsDestructive()) &&
+		matches(f.Idempotent, a.IsIdempotent()) &&
+		matches(f.OpenWorld, a.IsOpenWorld())
+}
+
+// String describes the filter in a human-readable way, eg- "read-only, not destructive".
+func (f *ToolAnnotationFilter) String() string {
+	if f == nil {
+		return "any"
+	}
+	var parts []string
+	add := func(v *bool, name string) {
+		if v == nil {
+			return
+		}
+		if *v {
+			parts = append(parts, name)
+		} else {
+			parts = append(parts, "not "+name)
+		}
+	}
+	add(f.ReadOnly, "read-only")
+	add(f.Destructive, "destructive")
+	add(f.Idempotent, "idempotent")
+	add(f.OpenWorld, "open-world")
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, ", ")
 }
 
 // ToolInvokeResult represents the result of a Tool call.
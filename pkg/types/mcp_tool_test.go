@@ -121,3 +121,63 @@ func TestToolInvokeResultJSONMarshaling(t *testing.T) {
 		t.Errorf("Expected JSON %s, got %s", expected, string(data))
 	}
 }
+
+func TestToolAnnotations(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+	tests := []struct {
+		name                                         string
+		annotations                                  *ToolAnnotations
+		readOnly, destructive, idempotent, openWorld bool
+	}{
+		{"no annotations use the defaults of the spec", nil, false, true, false, true},
+		{"read-only tools are neither destructive nor side-effectful", &ToolAnnotations{ReadOnlyHint: &yes}, true, false, true, true},
+		{"non-destructive idempotent tool", &ToolAnnotations{DestructiveHint: &no, IdempotentHint: &yes}, false, false, true, true},
+		{"closed-world tool", &ToolAnnotations{OpenWorldHint: &no}, false, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.annotations.IsReadOnly(); got != tt.readOnly {
+				t.Errorf("IsReadOnly() = %t, want %t", got, tt.readOnly)
+			}
+			if got := tt.annotations.IsDestructive(); got != tt.destructive {
+				t.Errorf("IsDestructive() = %t, want %t", got, tt.destructive)
+			}
+			if got := tt.annotations.IsIdempotent(); got != tt.idempotent {
+				t.Errorf("IsIdempotent() = %t, want %t", got, tt.idempotent)
+			}
+			if got := tt.annotations.IsOpenWorld(); got != tt.openWorld {
+				t.Errorf("IsOpenWorld() = %t, want %t", got, tt.openWorld)
+			}
+		})
+	}
+}
+
+func TestToolAnnotationFilter(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+	readOnly := &ToolAnnotations{ReadOnlyHint: &yes}
+
+	var filter *ToolAnnotationFilter
+	if !filter.Matches(nil) || !filter.Matches(readOnly) {
+		t.Error("expected a nil filter to match every tool")
+	}
+	if filter.String() != "any" {
+		t.Errorf("expected a nil filter to be described as 'any', got %q", filter.String())
+	}
+
+	filter = &ToolAnnotationFilter{ReadOnly: &yes}
+	if !filter.Matches(readOnly) || filter.Matches(nil) {
+		t.Error("expected the filter to only match read-only tools")
+	}
+
+	filter = &ToolAnnotationFilter{Destructive: &no, OpenWorld: &yes}
+	if !filter.Matches(readOnly) || filter.Matches(nil) {
+		t.Error("expected the filter to only match non-destructive tools")
+	}
+	if filter.String() != "not destructive, open-world" {
+		t.Errorf("unexpected description of the filter: %q", filter.String())
+	}
+}
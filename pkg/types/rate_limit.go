@@ -0,0 +1,59 @@
+package types
+
+import "fmt"
+
+// RateLimitScope represents the level at which a rate limit is applied.
+type RateLimitScope string
+
+const (
+	// RateLimitScopeClient applies a limit to all tool calls made by an MCP client.
+	RateLimitScopeClient RateLimitScope = "client"
+	// RateLimitScopeTool applies a limit to all calls of a tool, identified by its canonical name.
+	RateLimitScopeTool RateLimitScope = "tool"
+	// RateLimitScopeServer applies a limit to all tool calls proxied to an upstream MCP server.
+	RateLimitScopeServer RateLimitScope = "server"
+)
+
+// RateLimit describes the rate limit and call quotas configured for a client, tool or upstream MCP server.
+type RateLimit struct {
+	// Scope is the level the limit applies to.
+	// valid values are "client", "tool" and "server".
+	Scope string `json:"scope"`
+
+	// Target is the name of the MCP client, the canonical name of the tool or the name of the MCP server.
+	Target string `json:"target"`
+
+	// RequestsPerMinute is the sustained rate of calls allowed.
+	// 0 means that the rate is not limited.
+	RequestsPerMinute int `json:"requests_per_minute"`
+
+	// Burst is the maximum number of calls that can be made in quick succession.
+	// If not specified, it defaults to RequestsPerMinute.
+	Burst int `json:"burst,omitempty"`
+
+	// DailyQuota is the maximum number of calls allowed per day (UTC).
+	// It is only supported for the "client" scope. 0 means no quota.
+	DailyQuota int64 `json:"daily_quota,omitempty"`
+
+	// MonthlyQuota is the maximum number of calls allowed per calendar month (UTC).
+	// It is only supported for the "client" scope. 0 means no quota.
+	MonthlyQuota int64 `json:"monthly_quota,omitempty"`
+}
+
+// ValidateRateLimitScope validates the input string and returns the corresponding RateLimitScope.
+func ValidateRateLimitScope(input string) (RateLimitScope, error) {
+	switch RateLimitScope(input) {
+	case RateLimitScopeClient, RateLimitScopeTool, RateLimitScopeServer:
+		return RateLimitScope(input), nil
+	case "":
+		return "", fmt.Errorf(
+			"rate limit scope is required (acceptable values: '%s', '%s', '%s')",
+			RateLimitScopeClient, RateLimitScopeTool, RateLimitScopeServer,
+		)
+	default:
+		return "", fmt.Errorf(
+			"unsupported rate limit scope: %s (acceptable values: '%s', '%s', '%s')",
+			input, RateLimitScopeClient, RateLimitScopeTool, RateLimitScopeServer,
+		)
+	}
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SearchKind is a kind of entity that can be searched for.
+type SearchKind string
+
+const (
+	SearchKindTool      SearchKind = "tool"
+	SearchKindPrompt    SearchKind = "prompt"
+	SearchKindServer    SearchKind = "server"
+	SearchKindToolGroup SearchKind = "tool_group"
+)
+
+// AllSearchKinds lists every kind of entity that can be searched for.
+var AllSearchKinds = []SearchKind{SearchKindTool, SearchKindPrompt, SearchKindServer, SearchKindToolGroup}
+
+// SearchResult is an entity matching a search query.
+type SearchResult struct {
+	Kind SearchKind `json:"kind"`
+
+	// Name is the canonical name of a tool or prompt, or the name of a MCP server or tool group.
+	Name string `json:"name"`
+
+	// ServerName is the name of the MCP server that provides a tool or prompt.
+	// It is empty for MCP servers and tool groups.
+	ServerName string `json:"server_name,omitempty"`
+
+	Description string  `json:"description"`
+	Score       float64 `json:"score"`
+
+	// Enabled is false for tools and prompts that are disabled. MCP servers and tool groups are always enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// SearchResponse is the response of the unified search API.
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Kinds   []SearchKind   `json:"kinds"`
+	Results []SearchResult `json:"results"`
+	Count   int            `json:"count"`
+}
+
+// ValidateSearchKinds validates the input strings and returns the corresponding SearchKinds.
+// Each input may also be a comma-separated list of kinds.
+// No input defaults to all kinds.
+func ValidateSearchKinds(input []string) ([]SearchKind, error) {
+	kinds := make([]SearchKind, 0, len(input))
+	seen := make(map[SearchKind]bool)
+	for _, in := range input {
+		for _, k := range strings.Split(in, ",") {
+			kind := SearchKind(strings.ToLower(strings.TrimSpace(k)))
+			switch kind {
+			case "":
+				continue
+			case SearchKindTool, SearchKindPrompt, SearchKindServer, SearchKindToolGroup:
+			default:
+				return nil, fmt.Errorf(
+					"unsupported search kind: %s (acceptable values: '%s', '%s', '%s', '%s')",
+					k, SearchKindTool, SearchKindPrompt, SearchKindServer, SearchKindToolGroup,
+				)
+			}
+			if !seen[kind] {
+				seen[kind] = true
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+	if len(kinds) == 0 {
+		return slices.Clone(AllSearchKinds), nil
+	}
+	return kinds, nil
+}
@@ -0,0 +1,31 @@
+package types
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidateSearchKinds(t *testing.T) {
+	t.Parallel()
+
+	kinds, err := ValidateSearchKinds(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(kinds, AllSearchKinds) {
+		t.Errorf("Expected all kinds by default, got %v", kinds)
+	}
+
+	kinds, err = ValidateSearchKinds([]string{"prompt,Server", "prompt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []SearchKind{SearchKindPrompt, SearchKindServer}
+	if !slices.Equal(kinds, expected) {
+		t.Errorf("Expected %v, got %v", expected, kinds)
+	}
+
+	if _, err := ValidateSearchKinds([]string{"resource"}); err == nil {
+		t.Error("Expected an error for an unsupported kind")
+	}
+}
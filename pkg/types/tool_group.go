@@ -1,5 +1,13 @@
 package types
 
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
 // ToolGroup represents a group (collection) of MCP Tools and Prompts.
 // A group can contain a subset of all available tools and prompts in the MCPJungle system.
 // This allows you to expose a limited set of tools and prompts to certain mcp clients.
@@ -19,6 +27,207 @@ type ToolGroup struct {
 	ExcludedPrompts []string `json:"excluded_prompts,omitempty"`
 
 	Description string `json:"description"`
+
+	// LazyToolLoading makes MCP client sessions initially list only mcpjungle's meta-tools instead of all
+	// the tools of the group. Clients use the meta-tools to search, describe, call and activate the group's tools.
+	LazyToolLoading bool `json:"lazy_tool_loading,omitempty"`
+
+	// IncludedServersFilter narrows down the tools included from IncludedServers to the ones
+	// whose annotations match it, eg- {"read_only": true} only includes read-only tools.
+	IncludedServersFilter *ToolAnnotationFilter `json:"included_servers_filter,omitempty"`
+
+	// ToolRules add tools to the group or remove tools from it based on their names and annotations.
+	// Unlike the lists above, rules also apply to tools registered after the group was created.
+	ToolRules []ToolGroupRule `json:"tool_rules,omitempty"`
+
+	// ToolAliases expose tools in the group under different names, descriptions and input schemas.
+	ToolAliases []ToolAlias `json:"tool_aliases,omitempty"`
+
+	// ToolArguments presets the arguments of the group's tools, keyed by the name that the tool is exposed under
+	// in the group, ie, its canonical name or its alias.
+	ToolArguments map[string]ToolArguments `json:"tool_arguments,omitempty"`
+}
+
+// ToolGroupRule selects tools by their canonical names and annotations.
+// A tool matches the rule if it matches both the pattern and the annotations filter, when they are set.
+type ToolGroupRule struct {
+	// Pattern matches canonical tool names.
+	// It is a glob pattern where * matches any sequence of characters and ? matches a single character,
+	// eg- github__*_issue*.
+	// A pattern enclosed in slashes is a regular expression instead, eg- /^github__(get|list)_/.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Annotations only matches the tools whose annotations match the filter, eg- read-only tools.
+	Annotations *ToolAnnotationFilter `json:"annotations,omitempty"`
+
+	// Exclude removes the matching tools from the group instead of adding them.
+	// Like excluded_tools, exclusion rules are applied last, so they also remove tools included explicitly.
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+// Validate checks that the rule selects tools and that its pattern is valid.
+func (r *ToolGroupRule) Validate() error {
+	if r.Pattern == "" && r.Annotations == nil {
+		return fmt.Errorf("a tool rule must have a pattern, annotations or both")
+	}
+	_, err := r.compilePattern()
+	return err
+}
+
+// NameMatcher returns a function that reports whether a canonical tool name matches the rule's pattern.
+// A rule without a pattern matches every name.
+func (r *ToolGroupRule) NameMatcher() (func(name string) bool, error) {
+	re, err := r.compilePattern()
+	if err != nil {
+		return nil, err
+	}
+	if re == nil {
+		return func(string) bool { return true }, nil
+	}
+	return re.MatchString, nil
+}
+
+func (r *ToolGroupRule) compilePattern() (*regexp.Regexp, error) {
+	if r.Pattern == "" {
+		return nil, nil
+	}
+	if len(r.Pattern) > 1 && strings.HasPrefix(r.Pattern, "/") && strings.HasSuffix(r.Pattern, "/") {
+		re, err := regexp.Compile(r.Pattern[1 : len(r.Pattern)-1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression in tool rule pattern %s: %w", r.Pattern, err)
+		}
+		return re, nil
+	}
+
+	// translate the glob pattern into an anchored regular expression
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, c := range r.Pattern {
+		switch c {
+		case '*':
+			expr.WriteString(".*")
+		case '?':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String()), nil
+}
+
+// String describes the rule in a human-readable way, eg- "exclude github__delete_* (destructive)".
+func (r *ToolGroupRule) String() string {
+	action := "include"
+	if r.Exclude {
+		action = "exclude"
+	}
+	var parts []string
+	if r.Pattern != "" {
+		parts = append(parts, r.Pattern)
+	} else {
+		parts = append(parts, "all tools")
+	}
+	if r.Annotations != nil {
+		parts = append(parts, "("+r.Annotations.String()+")")
+	}
+	return action + " " + strings.Join(parts, " ")
+}
+
+// validAliasName matches the names that a tool can be exposed under.
+var validAliasName = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// ToolAlias exposes a tool under a different name.
+// Calls to the alias are forwarded to the underlying tool, so the alias is subject to the same access control.
+type ToolAlias struct {
+	// Name is the name that the tool is exposed under, eg- create_pr.
+	// It must not contain the "__" separator, so it can never clash with a canonical tool name.
+	Name string `json:"name"`
+	// Tool is the canonical name of the underlying tool, eg- github__create_pull_request.
+	Tool string `json:"tool"`
+	// Description overrides the description of the underlying tool, if set.
+	Description string `json:"description,omitempty"`
+	// Parameters narrows down the input schema of the tool to the given parameters, if set.
+	// The other parameters can't be passed when calling the alias, so they must be optional.
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// Validate checks that the alias has a valid name and refers to a tool.
+func (a *ToolAlias) Validate() error {
+	if !validAliasName.MatchString(a.Name) {
+		return fmt.Errorf(
+			"invalid tool alias name %q: name can only contain alphanumeric characters, underscores, hyphens and dots",
+			a.Name,
+		)
+	}
+	if strings.Contains(a.Name, "__") {
+		return fmt.Errorf("invalid tool alias name %q: name must not contain the __ separator", a.Name)
+	}
+	if a.Tool == "" {
+		return fmt.Errorf("tool alias %s must refer to a tool", a.Name)
+	}
+	return nil
+}
+
+// String describes the alias in a human-readable way, eg- "create_pr -> github__create_pull_request (title, body)".
+func (a *ToolAlias) String() string {
+	s := a.Name + " -> " + a.Tool
+	if len(a.Parameters) > 0 {
+		s += " (" + strings.Join(a.Parameters, ", ") + ")"
+	}
+	return s
+}
+
+// ToolArguments contains the argument presets of a tool in a tool group.
+type ToolArguments struct {
+	// Fixed arguments are always passed to the tool.
+	// They are removed from the tool's input schema, so MCP clients can't pass them.
+	Fixed map[string]any `json:"fixed,omitempty"`
+	// Defaults are passed to the tool unless the MCP client passes the argument itself.
+	Defaults map[string]any `json:"defaults,omitempty"`
+}
+
+// Validate checks that no argument is both fixed and defaulted.
+func (a *ToolArguments) Validate() error {
+	for name := range a.Fixed {
+		if _, ok := a.Defaults[name]; ok {
+			return fmt.Errorf("argument %s can't be both fixed and defaulted", name)
+		}
+	}
+	return nil
+}
+
+// String describes the presets in a human-readable way, eg- `fixed: database="analytics_ro", defaults: limit=100`.
+func (a *ToolArguments) String() string {
+	var parts []string
+	if len(a.Fixed) > 0 {
+		parts = append(parts, "fixed: "+formatArguments(a.Fixed))
+	}
+	if len(a.Defaults) > 0 {
+		parts = append(parts, "defaults: "+formatArguments(a.Defaults))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatArguments formats arguments as a list of name=value pairs sorted by name, with JSON encoded values.
+func formatArguments(args map[string]any) string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		value, err := json.Marshal(args[name])
+		if err != nil {
+			value = []byte(fmt.Sprint(args[name]))
+		}
+		pairs = append(pairs, name+"="+string(value))
+	}
+	return strings.Join(pairs, " ")
 }
 
 // ToolGroupEndpoints contains the endpoints a MCP client can use to access a tool group.
@@ -144,3 +144,142 @@ func TestGetToolGroupResponse(t *testing.T) {
 		t.Errorf("Expected StreamableHTTPEndpoint to be '/api/tool-groups/get-group/stream', got %s", response.StreamableHTTPEndpoint)
 	}
 }
+
+func TestToolGroupRule(t *testing.T) {
+	t.Parallel()
+
+	yes := true
+	tests := []struct {
+		name    string
+		rule    ToolGroupRule
+		matches []string
+		misses  []string
+		wantErr bool
+	}{
+		{
+			name:    "glob",
+			rule:    ToolGroupRule{Pattern: "github__*_issue*"},
+			matches: []string{"github__create_issue", "github__list_issues"},
+			misses:  []string{"github__issue", "gitlab__create_issue"},
+		},
+		{
+			name:    "glob with single character wildcard and regexp characters",
+			rule:    ToolGroupRule{Pattern: "fs__read.fil?"},
+			matches: []string{"fs__read.file"},
+			misses:  []string{"fs__readxfile", "fs__read.files"},
+		},
+		{
+			name:    "regular expression",
+			rule:    ToolGroupRule{Pattern: "/^github__(get|list)_/"},
+			matches: []string{"github__get_issue", "github__list_repos"},
+			misses:  []string{"github__create_issue"},
+		},
+		{
+			name:    "annotations only match every name",
+			rule:    ToolGroupRule{Annotations: &ToolAnnotationFilter{ReadOnly: &yes}},
+			matches: []string{"github__get_issue", "time__now"},
+		},
+		{name: "invalid regular expression", rule: ToolGroupRule{Pattern: "/github__(/"}, wantErr: true},
+		{name: "empty rule", rule: ToolGroupRule{Exclude: true}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected the rule to be invalid")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			matches, err := tt.rule.NameMatcher()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, name := range tt.matches {
+				if !matches(name) {
+					t.Errorf("expected %s to match", name)
+				}
+			}
+			for _, name := range tt.misses {
+				if matches(name) {
+					t.Errorf("expected %s not to match", name)
+				}
+			}
+		})
+	}
+}
+
+func TestToolGroupRuleString(t *testing.T) {
+	t.Parallel()
+
+	yes := true
+	rule := ToolGroupRule{Annotations: &ToolAnnotationFilter{Destructive: &yes}, Exclude: true}
+	if got := rule.String(); got != "exclude all tools (destructive)" {
+		t.Errorf("unexpected description: %q", got)
+	}
+	rule = ToolGroupRule{Pattern: "github__*"}
+	if got := rule.String(); got != "include github__*" {
+		t.Errorf("unexpected description: %q", got)
+	}
+}
+
+func TestToolAlias(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		alias   ToolAlias
+		wantErr bool
+	}{
+		{name: "valid", alias: ToolAlias{Name: "create_pr", Tool: "github__create_pull_request"}},
+		{name: "dots and hyphens", alias: ToolAlias{Name: "pr.create-v2", Tool: "github__create_pull_request"}},
+		{name: "empty name", alias: ToolAlias{Tool: "github__create_pull_request"}, wantErr: true},
+		{name: "invalid characters", alias: ToolAlias{Name: "create pr", Tool: "github__create_pull_request"}, wantErr: true},
+		{name: "separator in name", alias: ToolAlias{Name: "github__pr", Tool: "github__create_pull_request"}, wantErr: true},
+		{name: "no tool", alias: ToolAlias{Name: "create_pr"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.alias.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("Expected an error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+
+	alias := ToolAlias{Name: "create_pr", Tool: "github__create_pull_request", Parameters: []string{"title", "body"}}
+	if got, want := alias.String(), "create_pr -> github__create_pull_request (title, body)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestToolArguments(t *testing.T) {
+	t.Parallel()
+
+	args := ToolArguments{
+		Fixed:    map[string]any{"database": "analytics_ro"},
+		Defaults: map[string]any{"limit": 100, "format": "csv"},
+	}
+	if err := args.Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if got, want := args.String(), `fixed: database="analytics_ro", defaults: format="csv" limit=100`; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	conflicting := ToolArguments{Fixed: map[string]any{"limit": 10}, Defaults: map[string]any{"limit": 100}}
+	if err := conflicting.Validate(); err == nil {
+		t.Error("Expected an error for an argument that is both fixed and defaulted")
+	}
+
+	empty := ToolArguments{}
+	if got := empty.String(); got != "none" {
+		t.Errorf("Expected %q, got %q", "none", got)
+	}
+}
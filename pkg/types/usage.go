@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// UsageGroupBy is the dimension tool usage is aggregated by.
+type UsageGroupBy string
+
+const (
+	// UsageGroupByTool aggregates usage per tool, identified by its canonical name.
+	UsageGroupByTool UsageGroupBy = "tool"
+	// UsageGroupByClient aggregates usage per MCP client.
+	UsageGroupByClient UsageGroupBy = "client"
+	// UsageGroupByServer aggregates usage per upstream MCP server.
+	UsageGroupByServer UsageGroupBy = "server"
+)
+
+// UsageReport describes how often tools were called in a time range.
+type UsageReport struct {
+	Since   time.Time    `json:"since"`
+	Until   time.Time    `json:"until"`
+	GroupBy UsageGroupBy `json:"group_by"`
+
+	// Rows are sorted by the number of calls, most called first.
+	Rows []UsageRow `json:"rows"`
+
+	// UnusedTools lists the canonical names of the enabled tools that weren't called in the time range,
+	// even though they were registered before it started.
+	// These are candidates to disable.
+	UnusedTools []string `json:"unused_tools"`
+}
+
+// UsageRow is the aggregated usage of a single tool, MCP client or MCP server.
+type UsageRow struct {
+	// Name is the canonical name of the tool, the name of the MCP client or the name of the MCP server.
+	Name string `json:"name"`
+
+	Calls int64 `json:"calls"`
+
+	// Outcomes holds the number of calls per outcome, eg- "success", "error", "timeout", "rate_limited".
+	Outcomes map[string]int64 `json:"outcomes"`
+
+	// AvgDurationMs is the average duration of the calls in milliseconds.
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+
+	// LastCalledAt is the start of the most recent hour in which a call was made.
+	LastCalledAt time.Time `json:"last_called_at"`
+}
+
+// ValidateUsageGroupBy validates the input string and returns the corresponding UsageGroupBy.
+// An empty input defaults to UsageGroupByTool.
+func ValidateUsageGroupBy(input string) (UsageGroupBy, error) {
+	switch UsageGroupBy(input) {
+	case "":
+		return UsageGroupByTool, nil
+	case UsageGroupByTool, UsageGroupByClient, UsageGroupByServer:
+		return UsageGroupBy(input), nil
+	default:
+		return "", fmt.Errorf(
+			"unsupported usage grouping: %s (acceptable values: '%s', '%s', '%s')",
+			input, UsageGroupByTool, UsageGroupByClient, UsageGroupByServer,
+		)
+	}
+}